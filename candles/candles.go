@@ -0,0 +1,66 @@
+// Package candles backfills historical OHLCV bars from an exchange into a
+// local store.
+//
+// A Downloader splits a date range into requests no larger than the venue's
+// per-call limit, merges and de-duplicates the returned bars, reports bars
+// missing from the venue's history as gaps and appends the result to a Store.
+// Downloads resume from the last bar already saved, so an interrupted
+// backfill can simply be started again.
+package candles
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/neqin/futures/decimal"
+)
+
+// Candle is an OHLCV bar with exact decimal values.
+type Candle struct {
+	Time   time.Time // Bar open time
+	Open   decimal.Decimal
+	High   decimal.Decimal
+	Low    decimal.Decimal
+	Close  decimal.Decimal
+	Volume decimal.Decimal
+}
+
+// Source fetches historical candles from one venue.
+type Source interface {
+	// Limit returns the maximum number of candles a single Fetch may cover.
+	Limit() int
+	// Fetch returns the candles of contract whose open time lies within
+	// [from, to], in any order. The range never spans more than Limit bars.
+	Fetch(ctx context.Context, contract, interval string, from, to time.Time) ([]Candle, error)
+}
+
+// ParseInterval returns the duration of an interval such as "10s", "1m",
+// "4h", "1d" or "1w". Calendar months ("1M") have no fixed duration and are
+// rejected.
+func ParseInterval(interval string) (time.Duration, error) {
+	if len(interval) < 2 {
+		return 0, fmt.Errorf("candles: invalid interval %q", interval)
+	}
+	n, err := strconv.Atoi(interval[:len(interval)-1])
+	if err != nil || n <= 0 {
+		return 0, fmt.Errorf("candles: invalid interval %q", interval)
+	}
+	var unit time.Duration
+	switch interval[len(interval)-1] {
+	case 's':
+		unit = time.Second
+	case 'm':
+		unit = time.Minute
+	case 'h':
+		unit = time.Hour
+	case 'd':
+		unit = 24 * time.Hour
+	case 'w':
+		unit = 7 * 24 * time.Hour
+	default:
+		return 0, fmt.Errorf("candles: unsupported interval %q", interval)
+	}
+	return time.Duration(n) * unit, nil
+}
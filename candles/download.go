@@ -0,0 +1,121 @@
+package candles
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+)
+
+// Gap is a run of bars missing from the venue's history, from the open time
+// of the first missing bar to the open time of the last one (inclusive).
+type Gap struct {
+	From time.Time
+	To   time.Time
+}
+
+// Bars returns the number of missing bars for the given interval duration.
+func (g Gap) Bars(step time.Duration) int {
+	return int(g.To.Sub(g.From)/step) + 1
+}
+
+// Result summarizes a download.
+type Result struct {
+	From    time.Time // Start of the requested range (after resuming)
+	To      time.Time // End of the requested range (after excluding unclosed bars)
+	Candles int       // Number of bars saved
+	Gaps    []Gap     // Bars the venue did not return, in time order
+}
+
+// Downloader backfills candles from a Source into a Store.
+type Downloader struct {
+	src   Source
+	store Store
+	now   func() time.Time
+}
+
+// NewDownloader creates a downloader that saves the candles fetched from src to store.
+func NewDownloader(src Source, store Store) *Downloader {
+	return &Downloader{src: src, store: store, now: time.Now}
+}
+
+// Download saves the bars of contract with open times in [from, to] and
+// returns what was saved and which bars were missing. Bars up to the last one
+// already in the store are skipped, and bars that have not closed yet are not
+// requested, so calling Download again with the same arguments resumes or
+// extends the series. Every chunk is saved as soon as it is fetched; on error
+// the result describes the chunks saved so far.
+func (d *Downloader) Download(ctx context.Context, contract, interval string, from, to time.Time) (*Result, error) {
+	step, err := ParseInterval(interval)
+	if err != nil {
+		return nil, err
+	}
+	limit := d.src.Limit()
+	if limit <= 0 {
+		return nil, fmt.Errorf("candles: source limit must be positive, got %d", limit)
+	}
+
+	start := from
+	prev := start.Add(-step) // Open time of the previous bar, for gap detection
+	if last, ok, err := d.store.Last(contract, interval); err != nil {
+		return nil, err
+	} else if ok && !last.Before(start) {
+		start = last.Add(step)
+		prev = last
+	}
+	end := to
+	if closed := d.now().Add(-step); end.After(closed) {
+		end = closed // Do not save the bar that is still forming
+	}
+
+	res := &Result{From: start, To: end}
+	for chunkStart := start; !chunkStart.After(end); {
+		if err := ctx.Err(); err != nil {
+			return res, err
+		}
+		chunkEnd := chunkStart.Add(time.Duration(limit-1) * step)
+		if chunkEnd.After(end) {
+			chunkEnd = end
+		}
+		fetched, err := d.src.Fetch(ctx, contract, interval, chunkStart, chunkEnd)
+		if err != nil {
+			return res, fmt.Errorf("candles: fetch %s %s from %s: %w", contract, interval, chunkStart.UTC().Format(time.RFC3339), err)
+		}
+		bars := merge(fetched, chunkStart, chunkEnd)
+		if len(bars) > 0 {
+			if err := d.store.Append(contract, interval, bars); err != nil {
+				return res, err
+			}
+		}
+		for _, c := range bars {
+			if c.Time.Sub(prev) >= 2*step {
+				res.Gaps = append(res.Gaps, Gap{From: prev.Add(step), To: c.Time.Add(-step)})
+			}
+			prev = c.Time
+		}
+		res.Candles += len(bars)
+		chunkStart = chunkEnd.Add(step)
+	}
+	if missing := end.Sub(prev) / step; missing >= 1 {
+		res.Gaps = append(res.Gaps, Gap{From: prev.Add(step), To: prev.Add(missing * step)})
+	}
+	return res, nil
+}
+
+// merge returns the candles within [from, to], sorted by time with duplicate
+// open times removed (the last occurrence wins).
+func merge(candles []Candle, from, to time.Time) []Candle {
+	byTime := make(map[int64]Candle, len(candles))
+	for _, c := range candles {
+		if c.Time.Before(from) || c.Time.After(to) {
+			continue
+		}
+		byTime[c.Time.UnixNano()] = c
+	}
+	bars := make([]Candle, 0, len(byTime))
+	for _, c := range byTime {
+		bars = append(bars, c)
+	}
+	sort.Slice(bars, func(i, j int) bool { return bars[i].Time.Before(bars[j].Time) })
+	return bars
+}
@@ -0,0 +1,189 @@
+package candles_test
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/neqin/futures/candles"
+)
+
+// fakeSource serves the bars of history, newest first and with each bar
+// repeated, as venues may return overlapping pages in any order.
+type fakeSource struct {
+	limit   int
+	history []candles.Candle
+	fail    int      // Fetch call (1-based) that fails, 0 for none
+	calls   [][2]int // Bar indexes of each requested range
+}
+
+func (s *fakeSource) Limit() int { return s.limit }
+
+func (s *fakeSource) Fetch(ctx context.Context, contract, interval string, from, to time.Time) ([]candles.Candle, error) {
+	s.calls = append(s.calls, [2]int{int(from.Sub(t0) / time.Minute), int(to.Sub(t0) / time.Minute)})
+	if len(s.calls) == s.fail {
+		return nil, errors.New("venue unavailable")
+	}
+	var out []candles.Candle
+	for i := len(s.history) - 1; i >= 0; i-- {
+		if c := s.history[i]; !c.Time.Before(from.Add(-time.Minute)) && !c.Time.After(to.Add(time.Minute)) {
+			out = append(out, c, c) // Includes a bar either side of the range
+		}
+	}
+	return out, nil
+}
+
+func TestDownload(t *testing.T) {
+	type gap [2]int // First and last missing bar
+	tests := []struct {
+		name     string
+		saved    []candles.Candle // Already in the store
+		history  []candles.Candle
+		from, to int
+		fail     int
+		calls    [][2]int
+		candles  int
+		gaps     []gap
+		last     int // Last bar in the store afterwards
+	}{
+		{
+			name: "chunked", history: bars(0, 20), from: 0, to: 9,
+			calls: [][2]int{{0, 3}, {4, 7}, {8, 9}}, candles: 10, last: 9,
+		},
+		{
+			name: "leading gap", history: bars(3, 20), from: 0, to: 9,
+			calls: [][2]int{{0, 3}, {4, 7}, {8, 9}}, candles: 7, gaps: []gap{{0, 2}}, last: 9,
+		},
+		{
+			name: "trailing gap", history: bars(0, 6), from: 0, to: 9,
+			calls: [][2]int{{0, 3}, {4, 7}, {8, 9}}, candles: 7, gaps: []gap{{7, 9}}, last: 6,
+		},
+		{
+			name: "inner gap", history: append(bars(0, 3), bars(6, 9)...), from: 0, to: 9,
+			calls: [][2]int{{0, 3}, {4, 7}, {8, 9}}, candles: 8, gaps: []gap{{4, 5}}, last: 9,
+		},
+		{
+			name: "nothing returned", from: 0, to: 5,
+			calls: [][2]int{{0, 3}, {4, 5}}, gaps: []gap{{0, 5}}, last: -1,
+		},
+		{
+			name: "resumes after the last bar", saved: bars(0, 5), history: bars(0, 20), from: 0, to: 9,
+			calls: [][2]int{{6, 9}}, candles: 4, last: 9,
+		},
+		{
+			name: "resumed gap starts after the last bar", saved: bars(0, 5), history: bars(0, 6), from: 0, to: 9,
+			calls: [][2]int{{6, 9}}, candles: 1, gaps: []gap{{7, 9}}, last: 6,
+		},
+		{
+			name: "already complete", saved: bars(0, 9), history: bars(0, 20), from: 0, to: 9,
+			last: 9,
+		},
+		{
+			name: "saved bars before the range", saved: bars(0, 1), history: bars(0, 20), from: 4, to: 6,
+			calls: [][2]int{{4, 6}}, candles: 3, last: 6,
+		},
+		{
+			name: "fetch fails", history: bars(0, 20), from: 0, to: 9, fail: 2,
+			calls: [][2]int{{0, 3}, {4, 7}}, candles: 4, last: 3,
+		},
+	}
+	for _, tt := range tests {
+		store, err := candles.NewFileStore(t.TempDir())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if tt.saved != nil {
+			if err := store.Append("BTC_USDT", "1m", tt.saved); err != nil {
+				t.Fatal(err)
+			}
+		}
+		src := &fakeSource{limit: 4, history: tt.history, fail: tt.fail}
+		res, err := candles.NewDownloader(src, store).Download(context.Background(), "BTC_USDT", "1m", bar(tt.from).Time, bar(tt.to).Time)
+		if (err != nil) != (tt.fail != 0) {
+			t.Errorf("%s: error %v", tt.name, err)
+		}
+
+		if !slices.Equal(src.calls, tt.calls) {
+			t.Errorf("%s: fetched %v, want %v", tt.name, src.calls, tt.calls)
+		}
+		var gaps []gap
+		for _, g := range res.Gaps {
+			gaps = append(gaps, gap{int(g.From.Sub(t0) / time.Minute), int(g.To.Sub(t0) / time.Minute)})
+			if want := int(g.To.Sub(g.From)/time.Minute) + 1; g.Bars(time.Minute) != want {
+				t.Errorf("%s: gap %v has %d bars, want %d", tt.name, g, g.Bars(time.Minute), want)
+			}
+		}
+		if res.Candles != tt.candles || !slices.Equal(gaps, tt.gaps) {
+			t.Errorf("%s: saved %d bars with gaps %v, want %d with %v", tt.name, res.Candles, gaps, tt.candles, tt.gaps)
+		}
+
+		last, ok, err := store.Last("BTC_USDT", "1m")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if tt.last < 0 && ok || tt.last >= 0 && !last.Equal(bar(tt.last).Time) {
+			t.Errorf("%s: last saved bar %s (%t), want bar %d", tt.name, last, ok, tt.last)
+		}
+		loaded, _ := store.Load("BTC_USDT", "1m", t0, bar(100).Time)
+		for i := 1; i < len(loaded); i++ {
+			if !loaded[i].Time.After(loaded[i-1].Time) {
+				t.Errorf("%s: stored bars out of order or repeated at %s", tt.name, loaded[i].Time)
+			}
+		}
+	}
+}
+
+func TestDownloadSkipsUnclosedBar(t *testing.T) {
+	store, err := candles.NewFileStore(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	now := time.Now()
+	src := &liveSource{}
+	res, err := candles.NewDownloader(src, store).Download(context.Background(), "BTC_USDT", "1h", now.Add(-5*time.Hour), now.Add(time.Hour))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if closed := time.Now().Add(-time.Hour); res.To.After(closed) || src.latest.After(closed) {
+		t.Errorf("requested bars up to %s (range end %s), want none after %s", src.latest, res.To, closed)
+	}
+}
+
+// liveSource records the latest open time it was asked for.
+type liveSource struct {
+	latest time.Time
+}
+
+func (s *liveSource) Limit() int { return 1000 }
+
+func (s *liveSource) Fetch(ctx context.Context, contract, interval string, from, to time.Time) ([]candles.Candle, error) {
+	if to.After(s.latest) {
+		s.latest = to
+	}
+	return nil, nil
+}
+
+func TestParseInterval(t *testing.T) {
+	tests := []struct {
+		interval string
+		want     time.Duration
+	}{
+		{"10s", 10 * time.Second},
+		{"1m", time.Minute},
+		{"4h", 4 * time.Hour},
+		{"1d", 24 * time.Hour},
+		{"1w", 7 * 24 * time.Hour},
+		{"1M", 0},
+		{"0m", 0},
+		{"m", 0},
+		{"-1h", 0},
+	}
+	for _, tt := range tests {
+		got, err := candles.ParseInterval(tt.interval)
+		if got != tt.want || (err != nil) != (tt.want == 0) {
+			t.Errorf("ParseInterval(%q) = %v, %v; want %v", tt.interval, got, err, tt.want)
+		}
+	}
+}
@@ -0,0 +1,215 @@
+package candles
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/neqin/futures/decimal"
+)
+
+// Store persists candle series, one per contract and interval. Series are
+// append-only and kept in time order.
+type Store interface {
+	// Last returns the open time of the last saved bar; ok is false if the
+	// series is empty.
+	Last(contract, interval string) (last time.Time, ok bool, err error)
+	// Append saves bars, which are sorted and newer than Last.
+	Append(contract, interval string, bars []Candle) error
+}
+
+const csvHeader = "time,open,high,low,close,volume\n"
+
+// FileStore keeps each series in a CSV file named "<contract>_<interval>.csv"
+// in a directory. Rows hold the open time in Unix milliseconds followed by the
+// decimal OHLCV values exactly as received. A row left incomplete by an
+// interrupted write is discarded on the next Last or Append. A FileStore is
+// safe for concurrent use within one process.
+type FileStore struct {
+	dir string
+	mu  sync.Mutex
+}
+
+// NewFileStore creates a store in dir, creating the directory if needed.
+func NewFileStore(dir string) (*FileStore, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("candles: %w", err)
+	}
+	return &FileStore{dir: dir}, nil
+}
+
+// Path returns the file holding the series of contract and interval.
+func (s *FileStore) Path(contract, interval string) string {
+	name := strings.NewReplacer("/", "-", "\\", "-").Replace(contract + "_" + interval)
+	return filepath.Join(s.dir, name+".csv")
+}
+
+// Last returns the open time of the last saved bar.
+func (s *FileStore) Last(contract, interval string) (time.Time, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	f, err := os.OpenFile(s.Path(contract, interval), os.O_RDWR, 0)
+	if errors.Is(err, os.ErrNotExist) {
+		return time.Time{}, false, nil
+	}
+	if err != nil {
+		return time.Time{}, false, fmt.Errorf("candles: %w", err)
+	}
+	defer f.Close()
+	line, err := lastLine(f)
+	if err != nil || line == "" {
+		return time.Time{}, false, err
+	}
+	c, err := parseRow(line)
+	if err != nil {
+		return time.Time{}, false, err
+	}
+	return c.Time, true, nil
+}
+
+// Append writes bars to the end of the series.
+func (s *FileStore) Append(contract, interval string, bars []Candle) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	f, err := os.OpenFile(s.Path(contract, interval), os.O_RDWR|os.O_CREATE, 0o644)
+	if err != nil {
+		return fmt.Errorf("candles: %w", err)
+	}
+	defer f.Close()
+	if _, err := lastLine(f); err != nil { // Drops an incomplete row and positions at the end
+		return err
+	}
+	var buf bytes.Buffer
+	if off, _ := f.Seek(0, io.SeekCurrent); off == 0 {
+		buf.WriteString(csvHeader)
+	}
+	for _, c := range bars {
+		fmt.Fprintf(&buf, "%d,%s,%s,%s,%s,%s\n", c.Time.UnixMilli(), c.Open, c.High, c.Low, c.Close, c.Volume)
+	}
+	if _, err := f.Write(buf.Bytes()); err != nil {
+		return fmt.Errorf("candles: %w", err)
+	}
+	return nil
+}
+
+// Load reads the saved bars of contract with open times in [from, to].
+func (s *FileStore) Load(contract, interval string, from, to time.Time) ([]Candle, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	f, err := os.Open(s.Path(contract, interval))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("candles: %w", err)
+	}
+	defer f.Close()
+
+	var bars []Candle
+	r := bufio.NewReader(f)
+	for {
+		line, err := r.ReadString('\n')
+		if err == io.EOF {
+			return bars, nil // An unterminated last row is incomplete
+		}
+		if err != nil {
+			return nil, fmt.Errorf("candles: %w", err)
+		}
+		line = strings.TrimSuffix(line, "\n")
+		if line == "" || line+"\n" == csvHeader {
+			continue
+		}
+		c, err := parseRow(line)
+		if err != nil {
+			return nil, err
+		}
+		if c.Time.After(to) {
+			return bars, nil
+		}
+		if !c.Time.Before(from) {
+			bars = append(bars, c)
+		}
+	}
+}
+
+// lastLine returns the last complete data row of f, truncating any
+// unterminated row after it, and leaves the offset at the end of the file.
+// It returns "" if the file has no data rows.
+func lastLine(f *os.File) (string, error) {
+	size, err := f.Seek(0, io.SeekEnd)
+	if err != nil {
+		return "", fmt.Errorf("candles: %w", err)
+	}
+	const chunk = 4096
+	var tail []byte
+	for off := size; off > 0; {
+		n := int64(chunk)
+		if off < n {
+			n = off
+		}
+		off -= n
+		buf := make([]byte, n)
+		if _, err := f.ReadAt(buf, off); err != nil {
+			return "", fmt.Errorf("candles: %w", err)
+		}
+		tail = append(buf, tail...)
+		// The last complete row is between the last two newlines.
+		end := bytes.LastIndexByte(tail, '\n')
+		if end < 0 {
+			continue
+		}
+		start := bytes.LastIndexByte(tail[:end], '\n')
+		if start < 0 && off > 0 {
+			continue
+		}
+		if complete := off + int64(end) + 1; complete < size {
+			if err := f.Truncate(complete); err != nil {
+				return "", fmt.Errorf("candles: %w", err)
+			}
+			if _, err := f.Seek(complete, io.SeekStart); err != nil {
+				return "", fmt.Errorf("candles: %w", err)
+			}
+		}
+		line := string(tail[start+1 : end])
+		if line+"\n" == csvHeader {
+			return "", nil
+		}
+		return line, nil
+	}
+	// No complete row at all
+	if size > 0 {
+		if err := f.Truncate(0); err != nil {
+			return "", fmt.Errorf("candles: %w", err)
+		}
+		if _, err := f.Seek(0, io.SeekStart); err != nil {
+			return "", fmt.Errorf("candles: %w", err)
+		}
+	}
+	return "", nil
+}
+
+func parseRow(line string) (Candle, error) {
+	fields := strings.Split(line, ",")
+	if len(fields) != 6 {
+		return Candle{}, fmt.Errorf("candles: malformed row %q", line)
+	}
+	ms, err := strconv.ParseInt(fields[0], 10, 64)
+	if err != nil {
+		return Candle{}, fmt.Errorf("candles: malformed row %q: %w", line, err)
+	}
+	var v [5]decimal.Decimal
+	for i := range v {
+		if v[i], err = decimal.Parse(fields[i+1]); err != nil {
+			return Candle{}, fmt.Errorf("candles: malformed row %q: %w", line, err)
+		}
+	}
+	return Candle{Time: time.UnixMilli(ms), Open: v[0], High: v[1], Low: v[2], Close: v[3], Volume: v[4]}, nil
+}
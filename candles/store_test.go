@@ -0,0 +1,145 @@
+package candles_test
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/neqin/futures/candles"
+	"github.com/neqin/futures/decimal"
+)
+
+var t0 = time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+
+// bar returns the i-th one-minute bar after t0.
+func bar(i int) candles.Candle {
+	v := decimal.NewFromInt(int64(100 + i))
+	return candles.Candle{Time: t0.Add(time.Duration(i) * time.Minute), Open: v, High: v, Low: v, Close: v, Volume: decimal.NewFromInt(1)}
+}
+
+func bars(from, to int) []candles.Candle {
+	var out []candles.Candle
+	for i := from; i <= to; i++ {
+		out = append(out, bar(i))
+	}
+	return out
+}
+
+func row(i int) string {
+	return fmt.Sprintf("%d,%d,%d,%d,%d,1\n", t0.Add(time.Duration(i)*time.Minute).UnixMilli(), 100+i, 100+i, 100+i, 100+i)
+}
+
+func rows(from, to int) string {
+	var b strings.Builder
+	for i := from; i <= to; i++ {
+		b.WriteString(row(i))
+	}
+	return b.String()
+}
+
+const header = "time,open,high,low,close,volume\n"
+
+func TestFileStoreIncompleteRows(t *testing.T) {
+	tests := []struct {
+		name    string
+		missing bool   // No file at all
+		content string // Initial file content
+		last    int    // Index of the last complete bar, -1 if none
+		kept    string // File content after Last
+	}{
+		{"no file", true, "", -1, ""},
+		{"empty file", false, "", -1, ""},
+		{"partial header", false, "time,op", -1, ""},
+		{"header only", false, header, -1, header},
+		{"complete rows", false, header + rows(0, 2), 2, header + rows(0, 2)},
+		{"partial row", false, header + rows(0, 2) + row(3)[:10], 2, header + rows(0, 2)},
+		{"partial first row", false, header + row(0)[:5], -1, header},
+		{"rows across read chunks", false, header + rows(0, 299) + row(300)[:20], 299, header + rows(0, 299)},
+	}
+	for _, tt := range tests {
+		store, err := candles.NewFileStore(t.TempDir())
+		if err != nil {
+			t.Fatal(err)
+		}
+		path := store.Path("BTC_USDT", "1m")
+		if !tt.missing {
+			if err := os.WriteFile(path, []byte(tt.content), 0o644); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		last, ok, err := store.Last("BTC_USDT", "1m")
+		switch {
+		case err != nil:
+			t.Errorf("%s: Last: %v", tt.name, err)
+		case tt.last < 0 && ok:
+			t.Errorf("%s: Last = %s, want an empty series", tt.name, last)
+		case tt.last >= 0 && (!ok || !last.Equal(bar(tt.last).Time)):
+			t.Errorf("%s: Last = %s (%t), want %s", tt.name, last, ok, bar(tt.last).Time)
+		}
+		if data, _ := os.ReadFile(path); string(data) != tt.kept {
+			t.Errorf("%s: file after Last = %q, want %q", tt.name, data, tt.kept)
+		}
+
+		// Appending continues after the last complete row.
+		if err := store.Append("BTC_USDT", "1m", bars(tt.last+1, tt.last+2)); err != nil {
+			t.Fatal(err)
+		}
+		loaded, err := store.Load("BTC_USDT", "1m", t0, t0.Add(time.Hour*24))
+		if err != nil {
+			t.Fatalf("%s: Load: %v", tt.name, err)
+		}
+		if len(loaded) != tt.last+3 || !loaded[len(loaded)-1].Time.Equal(bar(tt.last+2).Time) {
+			t.Errorf("%s: loaded %d bars after Append, want %d ending at bar %d", tt.name, len(loaded), tt.last+3, tt.last+2)
+		}
+	}
+}
+
+func TestFileStoreLoad(t *testing.T) {
+	store, err := candles.NewFileStore(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if loaded, err := store.Load("BTC_USDT", "1m", t0, t0.Add(time.Hour)); err != nil || loaded != nil {
+		t.Errorf("missing series: %v, %v", loaded, err)
+	}
+	// An unterminated row left by an interrupted write is not loaded.
+	if err := os.WriteFile(store.Path("BTC_USDT", "1m"), []byte(header+rows(0, 9)+row(10)[:15]), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		from, to int
+		want     []candles.Candle
+	}{
+		{0, 9, bars(0, 9)},
+		{3, 5, bars(3, 5)},
+		{8, 20, bars(8, 9)},
+		{-5, 1, bars(0, 1)},
+		{11, 20, nil},
+	}
+	for _, tt := range tests {
+		loaded, err := store.Load("BTC_USDT", "1m", bar(tt.from).Time, bar(tt.to).Time)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(loaded) != len(tt.want) {
+			t.Errorf("Load(%d, %d): %d bars, want %d", tt.from, tt.to, len(loaded), len(tt.want))
+			continue
+		}
+		for i, c := range loaded {
+			w := tt.want[i]
+			if !c.Time.Equal(w.Time) || !c.Close.Equal(w.Close) || !c.Volume.Equal(w.Volume) {
+				t.Errorf("Load(%d, %d)[%d] = %+v, want %+v", tt.from, tt.to, i, c, w)
+			}
+		}
+	}
+
+	if err := os.WriteFile(store.Path("ETH_USDT", "1m"), []byte(header+"1714521600000,1,2\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := store.Load("ETH_USDT", "1m", t0, t0.Add(time.Hour)); err == nil {
+		t.Error("malformed row loaded without error")
+	}
+}
@@ -0,0 +1,113 @@
+// Package clock estimates the offset between the local clock and an
+// exchange's clock so that signed request timestamps match server time even
+// when the local clock drifts.
+//
+// Each sample pairs a server timestamp with the local send and receive times
+// of the request that produced it; the server time is assumed to correspond
+// to the midpoint of the round trip. Samples are smoothed with an
+// exponentially weighted moving average so a single slow response does not
+// move the offset much.
+package clock
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+const (
+	defaultSmoothing = 0.25            // Weight of a new sample in the moving average
+	maxRoundTrip     = 5 * time.Second // Samples with a slower round trip are discarded
+)
+
+// Offset is a smoothed estimate of server time minus local time. The zero
+// value is not usable; create one with NewOffset. An Offset is safe for
+// concurrent use.
+type Offset struct {
+	mu      sync.RWMutex
+	offset  time.Duration
+	samples int
+
+	errs chan error
+}
+
+// NewOffset creates an Offset with no samples (offset 0).
+func NewOffset() *Offset {
+	return &Offset{errs: make(chan error, 16)}
+}
+
+// Now returns the current time adjusted to the server's clock.
+func (o *Offset) Now() time.Time {
+	return time.Now().Add(o.Offset())
+}
+
+// Offset returns the current estimate of server time minus local time.
+func (o *Offset) Offset() time.Duration {
+	o.mu.RLock()
+	defer o.mu.RUnlock()
+	return o.offset
+}
+
+// Samples returns the number of samples observed so far.
+func (o *Offset) Samples() int {
+	o.mu.RLock()
+	defer o.mu.RUnlock()
+	return o.samples
+}
+
+// Observe records a server timestamp received for a request sent at sent and
+// answered at received (both local times). Samples with a round trip longer
+// than 5s are ignored.
+func (o *Offset) Observe(sent, received, server time.Time) {
+	rtt := received.Sub(sent)
+	if rtt < 0 || rtt > maxRoundTrip || server.IsZero() {
+		return
+	}
+	sample := server.Sub(sent.Add(rtt / 2))
+
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	if o.samples == 0 {
+		o.offset = sample
+	} else {
+		o.offset += time.Duration(defaultSmoothing * float64(sample-o.offset))
+	}
+	o.samples++
+}
+
+// Sample fetches the server time once and records it.
+func (o *Offset) Sample(ctx context.Context, fetch func(context.Context) (time.Time, error)) error {
+	sent := time.Now()
+	server, err := fetch(ctx)
+	if err != nil {
+		return err
+	}
+	o.Observe(sent, time.Now(), server)
+	return nil
+}
+
+// Run samples the server time immediately and then every interval until ctx
+// is done, and then returns ctx.Err(). Sampling errors are reported on Errors.
+func (o *Offset) Run(ctx context.Context, interval time.Duration, fetch func(context.Context) (time.Time, error)) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		if err := o.Sample(ctx, fetch); err != nil && ctx.Err() == nil {
+			select {
+			case o.errs <- err:
+			default:
+			}
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// Errors returns a channel of sampling errors raised by Run. Errors are
+// dropped if the channel is not drained.
+func (o *Offset) Errors() <-chan error {
+	return o.errs
+}
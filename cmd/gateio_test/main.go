@@ -66,7 +66,7 @@ func testPublicMethods(ctx context.Context, client *gateio.Client) {
 	if err != nil {
 		log.Printf("ERROR fetching contract stats for %s: %v\n", contractName, err)
 	} else if stats != nil && len(*stats) > 0 {
-		log.Printf("OK: Fetched %d stats entries for %s. First entry time: %d, MarkPrice: %f\n", len(*stats), contractName, (*stats)[0].Time, (*stats)[0].MarkPrice)
+		log.Printf("OK: Fetched %d stats entries for %s. First entry time: %d, MarkPrice: %s\n", len(*stats), contractName, (*stats)[0].Time, (*stats)[0].MarkPrice)
 	} else {
 		log.Printf("WARN: Fetched contract stats for %s, but list is empty or nil.\n", contractName)
 	}
@@ -106,7 +106,7 @@ func testPublicMethods(ctx context.Context, client *gateio.Client) {
 	if err != nil {
 		log.Printf("ERROR fetching candlesticks for %s: %v\n", contractName, err)
 	} else if candles != nil && len(*candles) > 0 {
-		log.Printf("OK: Fetched %d candlesticks for %s. First candle timestamp: %d, Open: %f\n", len(*candles), contractName, (*candles)[0].Timestamp, (*candles)[0].Open)
+		log.Printf("OK: Fetched %d candlesticks for %s. First candle timestamp: %d, Open: %s\n", len(*candles), contractName, (*candles)[0].Timestamp, (*candles)[0].Open)
 	} else {
 		log.Printf("WARN: Fetched candlesticks for %s, but list is empty or nil.\n", contractName)
 	}
@@ -119,7 +119,7 @@ func testPublicMethods(ctx context.Context, client *gateio.Client) {
 	if err != nil {
 		log.Printf("ERROR fetching premium index for %s: %v\n", contractName, err)
 	} else if premiumIndex != nil && len(*premiumIndex) > 0 {
-		log.Printf("OK: Fetched %d premium index entries for %s. First entry timestamp: %d, MarkPrice: %f\n", len(*premiumIndex), contractName, (*premiumIndex)[0].Timestamp, (*premiumIndex)[0].MarkPrice)
+		log.Printf("OK: Fetched %d premium index entries for %s. First entry timestamp: %d, MarkPrice: %s\n", len(*premiumIndex), contractName, (*premiumIndex)[0].Timestamp, (*premiumIndex)[0].MarkPrice)
 	} else {
 		log.Printf("WARN: Fetched premium index for %s, but list is empty or nil.\n", contractName)
 	}
@@ -266,6 +266,134 @@ func (c *Client) ListPositionCloseHistory(ctx context.Context, settle string, co
 	return &result, nil
 }
 
+// ListLiquidates lists the liquidation history of the current user.
+// settle: "usdt" or "btc"
+// contract: Filter by contract name (optional)
+// limit: Maximum number of records. Default 100, Max 1000.
+// offset: List offset (optional)
+// from: Start timestamp (seconds) (optional)
+// to: End timestamp (seconds) (optional)
+// at: Return only the record liquidated at this timestamp (seconds) (optional)
+func (c *Client) ListLiquidates(ctx context.Context, settle string, contract *string, limit, offset *int, from, to, at *int64) (*GetLiquidationHistoryResult, error) {
+	endpoint := fmt.Sprintf("/futures/%s/liquidates", settle)
+	params := url.Values{}
+	if contract != nil {
+		params.Set("contract", *contract)
+	}
+	if limit != nil {
+		params.Set("limit", strconv.Itoa(*limit))
+	}
+	if offset != nil {
+		params.Set("offset", strconv.Itoa(*offset))
+	}
+	if from != nil {
+		params.Set("from", strconv.FormatInt(*from, 10))
+	}
+	if to != nil {
+		params.Set("to", strconv.FormatInt(*to, 10))
+	}
+	if at != nil {
+		params.Set("at", strconv.FormatInt(*at, 10))
+	}
+
+	var result GetLiquidationHistoryResult
+	err := c.get(ctx, endpoint, params, &result)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// ListAutoDeleverages lists the auto-deleverage (ADL) history of the current user.
+// settle: "usdt" or "btc"
+// contract: Filter by contract name (optional)
+// limit: Maximum number of records. Default 100, Max 1000.
+// offset: List offset (optional)
+// from: Start timestamp (seconds) (optional)
+// to: End timestamp (seconds) (optional)
+// at: Return only the record deleveraged at this timestamp (seconds) (optional)
+func (c *Client) ListAutoDeleverages(ctx context.Context, settle string, contract *string, limit, offset *int, from, to, at *int64) (*ListAutoDeleveragesResult, error) {
+	endpoint := fmt.Sprintf("/futures/%s/auto_deleverages", settle)
+	params := url.Values{}
+	if contract != nil {
+		params.Set("contract", *contract)
+	}
+	if limit != nil {
+		params.Set("limit", strconv.Itoa(*limit))
+	}
+	if offset != nil {
+		params.Set("offset", strconv.Itoa(*offset))
+	}
+	if from != nil {
+		params.Set("from", strconv.FormatInt(*from, 10))
+	}
+	if to != nil {
+		params.Set("to", strconv.FormatInt(*to, 10))
+	}
+	if at != nil {
+		params.Set("at", strconv.FormatInt(*at, 10))
+	}
+
+	var result ListAutoDeleveragesResult
+	err := c.get(ctx, endpoint, params, &result)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// GetFuturesFee retrieves the personal trading fee rates.
+// settle: "usdt" or "btc"
+// contract: Return only the rates of this contract (optional). All contracts are returned if omitted.
+func (c *Client) GetFuturesFee(ctx context.Context, settle string, contract *string) (*GetFuturesFeeResult, error) {
+	endpoint := fmt.Sprintf("/futures/%s/fee", settle)
+	params := url.Values{}
+	if contract != nil {
+		params.Set("contract", *contract)
+	}
+
+	var result GetFuturesFeeResult
+	err := c.get(ctx, endpoint, params, &result)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// ListFuturesOrdersByTimeRange retrieves orders created within a time range, open or finished.
+// settle: "usdt" or "btc"
+// contract: Filter by contract name (optional)
+// from: Start timestamp (seconds) (optional)
+// to: End timestamp (seconds) (optional)
+// limit: Maximum number of records. Default 100, Max 1000.
+// offset: List offset (optional)
+func (c *Client) ListFuturesOrdersByTimeRange(ctx context.Context, settle string, contract *string, from, to *int64, limit, offset *int) (*[]FuturesOrder, error) {
+	endpoint := fmt.Sprintf("/futures/%s/orders_timerange", settle)
+	params := url.Values{}
+	if contract != nil {
+		params.Set("contract", *contract)
+	}
+	if from != nil {
+		params.Set("from", strconv.FormatInt(*from, 10))
+	}
+	if to != nil {
+		params.Set("to", strconv.FormatInt(*to, 10))
+	}
+	if limit != nil {
+		params.Set("limit", strconv.Itoa(*limit))
+	}
+	if offset != nil {
+		params.Set("offset", strconv.Itoa(*offset))
+	}
+
+	var result []FuturesOrder
+	err := c.get(ctx, endpoint, params, &result)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
 // --- Moved from market_public.go as they require authentication ---
 
 // ListDualCompContracts retrieves list of dual swap contracts. (Requires Auth)
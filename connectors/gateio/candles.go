@@ -0,0 +1,52 @@
+package gateio
+
+import (
+	"context"
+	"time"
+
+	"github.com/neqin/futures/candles"
+	"github.com/neqin/futures/decimal"
+)
+
+// candlesPerRequest is the maximum number of candlesticks returned for a from/to range.
+const candlesPerRequest = 2000
+
+// CandleSource implements candles.Source with ListFuturesCandlesticks for one
+// settlement currency.
+type CandleSource struct {
+	client *Client
+	settle string
+}
+
+// NewCandleSource creates a candle source for contracts settled in settle ("usdt" or "btc").
+func NewCandleSource(client *Client, settle string) *CandleSource {
+	return &CandleSource{client: client, settle: settle}
+}
+
+// Limit returns the maximum number of candles per request.
+func (s *CandleSource) Limit() int {
+	return candlesPerRequest
+}
+
+// Fetch returns the candles of contract opened within [from, to]. Volume is
+// the traded size in contracts.
+func (s *CandleSource) Fetch(ctx context.Context, contract, interval string, from, to time.Time) ([]candles.Candle, error) {
+	fromSec, toSec := from.Unix(), to.Unix()
+	// limit conflicts with from/to, so only the range is sent
+	res, err := s.client.ListFuturesCandlesticks(ctx, s.settle, contract, nil, &interval, &fromSec, &toSec)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]candles.Candle, 0, len(*res))
+	for _, c := range *res {
+		result = append(result, candles.Candle{
+			Time:   time.Unix(c.Timestamp, 0),
+			Open:   c.Open,
+			High:   c.High,
+			Low:    c.Low,
+			Close:  c.Close,
+			Volume: decimal.NewFromInt(c.Volume),
+		})
+	}
+	return result, nil
+}
@@ -3,17 +3,21 @@ package gateio
 import (
 	"bytes"
 	"context"
-	"crypto/hmac"
-	"crypto/sha512"
-	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
-	"log"
+	"log/slog"
 	"net/http"
 	"net/url"
 	"strings"
 	"time"
+
+	"github.com/neqin/futures/clock"
+	"github.com/neqin/futures/credentials"
+	"github.com/neqin/futures/httplog"
+	"github.com/neqin/futures/ratelimit"
+	"github.com/neqin/futures/retry"
 )
 
 const (
@@ -23,10 +27,17 @@ const (
 
 // Client is the main Gate.io API client.
 type Client struct {
-	apiKey     string
-	secretKey  string
-	baseURL    string
-	httpClient *http.Client
+	credentials credentials.Provider // Key pair consulted per request; nil for public-only clients
+	ownsCreds   bool                 // Whether Close closes credentials
+	signer      Signer               // Custom signer; nil signs with the credentials' secret
+	baseURL     string
+	httpClient  *http.Client
+	clock       *clock.Offset
+	limiter     *ratelimit.Limiter
+	retryPolicy retry.Policy
+	log         *httplog.Logger
+	validation  *OrderValidation // Pre-trade checks for CreateFuturesOrder; nil disables them
+	contracts   *contractCache   // Contract specs used by the pre-trade checks
 }
 
 // NewClient creates a new Gate.io API client.
@@ -36,46 +47,115 @@ func NewClient(apiKey, secretKey string, httpClient *http.Client) *Client {
 	if httpClient == nil {
 		httpClient = &http.Client{Timeout: 10 * time.Second} // Default timeout
 	}
-	return &Client{
-		baseURL:    defaultBaseURL,
-		apiKey:     apiKey,
-		secretKey:  secretKey,
-		httpClient: httpClient,
+	c := &Client{
+		baseURL:     defaultBaseURL,
+		httpClient:  httpClient,
+		clock:       clock.NewOffset(),
+		limiter:     ratelimit.New(DefaultRateLimits()),
+		retryPolicy: retry.DefaultPolicy(),
+		log:         httplog.New("gateio"),
+		contracts:   newContractCache(),
+	}
+	if apiKey != "" || secretKey != "" {
+		c.credentials, c.ownsCreds = credentials.NewStatic(apiKey, secretKey), true
 	}
+	return c
 }
 
 // SetBaseURL allows overriding the default base URL (e.g., for testing environments).
 func (c *Client) SetBaseURL(baseURL string) {
 	c.baseURL = strings.TrimSuffix(baseURL, "/")
-	log.Printf("Gate Client Base URL set to: %s", c.baseURL)
+	c.log.Info("base URL set", "url", c.baseURL)
 }
 
-// generateSignature creates the HMAC SHA512 signature for Gate.io API v4 private requests.
-func (c *Client) generateSignature(method, path, query, body string, timestamp string) string {
-	// Hash the body using SHA512
-	bodyHash := sha512.New()
-	bodyHash.Write([]byte(body))
-	hashedPayload := hex.EncodeToString(bodyHash.Sum(nil))
+// SetLogger replaces the logger of requests and configuration changes
+// (slog.Default() by default). Successful requests are logged at Debug level,
+// failed ones at Warn level; see package httplog. A nil logger disables
+// logging.
+func (c *Client) SetLogger(logger *slog.Logger) {
+	c.log.SetLogger(logger)
+}
+
+// SetLogBodies enables logging of request and response bodies, redacted and
+// truncated to maxBytes (in full if negative). 0, the default, disables it.
+func (c *Client) SetLogBodies(maxBytes int) {
+	c.log.SetMaxBody(maxBytes)
+}
+
+// SetClock replaces the clock offset used to timestamp signed requests, e.g. to
+// share one offset between several clients. The offset is updated from the
+// server-time headers of every response.
+func (c *Client) SetClock(offset *clock.Offset) {
+	c.clock = offset
+}
+
+// Clock returns the clock offset used to timestamp signed requests.
+func (c *Client) Clock() *clock.Offset {
+	return c.clock
+}
 
-	// Create the signature string
-	// METHOD\nURL_PATH\nQUERY_STRING\nHASHED_REQUEST_PAYLOAD\nTIMESTAMP
-	signStr := fmt.Sprintf("%s\n%s\n%s\n%s\n%s", method, path, query, hashedPayload, timestamp)
+// SetRateLimiter replaces the client-side rate limiter (DefaultRateLimits by default).
+// Share one limiter between clients that use the same API key or IP. A nil limiter disables throttling.
+func (c *Client) SetRateLimiter(limiter *ratelimit.Limiter) {
+	c.limiter = limiter
+}
+
+// SetSigner replaces the signer of private requests, e.g. to sign with a key
+// held by an external service. By default requests are signed with the secret
+// of the credentials; a nil signer restores that. Requests are signed when
+// the credentials hold an API key and a secret or a signer is set.
+func (c *Client) SetSigner(signer Signer) {
+	c.signer = signer
+}
+
+// SetCredentials replaces the source of the API key pair, which is consulted
+// for every request so keys can be rotated without recreating the client. The
+// caller keeps ownership of provider and closes it; the provider created from
+// the keys passed to NewClient is closed by Close. A nil provider makes the
+// client public-only.
+func (c *Client) SetCredentials(provider credentials.Provider) {
+	if c.ownsCreds {
+		c.credentials.Close()
+	}
+	c.credentials, c.ownsCreds = provider, false
+}
+
+// Close zeroes the secret key passed to NewClient. Requests fail afterwards
+// with credentials.ErrClosed without being sent.
+func (c *Client) Close() error {
+	if c.ownsCreds {
+		return c.credentials.Close()
+	}
+	return nil
+}
 
-	// Sign using HMAC-SHA512
-	mac := hmac.New(sha512.New, []byte(c.secretKey))
-	mac.Write([]byte(signStr))
-	signature := hex.EncodeToString(mac.Sum(nil))
+// currentCredentials returns the key pair for a request, nil for a
+// public-only client. It fails once the provider is closed, so a closed
+// client sends nothing. The caller zeroes it when done.
+func (c *Client) currentCredentials(ctx context.Context) (*credentials.Credentials, error) {
+	if c.credentials == nil {
+		return nil, nil
+	}
+	creds, err := c.credentials.Credentials(ctx)
+	if err != nil {
+		return nil, retry.Permanent(fmt.Errorf("failed to get credentials: %w", err))
+	}
+	return creds, nil
+}
 
-	// log.Printf("Gate Sign String: %s", signStr) // Debugging
-	// log.Printf("Gate Hashed Payload: %s", hashedPayload) // Debugging
-	// log.Printf("Gate Signature: %s", signature) // Debugging
-	return signature
+// SetRetryPolicy replaces the retry policy (retry.DefaultPolicy by default).
+// Order creation is only retried when the order carries a client order ID (Text).
+// A nil policy disables retries.
+func (c *Client) SetRetryPolicy(policy retry.Policy) {
+	if policy == nil {
+		policy = retry.Never
+	}
+	c.retryPolicy = policy
 }
 
 // sendRequest creates, signs (if private), and sends an HTTP request.
+// Failed attempts are retried according to the client's retry policy.
 func (c *Client) sendRequest(ctx context.Context, method, endpointPath string, queryParams url.Values, bodyPayload interface{}, target interface{}) error {
-	isPrivate := c.apiKey != "" && c.secretKey != ""
-
 	// Prepare URL
 	fullURL := c.baseURL + apiPrefix + endpointPath
 	queryString := ""
@@ -87,7 +167,6 @@ func (c *Client) sendRequest(ctx context.Context, method, endpointPath string, q
 	}
 
 	// Prepare Body
-	var bodyReader io.Reader
 	var bodyBytes []byte
 	var err error
 
@@ -96,16 +175,85 @@ func (c *Client) sendRequest(ctx context.Context, method, endpointPath string, q
 		if err != nil {
 			return fmt.Errorf("failed to marshal request body: %w", err)
 		}
-		bodyReader = bytes.NewReader(bodyBytes)
 	} else {
 		// Ensure bodyBytes is an empty slice, not nil, for hashing
 		bodyBytes = []byte{}
 	}
 
+	attempt := retry.Attempt{
+		Method:     method,
+		Path:       endpointPath,
+		Idempotent: isIdempotent(method, bodyPayload),
+	}
+	for {
+		attempt.Number++
+		statusCode, header, responseBody, err := c.doRequest(ctx, method, endpointPath, fullURL, queryString, bodyPayload != nil, bodyBytes)
+
+		// Throttling, credential and signing failures are local; resending cannot help.
+		if errors.Is(err, ratelimit.ErrRateLimited) || retry.IsPermanent(err) {
+			return err
+		}
+		attempt.StatusCode, attempt.Err, attempt.RetryAfter = statusCode, err, 0
+		if header != nil {
+			attempt.RetryAfter = retry.ParseRetryAfter(header.Get("Retry-After"), time.Now())
+		}
+		if delay, ok := c.retryPolicy.Next(&attempt); ok && (err != nil || statusCode >= 400) {
+			if sleepErr := retry.Sleep(ctx, delay); sleepErr == nil {
+				continue
+			}
+		}
+		if err != nil {
+			return err
+		}
+
+		// Handle Errors
+		if statusCode >= 400 {
+			var apiErr APIError
+			err = json.Unmarshal(responseBody, &apiErr)
+			if err == nil && apiErr.Label != "" {
+				// Return the structured API error
+				return apiErr
+			}
+			// Return a generic error if parsing fails or it's not the expected format
+			return fmt.Errorf("API error: status %d, body: %s", statusCode, string(responseBody))
+		}
+
+		// Unmarshal Success Response
+		if target != nil {
+			err = json.Unmarshal(responseBody, target)
+			if err != nil {
+				return fmt.Errorf("failed to unmarshal response body into target: %w (body: %s)", err, string(responseBody))
+			}
+		}
+
+		return nil
+	}
+}
+
+// doRequest performs a single signed attempt and returns the raw response.
+// A non-nil error means no usable response was received.
+func (c *Client) doRequest(ctx context.Context, method, endpointPath, fullURL, queryString string, hasBody bool, bodyBytes []byte) (int, http.Header, []byte, error) {
+	creds, err := c.currentCredentials(ctx)
+	if err != nil {
+		return 0, nil, nil, err
+	}
+	defer creds.Zero()
+	isPrivate := canSign(c.signer, creds)
+
+	// Throttle before doing any work; fails fast if ctx's deadline cannot be met
+	if err := c.limiter.Wait(ctx, endpointGroup(method, endpointPath)); err != nil {
+		return 0, nil, nil, err
+	}
+
+	var bodyReader io.Reader
+	if hasBody {
+		bodyReader = bytes.NewReader(bodyBytes)
+	}
+
 	// Create Request
 	req, err := http.NewRequestWithContext(ctx, method, fullURL, bodyReader)
 	if err != nil {
-		return fmt.Errorf("failed to create request: %w", err)
+		return 0, nil, nil, fmt.Errorf("failed to create request: %w", err)
 	}
 
 	// Set Headers
@@ -114,62 +262,83 @@ func (c *Client) sendRequest(ctx context.Context, method, endpointPath string, q
 		req.Header.Set("Content-Type", "application/json")
 	}
 
-	// Add Authentication Headers if private
+	// Add Authentication Headers if private (signed per attempt so the timestamp is fresh)
 	if isPrivate {
-		if c.apiKey == "" || c.secretKey == "" {
-			return fmt.Errorf("API key and secret key must be provided for private endpoints")
+		timestamp := fmt.Sprintf("%d", c.clock.Now().Unix())
+		signature, err := sign(ctx, c.signer, creds, RequestPayload(method, apiPrefix+endpointPath, queryString, string(bodyBytes), timestamp))
+		if err != nil {
+			return 0, nil, nil, retry.Permanent(fmt.Errorf("failed to sign request: %w", err))
 		}
-		timestamp := fmt.Sprintf("%d", time.Now().Unix())
-		signature := c.generateSignature(method, apiPrefix+endpointPath, queryString, string(bodyBytes), timestamp)
 
-		req.Header.Set("KEY", c.apiKey)
+		req.Header.Set("KEY", creds.APIKey)
 		req.Header.Set("Timestamp", timestamp)
 		req.Header.Set("SIGN", signature)
 	}
 
-	// log.Printf("[GATE.IO:%s] %s", method, fullURL) // Debugging request URL
-
 	// Send Request
+	entry := httplog.Entry{Method: method, Path: endpointPath, Query: queryString, RequestBody: bodyBytes}
+	sent := time.Now()
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
-		return fmt.Errorf("failed to send request: %w", err)
+		entry.Latency, entry.Err = time.Since(sent), err
+		c.log.Request(ctx, entry)
+		return 0, nil, nil, fmt.Errorf("failed to send request: %w", err)
 	}
 	defer resp.Body.Close()
+	c.clock.Observe(sent, time.Now(), serverTimeFromHeader(resp.Header))
 
 	// Read Response Body
 	responseBody, err := io.ReadAll(resp.Body)
+	entry.Latency, entry.Status, entry.ResponseBody, entry.Err = time.Since(sent), resp.StatusCode, responseBody, err
+	if resp.StatusCode >= 400 && c.log.Enabled(ctx, slog.LevelWarn) {
+		var apiErr APIError
+		if json.Unmarshal(responseBody, &apiErr) == nil {
+			entry.ErrorCode = apiErr.Label
+		}
+	}
+	c.log.Request(ctx, entry)
 	if err != nil {
-		return fmt.Errorf("failed to read response body: %w", err)
+		return 0, nil, nil, fmt.Errorf("failed to read response body: %w", err)
 	}
 
-	// log.Printf("Gate Response Status: %s", resp.Status) // Debugging response status
-	// if len(responseBody) < 1000 { // Avoid logging huge responses
-	// 	log.Printf("Gate Response Body: %s", string(responseBody))
-	// } else {
-	// 	log.Printf("Gate Response Body: (omitted, length %d)", len(responseBody))
-	// }
+	return resp.StatusCode, resp.Header, responseBody, nil
+}
 
-	// Handle Errors
-	if resp.StatusCode >= 400 {
-		var apiErr APIError
-		err = json.Unmarshal(responseBody, &apiErr)
-		if err == nil && apiErr.Label != "" {
-			// Return the structured API error
-			return apiErr
+// clientOrderIDer is implemented by request bodies that carry a client order
+// ID (Text), which makes re-sending them after an ambiguous failure safe.
+type clientOrderIDer interface {
+	clientOrderID() string
+}
+
+// isIdempotent reports whether a request may be repeated without side
+// effects: reads and cancellations always, creations only with a client order ID.
+func isIdempotent(method string, bodyPayload interface{}) bool {
+	switch method {
+	case http.MethodGet, http.MethodDelete:
+		return true
+	case http.MethodPost:
+		if b, ok := bodyPayload.(clientOrderIDer); ok {
+			return b.clientOrderID() != ""
 		}
-		// Return a generic error if parsing fails or it's not the expected format
-		return fmt.Errorf("API error: status %d, body: %s", resp.StatusCode, string(responseBody))
 	}
+	return false
+}
 
-	// Unmarshal Success Response
-	if target != nil {
-		err = json.Unmarshal(responseBody, target)
-		if err != nil {
-			return fmt.Errorf("failed to unmarshal response body into target: %w (body: %s)", err, string(responseBody))
+func (r CreateFuturesOrderRequest) clientOrderID() string { return r.Text }
+
+func (r CreateTriggerOrderRequest) clientOrderID() string { return r.Initial.Text }
+
+// clientOrderID of a batch is only set if every order in it carries one.
+func (r BatchCreateFuturesOrdersRequest) clientOrderID() string {
+	if len(r) == 0 {
+		return ""
+	}
+	for _, o := range r {
+		if o.Text == "" {
+			return ""
 		}
 	}
-
-	return nil
+	return r[0].Text
 }
 
 // --- Helper methods for different request types ---
@@ -0,0 +1,353 @@
+package gateio_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/neqin/futures/connectors/gateio"
+	"github.com/neqin/futures/connectors/gateio/gateiotest"
+	"github.com/neqin/futures/credentials"
+	"github.com/neqin/futures/decimal"
+	"github.com/neqin/futures/exchange"
+	"github.com/neqin/futures/paper"
+	"github.com/neqin/futures/retry"
+)
+
+const settle = "usdt"
+
+// newServer starts a mock with a BTC_USDT contract quoted 100.0 / 100.5.
+func newServer(t *testing.T) (*gateiotest.Server, *gateio.Client) {
+	t.Helper()
+	srv := gateiotest.NewServer(paper.Config{
+		Balance:  decimal.NewFromInt(1000),
+		MakerFee: decimal.MustParse("0.0002"),
+		TakerFee: decimal.MustParse("0.0005"),
+	})
+	t.Cleanup(srv.Close)
+	srv.AddContract(gateio.Ticker{
+		Name:             "BTC_USDT",
+		OrderPriceRound:  decimal.MustParse("0.1"),
+		QuantoMultiplier: decimal.MustParse("0.01"),
+		OrderSizeMax:     100000,
+	})
+	setBook(t, srv, 100, 100.5)
+	client := srv.Client()
+	client.SetRetryPolicy(&retry.Backoff{MaxAttempts: 3, BaseDelay: time.Millisecond, MaxDelay: time.Millisecond})
+	return srv, client
+}
+
+func setBook(t *testing.T, srv *gateiotest.Server, bid, ask float64) {
+	t.Helper()
+	err := srv.SetOrderBook(&exchange.OrderBook{
+		Symbol: "BTC_USDT",
+		Bids:   []exchange.OrderBookLevel{{Price: bid, Size: 50}, {Price: bid - 1, Size: 50}},
+		Asks:   []exchange.OrderBookLevel{{Price: ask, Size: 50}, {Price: ask + 1, Size: 50}},
+	})
+	if err != nil {
+		t.Fatalf("SetOrderBook: %v", err)
+	}
+}
+
+func ptr[T any](v T) *T { return &v }
+
+func TestSignatureRejected(t *testing.T) {
+	srv, _ := newServer(t)
+	ctx := context.Background()
+
+	for name, client := range map[string]*gateio.Client{
+		"wrong secret": gateio.NewClient(gateiotest.APIKey, "not-the-secret", nil),
+		"wrong key":    gateio.NewClient("not-the-key", gateiotest.SecretKey, nil),
+	} {
+		client.SetBaseURL(srv.URL)
+		_, err := client.GetFuturesAccount(ctx, settle)
+		if !errors.Is(err, exchange.ErrAuth) {
+			t.Errorf("%s: got %v, want ErrAuth", name, err)
+		}
+	}
+
+	skewed := srv.Client()
+	now := time.Now()
+	skewed.Clock().Observe(now, now, now.Add(-5*time.Minute))
+	_, err := skewed.GetFuturesAccount(ctx, settle)
+	var apiErr gateio.APIError
+	if !errors.As(err, &apiErr) || apiErr.Label != "REQUEST_EXPIRED" {
+		t.Errorf("skewed clock: got %v, want REQUEST_EXPIRED", err)
+	}
+}
+
+func TestMarketData(t *testing.T) {
+	_, client := newServer(t)
+	ctx := context.Background()
+
+	contracts, err := client.ListFuturesContracts(ctx, settle)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(*contracts) != 1 || (*contracts)[0].Name != "BTC_USDT" {
+		t.Fatalf("contracts = %+v", *contracts)
+	}
+
+	book, err := gateio.NewExchange(client, settle).OrderBook(ctx, "BTC_USDT", 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(book.Bids) != 1 || book.Bids[0].Price != 100 || book.Asks[0].Price != 100.5 {
+		t.Errorf("book = %+v", book)
+	}
+}
+
+func TestOrderLifecycle(t *testing.T) {
+	srv, client := newServer(t)
+	ctx := context.Background()
+
+	order, err := client.CreateFuturesOrder(ctx, settle, gateio.CreateFuturesOrderRequest{
+		Contract: "BTC_USDT", Size: 10, Price: ptr("99"), Text: "t-entry",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if order.Status != "open" || order.Left != 10 {
+		t.Fatalf("new order = %+v", order)
+	}
+
+	open, err := client.ListFuturesOrders(ctx, settle, "open", ptr("BTC_USDT"), nil, nil, nil, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(*open) != 1 || (*open)[0].ID != order.ID {
+		t.Fatalf("open orders = %+v", *open)
+	}
+
+	amended, err := client.AmendFuturesOrder(ctx, settle, "t-entry", nil, ptr("99.5"), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if amended.Price.Cmp(decimal.MustParse("99.5")) != 0 {
+		t.Errorf("amended price = %s", amended.Price)
+	}
+
+	// The ask drops through the resting bid, which fills at its limit price as maker.
+	setBook(t, srv, 98.5, 99)
+	filled, err := client.GetFuturesOrder(ctx, settle, strconv.FormatInt(order.ID, 10))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if filled.Status != "finished" || filled.FinishAs != "filled" || filled.FillPrice.Cmp(decimal.MustParse("99.5")) != 0 {
+		t.Fatalf("filled order = %+v", filled)
+	}
+
+	pos, err := client.GetPosition(ctx, settle, "BTC_USDT")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pos.Size != 10 || pos.EntryPrice.Cmp(decimal.MustParse("99.5")) != 0 {
+		t.Errorf("position = %+v", pos)
+	}
+
+	_, err = client.CancelFuturesOrder(ctx, settle, strconv.FormatInt(order.ID, 10))
+	if !errors.Is(err, exchange.ErrOrderNotFound) {
+		t.Errorf("cancel of filled order: got %v, want ErrOrderNotFound", err)
+	}
+
+	// Close at market: sells 10 contracts into the 98.5 bid.
+	closed, err := client.CreateFuturesOrder(ctx, settle, gateio.CreateFuturesOrderRequest{
+		Contract: "BTC_USDT", Size: 0, Close: true, Tif: "ioc",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if closed.Status != "finished" || closed.Size != -10 {
+		t.Errorf("close order = %+v", closed)
+	}
+	account, err := client.GetFuturesAccount(ctx, settle)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// PnL 10 * 0.01 * (98.5 - 99.5) = -0.1; fees 0.00199 maker + 0.004925 taker.
+	if want := decimal.MustParse("999.893085"); account.Total.Cmp(want) != 0 {
+		t.Errorf("account total = %s, want %s", account.Total, want)
+	}
+}
+
+func TestOrderRejections(t *testing.T) {
+	_, client := newServer(t)
+	ctx := context.Background()
+
+	_, err := client.CreateFuturesOrder(ctx, settle, gateio.CreateFuturesOrderRequest{
+		Contract: "BTC_USDT", Size: 20000, Price: ptr("100.5"),
+	})
+	if !errors.Is(err, exchange.ErrInsufficientBalance) {
+		t.Errorf("oversized order: got %v, want ErrInsufficientBalance", err)
+	}
+
+	_, err = client.CreateFuturesOrder(ctx, settle, gateio.CreateFuturesOrderRequest{
+		Contract: "ETH_USDT", Size: 1, Price: ptr("100"),
+	})
+	if !errors.Is(err, exchange.ErrInvalidParameter) {
+		t.Errorf("unknown contract: got %v, want ErrInvalidParameter", err)
+	}
+
+	order, err := client.CreateFuturesOrder(ctx, settle, gateio.CreateFuturesOrderRequest{
+		Contract: "BTC_USDT", Size: 1, Price: ptr("101"), Tif: "poc",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if order.Status != "finished" || order.FinishAs != "poc" {
+		t.Errorf("crossing post-only order = %+v", order)
+	}
+}
+
+func TestExchangeRejectsFractionalSize(t *testing.T) {
+	srv, client := newServer(t)
+	ex := gateio.NewExchange(client, settle)
+	for _, size := range []float64{1.6, 0.4, 0, -2} {
+		_, err := ex.PlaceOrder(context.Background(), exchange.OrderRequest{
+			Symbol: "BTC_USDT", Side: exchange.Buy, Type: exchange.Limit, Size: size, Price: 100,
+		})
+		if !errors.Is(err, exchange.ErrInvalidParameter) {
+			t.Errorf("size %v: got %v, want ErrInvalidParameter", size, err)
+		}
+	}
+	if n := len(srv.Requests()); n != 0 {
+		t.Errorf("%d requests sent for invalid sizes, want none", n)
+	}
+}
+
+func TestTriggerOrder(t *testing.T) {
+	srv, client := newServer(t)
+	ctx := context.Background()
+
+	if _, err := client.CreateFuturesOrder(ctx, settle, gateio.CreateFuturesOrderRequest{Contract: "BTC_USDT", Size: 5, Tif: "ioc"}); err != nil {
+		t.Fatal(err)
+	}
+	created, err := client.CreateTriggerOrder(ctx, settle, gateio.CreateTriggerOrderRequest{
+		Initial: gateio.FuturesOrder{Contract: "BTC_USDT", Close: true, Tif: "ioc"},
+		Trigger: gateio.Trigger{Price: decimal.MustParse("95"), Rule: 2},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	setBook(t, srv, 94, 94.5)
+	trigger, err := client.GetTriggerOrder(ctx, settle, strconv.FormatInt(created.ID, 10))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if trigger.Status != "finished" {
+		t.Fatalf("trigger = %+v", trigger)
+	}
+	positions, err := client.ListPositions(ctx, settle, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(*positions) != 0 {
+		t.Errorf("positions after stop = %+v", *positions)
+	}
+}
+
+func TestClosedClient(t *testing.T) {
+	srv, client := newServer(t)
+	ctx := context.Background()
+
+	if err := client.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.GetFuturesAccount(ctx, settle); !errors.Is(err, credentials.ErrClosed) {
+		t.Errorf("private request after Close: %v, want ErrClosed", err)
+	}
+	if _, err := client.ListFuturesContracts(ctx, settle); !errors.Is(err, credentials.ErrClosed) {
+		t.Errorf("public request after Close: %v, want ErrClosed", err)
+	}
+	if n := len(srv.Requests()); n != 0 {
+		t.Errorf("closed client sent %d requests", n)
+	}
+}
+
+func TestFaultInjection(t *testing.T) {
+	srv, client := newServer(t)
+	ctx := context.Background()
+
+	srv.InjectFault(gateiotest.Fault{Method: http.MethodGet, Path: "/futures/usdt/accounts", Status: http.StatusServiceUnavailable, Times: 2})
+	if _, err := client.GetFuturesAccount(ctx, settle); err != nil {
+		t.Fatalf("GetFuturesAccount after two 503s: %v", err)
+	}
+	if n := len(srv.Requests()); n != 3 {
+		t.Errorf("requests = %d, want 3 (two retries)", n)
+	}
+
+	srv.InjectFault(gateiotest.Fault{Path: "/futures/usdt/positions", Status: http.StatusTooManyRequests, Label: "TOO_MANY_REQUESTS"})
+	client.SetRetryPolicy(nil)
+	_, err := client.ListPositions(ctx, settle, nil)
+	if !errors.Is(err, exchange.ErrRateLimit) {
+		t.Errorf("got %v, want ErrRateLimit", err)
+	}
+
+	// A 503 on order creation without a client order ID is not retried.
+	srv.ClearFaults()
+	client.SetRetryPolicy(retry.DefaultPolicy())
+	before := len(srv.Requests())
+	srv.InjectFault(gateiotest.Fault{Method: http.MethodPost, Path: "/futures/usdt/orders", Status: http.StatusServiceUnavailable, Times: 1})
+	if _, err := client.CreateFuturesOrder(ctx, settle, gateio.CreateFuturesOrderRequest{Contract: "BTC_USDT", Size: 1, Price: ptr("99")}); err == nil {
+		t.Error("order creation succeeded despite the 503")
+	}
+	if n := len(srv.Requests()) - before; n != 1 {
+		t.Errorf("order creation attempts = %d, want 1", n)
+	}
+}
+
+// countingProvider counts the key pair lookups of a client and fails them
+// with err if set.
+type countingProvider struct {
+	credentials.Provider
+	calls int
+	err   error
+}
+
+func (p *countingProvider) Credentials(ctx context.Context) (*credentials.Credentials, error) {
+	p.calls++
+	if p.err != nil {
+		return nil, p.err
+	}
+	return p.Provider.Credentials(ctx)
+}
+
+func TestLocalFailuresNotRetried(t *testing.T) {
+	srv, client := newServer(t)
+	ctx := context.Background()
+	provider := &countingProvider{Provider: credentials.NewStatic(gateiotest.APIKey, gateiotest.SecretKey)}
+	client.SetCredentials(provider)
+
+	if _, err := client.GetFuturesAccount(ctx, settle); err != nil {
+		t.Fatal(err)
+	}
+	if provider.calls != 1 {
+		t.Errorf("successful request looked up the key pair %d times, want 1", provider.calls)
+	}
+
+	provider.calls, provider.err = 0, errors.New("vault unavailable")
+	_, err := client.GetFuturesAccount(ctx, settle)
+	if !errors.Is(err, provider.err) || !retry.IsPermanent(err) {
+		t.Errorf("provider failure: got %v, want it returned as permanent", err)
+	}
+	if provider.calls != 1 {
+		t.Errorf("provider failure retried: %d lookups, want 1", provider.calls)
+	}
+
+	provider.calls, provider.err = 0, nil
+	signErr := errors.New("signing service unavailable")
+	client.SetSigner(gateio.SignerFunc(func(context.Context, string) (string, error) { return "", signErr }))
+	if _, err := client.GetFuturesAccount(ctx, settle); !errors.Is(err, signErr) {
+		t.Errorf("signer failure: got %v, want %v", err, signErr)
+	}
+	if provider.calls != 1 {
+		t.Errorf("signer failure retried: %d attempts, want 1", provider.calls)
+	}
+	if n := len(srv.Requests()); n != 1 {
+		t.Errorf("server saw %d requests, want only the successful one", n)
+	}
+}
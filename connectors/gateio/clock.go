@@ -0,0 +1,27 @@
+package gateio
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// serverTimeFromHeader extracts the server time from a Gate.io response.
+// X-In-Time and X-Out-Time carry the microsecond timestamps at which the
+// request was received and the response sent; their midpoint is used. The
+// one-second Date header, centred within its second, is the fallback. It returns the zero time if
+// neither is present.
+func serverTimeFromHeader(h http.Header) time.Time {
+	in, inErr := strconv.ParseInt(h.Get("X-In-Time"), 10, 64)
+	out, outErr := strconv.ParseInt(h.Get("X-Out-Time"), 10, 64)
+	switch {
+	case inErr == nil && outErr == nil:
+		return time.UnixMicro((in + out) / 2)
+	case outErr == nil:
+		return time.UnixMicro(out)
+	}
+	if t, err := http.ParseTime(h.Get("Date")); err == nil {
+		return t.Add(500 * time.Millisecond) // Date is truncated to the second
+	}
+	return time.Time{}
+}
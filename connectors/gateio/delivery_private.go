@@ -0,0 +1,420 @@
+package gateio
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+)
+
+// GetDeliveryAccount retrieves the delivery account details for a specific settlement currency.
+// settle: "usdt"
+func (c *Client) GetDeliveryAccount(ctx context.Context, settle string) (*FuturesAccount, error) {
+	endpoint := fmt.Sprintf("/delivery/%s/accounts", settle)
+	var result FuturesAccount
+	err := c.get(ctx, endpoint, nil, &result)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// ListDeliveryAccountBook retrieves the delivery account ledger.
+// settle: "usdt"
+// limit: Maximum number of records. Default 100, Max 1000.
+// from: Start timestamp (seconds) (optional)
+// to: End timestamp (seconds) (optional)
+// typeFilter: Filter by entry type (dnw, pnl, fee, refr, fund, point_dnw, point_fee, point_refr) (optional)
+func (c *Client) ListDeliveryAccountBook(ctx context.Context, settle string, limit *int, from, to *int64, typeFilter *string) (*ListFuturesAccountBookResult, error) {
+	endpoint := fmt.Sprintf("/delivery/%s/account_book", settle)
+	params := url.Values{}
+	if limit != nil {
+		params.Set("limit", strconv.Itoa(*limit))
+	}
+	if from != nil {
+		params.Set("from", strconv.FormatInt(*from, 10))
+	}
+	if to != nil {
+		params.Set("to", strconv.FormatInt(*to, 10))
+	}
+	if typeFilter != nil {
+		params.Set("type", *typeFilter)
+	}
+
+	var result ListFuturesAccountBookResult
+	err := c.get(ctx, endpoint, params, &result)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// --- Positions ---
+
+// ListDeliveryPositions retrieves all delivery positions for the user.
+// settle: "usdt"
+func (c *Client) ListDeliveryPositions(ctx context.Context, settle string) (*[]Position, error) {
+	endpoint := fmt.Sprintf("/delivery/%s/positions", settle)
+	var result []Position
+	err := c.get(ctx, endpoint, nil, &result)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// GetDeliveryPosition retrieves details for a single delivery position.
+// settle: "usdt"
+// contract: Delivery contract name
+func (c *Client) GetDeliveryPosition(ctx context.Context, settle, contract string) (*Position, error) {
+	endpoint := fmt.Sprintf("/delivery/%s/positions/%s", settle, contract)
+	var result Position
+	err := c.get(ctx, endpoint, nil, &result)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// UpdateDeliveryPositionMargin updates the margin for a specific delivery position.
+// settle: "usdt"
+// contract: Delivery contract name
+// change: The amount to change the margin by (positive to add, negative to reduce).
+func (c *Client) UpdateDeliveryPositionMargin(ctx context.Context, settle, contract, change string) (*Position, error) {
+	endpoint := fmt.Sprintf("/delivery/%s/positions/%s/margin", settle, contract)
+	params := url.Values{}
+	params.Set("change", change)
+	var result Position
+	// Note: API uses POST for this, but query parameters.
+	err := c.post(ctx, endpoint, params, nil, &result)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// UpdateDeliveryPositionLeverage updates the leverage for a specific delivery position.
+// settle: "usdt"
+// contract: Delivery contract name
+// leverage: The new leverage value (e.g., "10"). "0" means cross margin.
+func (c *Client) UpdateDeliveryPositionLeverage(ctx context.Context, settle, contract, leverage string) (*Position, error) {
+	endpoint := fmt.Sprintf("/delivery/%s/positions/%s/leverage", settle, contract)
+	params := url.Values{}
+	params.Set("leverage", leverage)
+	var result Position
+	// Note: API uses POST for this, but query parameters.
+	err := c.post(ctx, endpoint, params, nil, &result)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// UpdateDeliveryPositionRiskLimit updates the risk limit for a specific delivery position.
+// settle: "usdt"
+// contract: Delivery contract name
+// riskLimit: The new risk limit value.
+func (c *Client) UpdateDeliveryPositionRiskLimit(ctx context.Context, settle, contract, riskLimit string) (*Position, error) {
+	endpoint := fmt.Sprintf("/delivery/%s/positions/%s/risk_limit", settle, contract)
+	params := url.Values{}
+	params.Set("risk_limit", riskLimit)
+	var result Position
+	// Note: API uses POST for this, but query parameters.
+	err := c.post(ctx, endpoint, params, nil, &result)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// --- Orders ---
+
+// CreateDeliveryOrder places a new delivery order.
+// settle: "usdt"
+// order: The order details defined in CreateFuturesOrderRequest.
+func (c *Client) CreateDeliveryOrder(ctx context.Context, settle string, order CreateFuturesOrderRequest) (*FuturesOrder, error) {
+	endpoint := fmt.Sprintf("/delivery/%s/orders", settle)
+	var result FuturesOrder
+	err := c.post(ctx, endpoint, nil, order, &result)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// ListDeliveryOrders retrieves a list of delivery orders.
+// settle: "usdt"
+// status: Filter by order status ("open" or "finished") (required)
+// contract: Filter by contract name (optional)
+// limit: Maximum number of records. Default 100, Max 1000.
+// offset: List offset.
+// lastID: Specify the last order ID seen for pagination (alternative to offset).
+func (c *Client) ListDeliveryOrders(ctx context.Context, settle, status string, contract *string, limit, offset *int, lastID *string) (*[]FuturesOrder, error) {
+	endpoint := fmt.Sprintf("/delivery/%s/orders", settle)
+	params := url.Values{}
+	params.Set("status", status)
+	if contract != nil {
+		params.Set("contract", *contract)
+	}
+	if limit != nil {
+		params.Set("limit", strconv.Itoa(*limit))
+	}
+	if offset != nil {
+		params.Set("offset", strconv.Itoa(*offset))
+	}
+	if lastID != nil {
+		params.Set("last_id", *lastID)
+	}
+
+	var result []FuturesOrder
+	err := c.get(ctx, endpoint, params, &result)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// CancelAllDeliveryOrders cancels all open orders in a delivery contract.
+// settle: "usdt"
+// contract: Delivery contract name (required)
+// side: Optional side filter ("bid" or "ask")
+func (c *Client) CancelAllDeliveryOrders(ctx context.Context, settle, contract string, side *string) (*BatchCancelOrdersResult, error) {
+	endpoint := fmt.Sprintf("/delivery/%s/orders", settle)
+	params := url.Values{}
+	params.Set("contract", contract)
+	if side != nil {
+		params.Set("side", *side)
+	}
+
+	var result BatchCancelOrdersResult
+	// Note: API uses DELETE with query parameters for this.
+	err := c.delete(ctx, endpoint, params, nil, &result)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// GetDeliveryOrder retrieves details of a single delivery order.
+// settle: "usdt"
+// orderID: The ID of the order to retrieve.
+func (c *Client) GetDeliveryOrder(ctx context.Context, settle, orderID string) (*FuturesOrder, error) {
+	endpoint := fmt.Sprintf("/delivery/%s/orders/%s", settle, orderID)
+	var result FuturesOrder
+	err := c.get(ctx, endpoint, nil, &result)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// CancelDeliveryOrder cancels a single delivery order by ID.
+// settle: "usdt"
+// orderID: The ID of the order to cancel.
+func (c *Client) CancelDeliveryOrder(ctx context.Context, settle, orderID string) (*CancelOrderResult, error) {
+	endpoint := fmt.Sprintf("/delivery/%s/orders/%s", settle, orderID)
+	var result CancelOrderResult
+	err := c.delete(ctx, endpoint, nil, nil, &result)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// ListMyDeliveryTrades retrieves personal delivery trading history.
+// settle: "usdt"
+// contract: Filter by contract name (optional)
+// orderID: Filter by order ID (optional)
+// limit: Maximum number of records. Default 100, Max 1000.
+// offset: List offset.
+// lastID: Specify the last trade ID seen for pagination.
+func (c *Client) ListMyDeliveryTrades(ctx context.Context, settle string, contract, orderID *string, limit, offset *int, lastID *string) (*ListFuturesTradesResult, error) {
+	endpoint := fmt.Sprintf("/delivery/%s/my_trades", settle)
+	params := url.Values{}
+	if contract != nil {
+		params.Set("contract", *contract)
+	}
+	if orderID != nil {
+		params.Set("order", *orderID) // API uses 'order' param for order_id filter
+	}
+	if limit != nil {
+		params.Set("limit", strconv.Itoa(*limit))
+	}
+	if offset != nil {
+		params.Set("offset", strconv.Itoa(*offset))
+	}
+	if lastID != nil {
+		params.Set("last_id", *lastID)
+	}
+
+	var result ListFuturesTradesResult
+	err := c.get(ctx, endpoint, params, &result)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// --- History ---
+
+// ListDeliveryPositionCloseHistory lists the history of closed delivery positions.
+// settle: "usdt"
+// contract: Filter by contract name (optional)
+// limit: Maximum number of records. Default 100, Max 1000.
+func (c *Client) ListDeliveryPositionCloseHistory(ctx context.Context, settle string, contract *string, limit *int) (*ListPositionCloseResult, error) {
+	endpoint := fmt.Sprintf("/delivery/%s/position_close", settle)
+	params := url.Values{}
+	if contract != nil {
+		params.Set("contract", *contract)
+	}
+	if limit != nil {
+		params.Set("limit", strconv.Itoa(*limit))
+	}
+
+	var result ListPositionCloseResult
+	err := c.get(ctx, endpoint, params, &result)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// ListDeliveryLiquidates lists the user's delivery liquidation history.
+// settle: "usdt"
+// contract: Filter by contract name (optional)
+// limit: Maximum number of records. Default 100, Max 1000.
+// at: Return only the record liquidated at this timestamp (seconds) (optional)
+func (c *Client) ListDeliveryLiquidates(ctx context.Context, settle string, contract *string, limit *int, at *int64) (*GetLiquidationHistoryResult, error) {
+	endpoint := fmt.Sprintf("/delivery/%s/liquidates", settle)
+	params := url.Values{}
+	if contract != nil {
+		params.Set("contract", *contract)
+	}
+	if limit != nil {
+		params.Set("limit", strconv.Itoa(*limit))
+	}
+	if at != nil {
+		params.Set("at", strconv.FormatInt(*at, 10))
+	}
+
+	var result GetLiquidationHistoryResult
+	err := c.get(ctx, endpoint, params, &result)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// ListDeliverySettlements lists the user's delivery settlement history.
+// settle: "usdt"
+// contract: Filter by contract name (optional)
+// limit: Maximum number of records. Default 100, Max 1000.
+// at: Return only the record settled at this timestamp (seconds) (optional)
+func (c *Client) ListDeliverySettlements(ctx context.Context, settle string, contract *string, limit *int, at *int64) (*ListDeliverySettlementsResult, error) {
+	endpoint := fmt.Sprintf("/delivery/%s/settlements", settle)
+	params := url.Values{}
+	if contract != nil {
+		params.Set("contract", *contract)
+	}
+	if limit != nil {
+		params.Set("limit", strconv.Itoa(*limit))
+	}
+	if at != nil {
+		params.Set("at", strconv.FormatInt(*at, 10))
+	}
+
+	var result ListDeliverySettlementsResult
+	err := c.get(ctx, endpoint, params, &result)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// --- Price Triggered Orders ---
+
+// CreateDeliveryTriggerOrder creates a new price-triggered (conditional) delivery order.
+// settle: "usdt"
+// order: The trigger order details defined in CreateTriggerOrderRequest.
+func (c *Client) CreateDeliveryTriggerOrder(ctx context.Context, settle string, order CreateTriggerOrderRequest) (*TriggerOrder, error) {
+	// The API requires the settle parameter to be part of the request body for trigger orders.
+	order.Settle = settle
+	endpoint := fmt.Sprintf("/delivery/%s/price_orders", settle)
+	var result TriggerOrder
+	err := c.post(ctx, endpoint, nil, order, &result)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// ListDeliveryTriggerOrders retrieves a list of price-triggered delivery orders.
+// settle: "usdt"
+// status: Filter by status ("open", "finished") (required)
+// contract: Filter by contract name (optional)
+// limit: Maximum number of records. Default 100, Max 1000.
+// offset: List offset.
+func (c *Client) ListDeliveryTriggerOrders(ctx context.Context, settle, status string, contract *string, limit, offset *int) (*ListPriceTriggeredOrdersResult, error) {
+	endpoint := fmt.Sprintf("/delivery/%s/price_orders", settle)
+	params := url.Values{}
+	params.Set("status", status)
+	if contract != nil {
+		params.Set("contract", *contract)
+	}
+	if limit != nil {
+		params.Set("limit", strconv.Itoa(*limit))
+	}
+	if offset != nil {
+		params.Set("offset", strconv.Itoa(*offset))
+	}
+
+	var result ListPriceTriggeredOrdersResult
+	err := c.get(ctx, endpoint, params, &result)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// CancelAllDeliveryTriggerOrders cancels all open trigger orders in a delivery contract.
+// settle: "usdt"
+// contract: Delivery contract name (required)
+func (c *Client) CancelAllDeliveryTriggerOrders(ctx context.Context, settle, contract string) (*ListPriceTriggeredOrdersResult, error) {
+	endpoint := fmt.Sprintf("/delivery/%s/price_orders", settle)
+	params := url.Values{}
+	params.Set("contract", contract)
+
+	var result ListPriceTriggeredOrdersResult
+	// Note: API uses DELETE with query parameters.
+	err := c.delete(ctx, endpoint, params, nil, &result)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// GetDeliveryTriggerOrder retrieves details of a single price-triggered delivery order.
+// settle: "usdt"
+// orderID: The ID of the trigger order.
+func (c *Client) GetDeliveryTriggerOrder(ctx context.Context, settle, orderID string) (*PriceTriggeredOrder, error) {
+	endpoint := fmt.Sprintf("/delivery/%s/price_orders/%s", settle, orderID)
+	var result PriceTriggeredOrder
+	err := c.get(ctx, endpoint, nil, &result)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// CancelDeliveryTriggerOrder cancels a single price-triggered delivery order by ID.
+// settle: "usdt"
+// orderID: The ID of the trigger order to cancel.
+func (c *Client) CancelDeliveryTriggerOrder(ctx context.Context, settle, orderID string) (*CancelPriceTriggeredOrderResult, error) {
+	endpoint := fmt.Sprintf("/delivery/%s/price_orders/%s", settle, orderID)
+	var result CancelPriceTriggeredOrderResult
+	err := c.delete(ctx, endpoint, nil, nil, &result)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
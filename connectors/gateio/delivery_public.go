@@ -0,0 +1,178 @@
+package gateio
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+)
+
+// ListDeliveryContracts retrieves all delivery (dated) futures contracts.
+// settle: "usdt"
+func (c *Client) ListDeliveryContracts(ctx context.Context, settle string) (*ListDeliveryContractsResult, error) {
+	endpoint := fmt.Sprintf("/delivery/%s/contracts", settle)
+	var result ListDeliveryContractsResult
+	err := c.get(ctx, endpoint, nil, &result)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// GetDeliveryContract retrieves a single delivery contract.
+// settle: "usdt"
+// contract: Delivery contract name, e.g. BTC_USDT_20241227
+func (c *Client) GetDeliveryContract(ctx context.Context, settle, contract string) (*DeliveryContract, error) {
+	endpoint := fmt.Sprintf("/delivery/%s/contracts/%s", settle, contract)
+	var result DeliveryContract
+	err := c.get(ctx, endpoint, nil, &result)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// ListDeliveryOrderBook retrieves a delivery contract order book.
+// settle: "usdt"
+// contract: Delivery contract name
+// interval: Order book depth aggregation interval. '0' means no aggregation. Allowed values: 0, 0.1, 0.01
+// limit: Maximum number of order depth data in asks or bids. Default is 10, max 50
+// withID: Whether the order book ID will be returned. Default is false
+func (c *Client) ListDeliveryOrderBook(ctx context.Context, settle, contract string, interval *string, limit *int, withID *bool) (*FutureOrderBook, error) {
+	endpoint := fmt.Sprintf("/delivery/%s/order_book", settle)
+	params := url.Values{}
+	params.Set("contract", contract)
+	if interval != nil {
+		params.Set("interval", *interval)
+	}
+	if limit != nil {
+		params.Set("limit", strconv.Itoa(*limit))
+	}
+	if withID != nil {
+		params.Set("with_id", strconv.FormatBool(*withID))
+	}
+
+	var orderBook FutureOrderBook
+	err := c.get(ctx, endpoint, params, &orderBook)
+	if err != nil {
+		return nil, err
+	}
+	orderBook.Contract = contract // Add contract name to the result for context
+	return &orderBook, nil
+}
+
+// ListDeliveryTrades retrieves delivery contract trading history.
+// settle: "usdt"
+// contract: Delivery contract name
+// limit: Maximum number of records to be returned. Default is 100, max 1000
+// lastID: Specify the starting point for this list based on the last retrieved ID
+// from: Start timestamp of the query (seconds)
+// to: End timestamp of the query (seconds)
+func (c *Client) ListDeliveryTrades(ctx context.Context, settle, contract string, limit *int, lastID *string, from, to *int64) (*ListFuturesTradesResult, error) {
+	endpoint := fmt.Sprintf("/delivery/%s/trades", settle)
+	params := url.Values{}
+	params.Set("contract", contract)
+	if limit != nil {
+		params.Set("limit", strconv.Itoa(*limit))
+	}
+	if lastID != nil {
+		params.Set("last_id", *lastID)
+	}
+	if from != nil {
+		params.Set("from", strconv.FormatInt(*from, 10))
+	}
+	if to != nil {
+		params.Set("to", strconv.FormatInt(*to, 10))
+	}
+
+	var result ListFuturesTradesResult
+	err := c.get(ctx, endpoint, params, &result)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// ListDeliveryCandlesticks retrieves delivery contract candlestick data.
+// settle: "usdt"
+// contract: Delivery contract name
+// limit: Maximum number of records to be returned. Default is 100, max 2000
+// interval: Interval time between candlesticks. Allowed values: 10s, 30s, 1m, 5m, 15m, 30m, 1h, 2h, 4h, 6h, 8h, 12h, 1d, 7d, 1w, 30d
+// from: Start timestamp of the query (seconds)
+// to: End timestamp of the query (seconds)
+func (c *Client) ListDeliveryCandlesticks(ctx context.Context, settle, contract string, limit *int, interval *string, from, to *int64) (*ListFuturesCandlesticksResult, error) {
+	endpoint := fmt.Sprintf("/delivery/%s/candlesticks", settle)
+	params := url.Values{}
+	params.Set("contract", contract)
+	if limit != nil {
+		params.Set("limit", strconv.Itoa(*limit))
+	}
+	if interval != nil {
+		params.Set("interval", *interval)
+	}
+	if from != nil {
+		params.Set("from", strconv.FormatInt(*from, 10))
+	}
+	if to != nil {
+		params.Set("to", strconv.FormatInt(*to, 10))
+	}
+
+	var result ListFuturesCandlesticksResult
+	err := c.get(ctx, endpoint, params, &result)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// ListDeliveryTickers retrieves delivery contract tickers.
+// settle: "usdt"
+// contract: Delivery contract name (optional)
+func (c *Client) ListDeliveryTickers(ctx context.Context, settle string, contract *string) (*ListDeliveryTickersResult, error) {
+	endpoint := fmt.Sprintf("/delivery/%s/tickers", settle)
+	params := url.Values{}
+	if contract != nil {
+		params.Set("contract", *contract)
+	}
+
+	var result ListDeliveryTickersResult
+	err := c.get(ctx, endpoint, params, &result)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// ListDeliveryInsuranceLedger retrieves the delivery insurance balance history.
+// settle: "usdt"
+// limit: Maximum number of records to be returned. Default is 100, max 1000
+func (c *Client) ListDeliveryInsuranceLedger(ctx context.Context, settle string, limit *int) (*ListFuturesInsuranceLedgerResult, error) {
+	endpoint := fmt.Sprintf("/delivery/%s/insurance", settle)
+	params := url.Values{}
+	if limit != nil {
+		params.Set("limit", strconv.Itoa(*limit))
+	}
+
+	var result ListFuturesInsuranceLedgerResult
+	err := c.get(ctx, endpoint, params, &result)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// GetDeliveryRiskLimitTiers retrieves the risk limit tiers of a delivery contract.
+// settle: "usdt"
+// contract: Delivery contract name
+func (c *Client) GetDeliveryRiskLimitTiers(ctx context.Context, settle, contract string) (*GetRiskLimitTiersResult, error) {
+	endpoint := fmt.Sprintf("/delivery/%s/risk_limit_tiers", settle)
+	params := url.Values{}
+	params.Set("contract", contract)
+
+	var result GetRiskLimitTiersResult
+	err := c.get(ctx, endpoint, params, &result)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
@@ -0,0 +1,54 @@
+package gateio
+
+import "github.com/neqin/futures/exchange"
+
+// labelClasses maps documented Gate.io error labels to the shared error classes.
+var labelClasses = map[string]error{
+	"INVALID_KEY":             exchange.ErrAuth,
+	"INVALID_SIGNATURE":       exchange.ErrAuth,
+	"INVALID_CREDENTIALS":     exchange.ErrAuth,
+	"MISSING_REQUIRED_HEADER": exchange.ErrAuth,
+	"REQUEST_EXPIRED":         exchange.ErrAuth,
+	"IP_FORBIDDEN":            exchange.ErrAuth,
+	"READ_ONLY":               exchange.ErrAuth,
+	"FORBIDDEN":               exchange.ErrAuth,
+	"ACCOUNT_LOCKED":          exchange.ErrAuth,
+
+	"TOO_MANY_REQUESTS": exchange.ErrRateLimit,
+
+	"BALANCE_NOT_ENOUGH":        exchange.ErrInsufficientBalance,
+	"INSUFFICIENT_AVAILABLE":    exchange.ErrInsufficientBalance,
+	"MARGIN_BALANCE_NOT_ENOUGH": exchange.ErrInsufficientBalance,
+	"INSUFFICIENT_BALANCE":      exchange.ErrInsufficientBalance,
+
+	"ORDER_NOT_FOUND":      exchange.ErrOrderNotFound,
+	"AUTO_ORDER_NOT_FOUND": exchange.ErrOrderNotFound,
+	"ORDER_FINISHED":       exchange.ErrOrderNotFound,
+
+	"INVALID_PARAM_VALUE":    exchange.ErrInvalidParameter,
+	"INVALID_PROTOCOL":       exchange.ErrInvalidParameter,
+	"INVALID_ARGUMENT":       exchange.ErrInvalidParameter,
+	"INVALID_REQUEST_BODY":   exchange.ErrInvalidParameter,
+	"MISSING_REQUIRED_PARAM": exchange.ErrInvalidParameter,
+	"BAD_REQUEST":            exchange.ErrInvalidParameter,
+	"INVALID_CONTRACT":       exchange.ErrInvalidParameter,
+	"CONTRACT_NOT_FOUND":     exchange.ErrInvalidParameter,
+	"INVALID_ORDER_SIZE":     exchange.ErrInvalidParameter,
+	"INVALID_PRICE":          exchange.ErrInvalidParameter,
+	"ORDER_SIZE_TOO_SMALL":   exchange.ErrInvalidParameter,
+	"ORDER_PRICE_TOO_HIGH":   exchange.ErrInvalidParameter,
+	"ORDER_PRICE_TOO_LOW":    exchange.ErrInvalidParameter,
+}
+
+// Class returns the shared error class of the error's label (e.g.
+// exchange.ErrOrderNotFound), or nil if the label is not recognised.
+func (e APIError) Class() error {
+	return labelClasses[e.Label]
+}
+
+// Is reports whether the error belongs to the given shared error class, for
+// use with errors.Is.
+func (e APIError) Is(target error) bool {
+	class := e.Class()
+	return class != nil && class == target
+}
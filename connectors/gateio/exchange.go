@@ -0,0 +1,367 @@
+package gateio
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/neqin/futures/decimal"
+	"github.com/neqin/futures/exchange"
+)
+
+// Exchange adapts a Client to the venue-agnostic exchange.Exchange interface.
+// All calls are made against a single settlement currency.
+type Exchange struct {
+	client *Client
+	settle string
+}
+
+var _ exchange.Exchange = (*Exchange)(nil)
+
+// NewExchange wraps client as an exchange.Exchange for the given settle currency ("usdt" or "btc").
+func NewExchange(client *Client, settle string) *Exchange {
+	return &Exchange{client: client, settle: settle}
+}
+
+// Name returns the venue identifier.
+func (e *Exchange) Name() string {
+	return "gateio"
+}
+
+// Client returns the underlying Gate.io client.
+func (e *Exchange) Client() *Client {
+	return e.client
+}
+
+// --- exchange.MarketData ---
+
+// Ticker returns the latest ticker for a contract.
+func (e *Exchange) Ticker(ctx context.Context, symbol string) (*exchange.Ticker, error) {
+	contract := contractName(symbol)
+	tickers, err := e.client.ListFuturesTickers(ctx, e.settle, &contract)
+	if err != nil {
+		return nil, err
+	}
+	if tickers == nil || len(*tickers) == 0 {
+		return nil, fmt.Errorf("no ticker returned for %s", contract)
+	}
+	t := (*tickers)[0]
+	result := &exchange.Ticker{
+		Symbol:      symbol,
+		Last:        t.Last.Float64(),
+		MarkPrice:   t.MarkPrice.Float64(),
+		IndexPrice:  t.IndexPrice.Float64(),
+		High24h:     t.High24H.Float64(),
+		Low24h:      t.Low24H.Float64(),
+		Volume24h:   t.Volume24H.Float64(),
+		FundingRate: t.FundingRate.Float64(),
+		Time:        time.Now(),
+	}
+	if t.HighestBid != nil {
+		result.Bid = t.HighestBid.Float64()
+	}
+	if t.LowestAsk != nil {
+		result.Ask = t.LowestAsk.Float64()
+	}
+	return result, nil
+}
+
+// OrderBook returns an order book snapshot with up to depth levels per side.
+func (e *Exchange) OrderBook(ctx context.Context, symbol string, depth int) (*exchange.OrderBook, error) {
+	withID := true
+	var limit *int
+	if depth > 0 {
+		limit = &depth
+	}
+	ob, err := e.client.ListFuturesOrderBook(ctx, e.settle, contractName(symbol), nil, limit, &withID)
+	if err != nil {
+		return nil, err
+	}
+	result := &exchange.OrderBook{
+		Symbol:   symbol,
+		Bids:     toLevels(ob.Bids),
+		Asks:     toLevels(ob.Asks),
+		UpdateID: ob.ID,
+		Time:     secondsToTime(ob.Update),
+	}
+	return result, nil
+}
+
+// Trades returns up to limit recent public trades.
+func (e *Exchange) Trades(ctx context.Context, symbol string, limit int) ([]exchange.Trade, error) {
+	var limitPtr *int
+	if limit > 0 {
+		limitPtr = &limit
+	}
+	trades, err := e.client.ListFuturesTrades(ctx, e.settle, contractName(symbol), limitPtr, nil, nil, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]exchange.Trade, 0, len(*trades))
+	for _, t := range *trades {
+		side := exchange.Buy
+		if t.Size < 0 {
+			side = exchange.Sell
+		}
+		result = append(result, exchange.Trade{
+			ID:     strconv.FormatInt(t.ID, 10),
+			Symbol: symbol,
+			Price:  t.Price.Float64(),
+			Size:   math.Abs(float64(t.Size)),
+			Side:   side,
+			Time:   secondsToTime(t.CreateTime),
+		})
+	}
+	return result, nil
+}
+
+// Candles returns up to limit candlesticks for the given interval.
+func (e *Exchange) Candles(ctx context.Context, symbol, interval string, limit int) ([]exchange.Candle, error) {
+	var limitPtr *int
+	if limit > 0 {
+		limitPtr = &limit
+	}
+	candles, err := e.client.ListFuturesCandlesticks(ctx, e.settle, contractName(symbol), limitPtr, &interval, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]exchange.Candle, 0, len(*candles))
+	for _, c := range *candles {
+		result = append(result, exchange.Candle{
+			Time:   time.Unix(c.Timestamp, 0),
+			Open:   c.Open.Float64(),
+			High:   c.High.Float64(),
+			Low:    c.Low.Float64(),
+			Close:  c.Close.Float64(),
+			Volume: float64(c.Volume),
+		})
+	}
+	return result, nil
+}
+
+// --- exchange.Trading ---
+
+// PlaceOrder submits a new order. Sell orders are sent with a negative size as
+// required by Gate.io, and the client order ID is carried in the "t-" prefixed text field.
+// Sizes that are not a positive whole number of contracts are rejected with an
+// error matching exchange.ErrInvalidParameter.
+func (e *Exchange) PlaceOrder(ctx context.Context, req exchange.OrderRequest) (*exchange.Order, error) {
+	// Gate.io sizes are whole contracts; rounding would change the order.
+	if req.Size < 1 || req.Size != math.Trunc(req.Size) || req.Size > math.MaxInt64 {
+		return nil, fmt.Errorf("order size must be a positive whole number of contracts, got %v: %w", req.Size, exchange.ErrInvalidParameter)
+	}
+	size := int64(req.Size)
+	if req.Side == exchange.Sell {
+		size = -size
+	}
+
+	order := CreateFuturesOrderRequest{
+		Contract:   contractName(req.Symbol),
+		Size:       size,
+		ReduceOnly: req.ReduceOnly,
+		Tif:        toGateTif(req.TimeInForce),
+	}
+	price := "0"
+	if req.Type == exchange.Market {
+		order.Tif = "ioc" // Market orders must be IOC on Gate.io
+	} else {
+		price = strconv.FormatFloat(req.Price, 'f', -1, 64)
+	}
+	order.Price = &price
+	if req.ClientOrderID != "" {
+		order.Text = "t-" + strings.TrimPrefix(req.ClientOrderID, "t-")
+	}
+
+	result, err := e.client.CreateFuturesOrder(ctx, e.settle, order)
+	if err != nil {
+		return nil, err
+	}
+	return toExchangeOrder(req.Symbol, result), nil
+}
+
+// CancelOrder cancels a single order by ID.
+func (e *Exchange) CancelOrder(ctx context.Context, symbol, orderID string) error {
+	_, err := e.client.CancelFuturesOrder(ctx, e.settle, orderID)
+	return err
+}
+
+// CancelAllOrders cancels every open order on a contract.
+func (e *Exchange) CancelAllOrders(ctx context.Context, symbol string) error {
+	_, err := e.client.CancelAllFuturesOrders(ctx, e.settle, contractName(symbol), nil)
+	return err
+}
+
+// GetOrder returns the current state of an order.
+func (e *Exchange) GetOrder(ctx context.Context, symbol, orderID string) (*exchange.Order, error) {
+	order, err := e.client.GetFuturesOrder(ctx, e.settle, orderID)
+	if err != nil {
+		return nil, err
+	}
+	return toExchangeOrder(symbol, order), nil
+}
+
+// OpenOrders lists open orders on a contract.
+func (e *Exchange) OpenOrders(ctx context.Context, symbol string) ([]exchange.Order, error) {
+	contract := contractName(symbol)
+	orders, err := e.client.ListFuturesOrders(ctx, e.settle, "open", &contract, nil, nil, nil, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]exchange.Order, 0, len(*orders))
+	for i := range *orders {
+		result = append(result, *toExchangeOrder(symbol, &(*orders)[i]))
+	}
+	return result, nil
+}
+
+// --- exchange.Account ---
+
+// Balances returns the futures account balance for the adapter's settle currency.
+func (e *Exchange) Balances(ctx context.Context) ([]exchange.Balance, error) {
+	account, err := e.client.GetFuturesAccount(ctx, e.settle)
+	if err != nil {
+		return nil, err
+	}
+	currency := account.Currency
+	if currency == "" {
+		currency = strings.ToUpper(e.settle)
+	}
+	return []exchange.Balance{{
+		Currency:      currency,
+		Total:         account.Total.Float64(),
+		Available:     account.Available.Float64(),
+		Frozen:        account.PositionMargin.Float64() + account.OrderMargin.Float64(),
+		UnrealizedPnL: account.UnrealisedPnl.Float64(),
+	}}, nil
+}
+
+// Positions returns all non-empty positions.
+func (e *Exchange) Positions(ctx context.Context) ([]exchange.Position, error) {
+	positions, err := e.client.ListPositions(ctx, e.settle, nil)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]exchange.Position, 0, len(*positions))
+	for _, p := range *positions {
+		if p.Size == 0 {
+			continue
+		}
+		side := exchange.Long
+		if p.Size < 0 || p.Mode == "dual_short" {
+			side = exchange.Short
+		}
+		result = append(result, exchange.Position{
+			Symbol:        p.Contract,
+			Side:          side,
+			Size:          math.Abs(float64(p.Size)),
+			EntryPrice:    p.EntryPrice.Float64(),
+			MarkPrice:     p.MarkPrice.Float64(),
+			LiqPrice:      p.LiqPrice.Float64(),
+			UnrealizedPnL: p.UnrealisedPnl.Float64(),
+			Margin:        p.Margin.Float64(),
+			Leverage:      p.Leverage.Float64(),
+		})
+	}
+	return result, nil
+}
+
+// SetLeverage updates the leverage of a contract's position. 0 switches to cross margin.
+func (e *Exchange) SetLeverage(ctx context.Context, symbol string, leverage int) error {
+	_, err := e.client.UpdatePositionLeverage(ctx, e.settle, contractName(symbol), strconv.Itoa(leverage), nil)
+	return err
+}
+
+// --- Conversion helpers ---
+
+// contractName converts a canonical symbol to Gate.io's contract name (e.g. "BTC_USDT").
+func contractName(symbol string) string {
+	return strings.ToUpper(symbol)
+}
+
+func toGateTif(tif exchange.TimeInForce) string {
+	switch tif {
+	case exchange.IOC:
+		return "ioc"
+	case exchange.FOK:
+		return "fok"
+	case exchange.PostOnly:
+		return "poc"
+	default:
+		return "gtc"
+	}
+}
+
+func fromGateTif(tif string) exchange.TimeInForce {
+	switch tif {
+	case "ioc":
+		return exchange.IOC
+	case "fok":
+		return exchange.FOK
+	case "poc":
+		return exchange.PostOnly
+	default:
+		return exchange.GTC
+	}
+}
+
+func toExchangeOrder(symbol string, o *FuturesOrder) *exchange.Order {
+	if symbol == "" {
+		symbol = o.Contract
+	}
+	side := exchange.Buy
+	if o.Size < 0 {
+		side = exchange.Sell
+	}
+	orderType := exchange.Limit
+	price := o.Price.Float64()
+	if price == 0 {
+		orderType = exchange.Market
+	}
+	size := math.Abs(float64(o.Size))
+	filled := size - math.Abs(float64(o.Left))
+
+	status := exchange.StatusOpen
+	switch {
+	case o.Status == "open" && filled > 0:
+		status = exchange.StatusPartiallyFilled
+	case o.Status == "finished" && o.FinishAs == "filled":
+		status = exchange.StatusFilled
+	case o.Status == "finished":
+		status = exchange.StatusCanceled
+	}
+
+	return &exchange.Order{
+		ID:            strconv.FormatInt(o.ID, 10),
+		ClientOrderID: strings.TrimPrefix(o.Text, "t-"),
+		Symbol:        symbol,
+		Side:          side,
+		Type:          orderType,
+		Price:         price,
+		Size:          size,
+		FilledSize:    filled,
+		AvgPrice:      o.FillPrice.Float64(),
+		TimeInForce:   fromGateTif(o.Tif),
+		ReduceOnly:    o.IsReduceOnly,
+		Status:        status,
+		CreateTime:    secondsToTime(o.CreateTime),
+	}
+}
+
+// secondsToTime converts Gate.io's fractional second timestamps to time.Time.
+func secondsToTime(ts decimal.Decimal) time.Time {
+	if ts.IsZero() {
+		return time.Time{}
+	}
+	return time.Unix(0, ts.Mul(decimal.New(1, 9)).IntPart())
+}
+
+func toLevels(entries []FutureOrderBookEntry) []exchange.OrderBookLevel {
+	levels := make([]exchange.OrderBookLevel, 0, len(entries))
+	for _, e := range entries {
+		levels = append(levels, exchange.OrderBookLevel{Price: e.Price.Float64(), Size: float64(e.Size)})
+	}
+	return levels
+}
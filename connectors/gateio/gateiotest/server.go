@@ -0,0 +1,601 @@
+// Package gateiotest provides an in-process emulation of the Gate.io v4
+// futures REST API for tests.
+//
+// A Server verifies the KEY/Timestamp/SIGN headers of private requests the
+// way Gate.io does, keeps orders, positions, the account and price-triggered
+// orders on a paper.Engine, and answers with Gate.io JSON payloads and error
+// labels, so a gateio.Client pointed at it behaves as against the venue:
+//
+//	srv := gateiotest.NewServer(paper.Config{Currency: "USDT", Balance: decimal.NewFromInt(1000)})
+//	defer srv.Close()
+//	srv.AddContract(gateio.Ticker{Name: "BTC_USDT", OrderPriceRound: decimal.MustParse("0.1"), QuantoMultiplier: decimal.MustParse("0.0001")})
+//	srv.SetOrderBook(&exchange.OrderBook{Symbol: "BTC_USDT", Bids: bids, Asks: asks})
+//	client := srv.Client()
+//
+// Resting orders and triggers are matched whenever SetOrderBook replaces a
+// book. Faults injected with InjectFault replace the normal response of
+// matching requests, e.g. to exercise retries and error classification.
+package gateiotest
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha512"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/neqin/futures/connectors/gateio"
+	"github.com/neqin/futures/decimal"
+	"github.com/neqin/futures/exchange"
+	"github.com/neqin/futures/paper"
+	"github.com/neqin/futures/symbols"
+)
+
+// Credentials accepted by a Server.
+const (
+	APIKey    = "gateiotest-key"
+	SecretKey = "gateiotest-secret"
+)
+
+// UserID is the user ID of the account of a Server.
+const UserID = 10001
+
+const (
+	apiPrefix = "/api/v4"
+	// maxSkew is how far the Timestamp header may be from the server clock.
+	maxSkew = 60 * time.Second
+)
+
+// Fault is an error response returned instead of the normal handling of
+// matching requests.
+type Fault struct {
+	Method  string        // HTTP method to match; empty matches any
+	Path    string        // Path without the /api/v4 prefix, e.g. "/futures/usdt/orders"; empty matches any
+	Status  int           // HTTP status, e.g. 429 or 503
+	Label   string        // Gate.io error label, e.g. "TOO_MANY_REQUESTS"; the body is not JSON if empty
+	Message string        // Error message
+	Delay   time.Duration // Wait before responding, e.g. to exceed a client timeout
+	Times   int           // Number of matching requests to fail; zero for all
+}
+
+// Request is a request received by a Server.
+type Request struct {
+	Method string
+	Path   string // Without the /api/v4 prefix
+	Query  url.Values
+	Body   []byte
+	Header http.Header
+}
+
+// Server is an emulated Gate.io futures API. It is safe for concurrent use.
+type Server struct {
+	URL string // Base URL for gateio.Client.SetBaseURL
+
+	srv    *httptest.Server
+	engine *paper.Engine
+	trader *gateio.PaperClient
+	books  *paper.StaticBooks
+	settle string
+
+	mu        sync.Mutex
+	contracts []gateio.Ticker
+	faults    []*Fault
+	requests  []Request
+}
+
+// NewServer starts a Server for the settlement currency cfg.Currency (USDT
+// if empty) whose account starts with cfg. Close it when done.
+func NewServer(cfg paper.Config) *Server {
+	if cfg.Currency == "" {
+		cfg.Currency = "USDT"
+	}
+	books := paper.NewStaticBooks()
+	engine := paper.NewEngine(cfg, books)
+	s := &Server{
+		engine: engine,
+		trader: gateio.NewPaperClient(engine),
+		books:  books,
+		settle: strings.ToLower(cfg.Currency),
+	}
+	s.srv = httptest.NewServer(s.handler())
+	s.URL = s.srv.URL
+	return s
+}
+
+// Close shuts the server down.
+func (s *Server) Close() {
+	s.srv.Close()
+}
+
+// Client returns a gateio.Client for the server, authenticated with APIKey
+// and SecretKey.
+func (s *Server) Client() *gateio.Client {
+	c := gateio.NewClient(APIKey, SecretKey, s.srv.Client())
+	c.SetBaseURL(s.URL)
+	return c
+}
+
+// Engine returns the simulated account behind the server.
+func (s *Server) Engine() *paper.Engine {
+	return s.engine
+}
+
+// AddContract lists a perpetual contract. Name, OrderPriceRound and
+// QuantoMultiplier are required; OrderSizeMin defaults to 1.
+func (s *Server) AddContract(t gateio.Ticker) {
+	if t.OrderSizeMin == 0 {
+		t.OrderSizeMin = 1
+	}
+	if t.Type == "" {
+		t.Type = "direct"
+	}
+	s.mu.Lock()
+	s.contracts = append(s.contracts, t)
+	contracts := make([]symbols.Contract, len(s.contracts))
+	for i, c := range s.contracts {
+		contracts[i] = symbols.Contract{
+			Symbol:     symbols.Canonical(c.Name),
+			Venue:      "gateio",
+			Settle:     strings.ToUpper(s.settle),
+			TickSize:   c.OrderPriceRound,
+			LotSize:    decimal.NewFromInt(1),
+			MinSize:    decimal.NewFromInt(int64(c.OrderSizeMin)),
+			MaxSize:    decimal.NewFromInt(int64(c.OrderSizeMax)),
+			Multiplier: c.QuantoMultiplier,
+		}
+	}
+	s.mu.Unlock()
+	s.engine.SetContracts(contracts...)
+}
+
+// SetOrderBook replaces the book of a contract and matches resting orders
+// and triggers against it.
+func (s *Server) SetOrderBook(book *exchange.OrderBook) error {
+	s.books.Set(book)
+	return s.engine.Match(context.Background())
+}
+
+// InjectFault makes matching requests fail. Faults are checked in the order
+// they were injected.
+func (s *Server) InjectFault(f Fault) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.faults = append(s.faults, &f)
+}
+
+// ClearFaults removes all injected faults.
+func (s *Server) ClearFaults() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.faults = nil
+}
+
+// Requests returns the requests received so far, oldest first.
+func (s *Server) Requests() []Request {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]Request(nil), s.requests...)
+}
+
+// --- HTTP handling ---
+
+func (s *Server) handler() http.Handler {
+	mux := http.NewServeMux()
+	public := func(pattern string, h func(*http.Request) (interface{}, error)) {
+		mux.Handle(pattern, s.endpoint(false, h))
+	}
+	private := func(pattern string, h func(*http.Request) (interface{}, error)) {
+		mux.Handle(pattern, s.endpoint(true, h))
+	}
+
+	public("GET /api/v4/futures/{settle}/contracts", s.listContracts)
+	public("GET /api/v4/futures/{settle}/contracts/{contract}", s.getContract)
+	public("GET /api/v4/futures/{settle}/order_book", s.orderBook)
+	public("GET /api/v4/futures/{settle}/tickers", s.tickers)
+
+	private("POST /api/v4/futures/{settle}/orders", s.createOrder)
+	private("GET /api/v4/futures/{settle}/orders", s.listOrders)
+	private("DELETE /api/v4/futures/{settle}/orders", s.cancelOrders)
+	private("POST /api/v4/futures/{settle}/batch_orders", s.batchCreateOrders)
+	private("GET /api/v4/futures/{settle}/orders/{order_id}", s.getOrder)
+	private("DELETE /api/v4/futures/{settle}/orders/{order_id}", s.cancelOrder)
+	private("PUT /api/v4/futures/{settle}/orders/{order_id}", s.amendOrder)
+
+	private("GET /api/v4/futures/{settle}/accounts", s.account)
+	private("GET /api/v4/futures/{settle}/positions", s.listPositions)
+	private("GET /api/v4/futures/{settle}/positions/{contract}", s.getPosition)
+	private("POST /api/v4/futures/{settle}/positions/{contract}/margin", s.updateMargin)
+	private("POST /api/v4/futures/{settle}/positions/{contract}/leverage", s.updateLeverage)
+
+	private("POST /api/v4/futures/{settle}/price_orders", s.createTrigger)
+	private("GET /api/v4/futures/{settle}/price_orders", s.listTriggers)
+	private("DELETE /api/v4/futures/{settle}/price_orders", s.cancelTriggers)
+	private("GET /api/v4/futures/{settle}/price_orders/{order_id}", s.getTrigger)
+	private("DELETE /api/v4/futures/{settle}/price_orders/{order_id}", s.cancelTrigger)
+
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		writeError(w, http.StatusNotFound, "NOT_FOUND", "unsupported endpoint "+r.Method+" "+r.URL.Path)
+	})
+	return mux
+}
+
+// endpoint wraps a handler with request recording, fault injection,
+// signature verification for private endpoints and response encoding.
+func (s *Server) endpoint(private bool, h func(*http.Request) (interface{}, error)) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		in := time.Now()
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "INVALID_REQUEST_BODY", err.Error())
+			return
+		}
+		path := strings.TrimPrefix(r.URL.Path, apiPrefix)
+		f := s.record(Request{Method: r.Method, Path: path, Query: r.URL.Query(), Body: body, Header: r.Header.Clone()})
+
+		w.Header().Set("X-In-Time", strconv.FormatInt(in.UnixMicro(), 10))
+		w.Header().Set("X-Out-Time", strconv.FormatInt(time.Now().UnixMicro(), 10))
+		if f != nil {
+			select {
+			case <-time.After(f.Delay):
+			case <-r.Context().Done():
+				return
+			}
+			if f.Label == "" {
+				http.Error(w, f.Message, f.Status)
+				return
+			}
+			writeError(w, f.Status, f.Label, f.Message)
+			return
+		}
+		if private {
+			if status, label, msg := s.authenticate(r, body); label != "" {
+				writeError(w, status, label, msg)
+				return
+			}
+		}
+		if settle := r.PathValue("settle"); settle != s.settle {
+			writeError(w, http.StatusBadRequest, "INVALID_PARAM_VALUE", "unsupported settle "+settle)
+			return
+		}
+		r.Body = io.NopCloser(bytes.NewReader(body))
+		result, err := h(r)
+		if err != nil {
+			status, label := errorLabel(err)
+			writeError(w, status, label, err.Error())
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(result)
+	})
+}
+
+// record logs a request and returns the fault to apply, if any.
+func (s *Server) record(req Request) *Fault {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.requests = append(s.requests, req)
+	for i, f := range s.faults {
+		if (f.Method != "" && f.Method != req.Method) || (f.Path != "" && f.Path != req.Path) {
+			continue
+		}
+		if f.Times > 0 {
+			f.Times--
+			if f.Times == 0 {
+				s.faults = append(s.faults[:i:i], s.faults[i+1:]...)
+			}
+		}
+		return f
+	}
+	return nil
+}
+
+// authenticate checks the signature headers of a private request and returns
+// the error response for a rejected one.
+func (s *Server) authenticate(r *http.Request, body []byte) (int, string, string) {
+	key, ts, sign := r.Header.Get("KEY"), r.Header.Get("Timestamp"), r.Header.Get("SIGN")
+	if key == "" || ts == "" || sign == "" {
+		return http.StatusUnauthorized, "MISSING_REQUIRED_HEADER", "KEY, Timestamp and SIGN headers are required"
+	}
+	if key != APIKey {
+		return http.StatusUnauthorized, "INVALID_KEY", "invalid key provided"
+	}
+	sec, err := strconv.ParseInt(ts, 10, 64)
+	if err != nil {
+		return http.StatusUnauthorized, "INVALID_SIGNATURE", "invalid timestamp"
+	}
+	if skew := time.Since(time.Unix(sec, 0)); skew > maxSkew || skew < -maxSkew {
+		return http.StatusUnauthorized, "REQUEST_EXPIRED", "gap between request Timestamp and server time exceeds 60"
+	}
+	payload := sha512.Sum512(body)
+	mac := hmac.New(sha512.New, []byte(SecretKey))
+	fmt.Fprintf(mac, "%s\n%s\n%s\n%s\n%s", r.Method, r.URL.Path, r.URL.RawQuery, hex.EncodeToString(payload[:]), ts)
+	if !hmac.Equal([]byte(sign), []byte(hex.EncodeToString(mac.Sum(nil)))) {
+		return http.StatusUnauthorized, "INVALID_SIGNATURE", "signature mismatch"
+	}
+	return 0, "", ""
+}
+
+// errorLabel maps an engine error to the Gate.io status and label.
+func errorLabel(err error) (int, string) {
+	switch {
+	case errors.Is(err, exchange.ErrOrderNotFound):
+		return http.StatusNotFound, "ORDER_NOT_FOUND"
+	case errors.Is(err, exchange.ErrInsufficientBalance):
+		return http.StatusBadRequest, "BALANCE_NOT_ENOUGH"
+	case errors.Is(err, exchange.ErrInvalidParameter):
+		return http.StatusBadRequest, "INVALID_PARAM_VALUE"
+	}
+	return http.StatusInternalServerError, "SERVER_ERROR"
+}
+
+func writeError(w http.ResponseWriter, status int, label, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(gateio.APIError{Label: label, Message: message})
+}
+
+func invalid(format string, args ...interface{}) error {
+	return fmt.Errorf(format+": %w", append(args, exchange.ErrInvalidParameter)...)
+}
+
+func decodeBody(r *http.Request, v interface{}) error {
+	if err := json.NewDecoder(r.Body).Decode(v); err != nil {
+		return invalid("malformed request body: %v", err)
+	}
+	return nil
+}
+
+func queryInt(r *http.Request, name string) (*int, error) {
+	v := r.URL.Query().Get(name)
+	if v == "" {
+		return nil, nil
+	}
+	n, err := strconv.Atoi(v)
+	if err != nil {
+		return nil, invalid("invalid %s %q", name, v)
+	}
+	return &n, nil
+}
+
+func queryString(r *http.Request, name string) *string {
+	if !r.URL.Query().Has(name) {
+		return nil
+	}
+	v := r.URL.Query().Get(name)
+	return &v
+}
+
+// --- Market data ---
+
+func (s *Server) listContracts(r *http.Request) (interface{}, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append(gateio.TickerResult{}, s.contracts...), nil
+}
+
+func (s *Server) getContract(r *http.Request) (interface{}, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, c := range s.contracts {
+		if c.Name == r.PathValue("contract") {
+			return c, nil
+		}
+	}
+	return nil, invalid("contract %s not found", r.PathValue("contract"))
+}
+
+func (s *Server) book(contract string) (*exchange.OrderBook, error) {
+	if contract == "" {
+		return nil, invalid("contract is required")
+	}
+	book, err := s.books.OrderBook(context.Background(), symbols.Canonical(contract))
+	if err != nil {
+		return nil, invalid("%v", err)
+	}
+	return book, nil
+}
+
+func (s *Server) orderBook(r *http.Request) (interface{}, error) {
+	book, err := s.book(r.URL.Query().Get("contract"))
+	if err != nil {
+		return nil, err
+	}
+	limit, err := queryInt(r, "limit")
+	if err != nil {
+		return nil, err
+	}
+	levels := func(in []exchange.OrderBookLevel) []gateio.FutureOrderBookEntry {
+		out := []gateio.FutureOrderBookEntry{}
+		for i, l := range in {
+			if limit != nil && i >= *limit {
+				break
+			}
+			out = append(out, gateio.FutureOrderBookEntry{Price: decimal.NewFromFloat(l.Price), Size: int64(l.Size)})
+		}
+		return out
+	}
+	now := decimal.NewFromFloat(float64(time.Now().UnixMicro()) / 1e6)
+	return gateio.FutureOrderBook{
+		ID:       book.UpdateID,
+		Current:  now,
+		Update:   now,
+		Asks:     levels(book.Asks),
+		Bids:     levels(book.Bids),
+		Contract: r.URL.Query().Get("contract"),
+	}, nil
+}
+
+func (s *Server) tickers(r *http.Request) (interface{}, error) {
+	contract := r.URL.Query().Get("contract")
+	book, err := s.book(contract)
+	if err != nil {
+		return nil, err
+	}
+	t := gateio.FuturesTicker{Contract: contract}
+	if len(book.Bids) > 0 {
+		bid := decimal.NewFromFloat(book.Bids[0].Price)
+		t.HighestBid, t.Last = &bid, bid
+	}
+	if len(book.Asks) > 0 {
+		ask := decimal.NewFromFloat(book.Asks[0].Price)
+		t.LowestAsk, t.Last = &ask, ask
+	}
+	if t.HighestBid != nil && t.LowestAsk != nil {
+		t.Last = t.HighestBid.Add(*t.LowestAsk).Div(decimal.NewFromInt(2), 8)
+	}
+	t.MarkPrice, t.IndexPrice = t.Last, t.Last
+	return gateio.ListFuturesTickersResult{t}, nil
+}
+
+// --- Orders ---
+
+func (s *Server) createOrder(r *http.Request) (interface{}, error) {
+	var order gateio.CreateFuturesOrderRequest
+	if err := decodeBody(r, &order); err != nil {
+		return nil, err
+	}
+	return s.trader.CreateFuturesOrder(r.Context(), s.settle, order)
+}
+
+func (s *Server) batchCreateOrders(r *http.Request) (interface{}, error) {
+	var orders gateio.BatchCreateFuturesOrdersRequest
+	if err := decodeBody(r, &orders); err != nil {
+		return nil, err
+	}
+	result := gateio.BatchFuturesOrdersResult{}
+	for _, order := range orders {
+		o, err := s.trader.CreateFuturesOrder(r.Context(), s.settle, order)
+		if err != nil {
+			_, label := errorLabel(err)
+			result = append(result, gateio.BatchFuturesOrder{Label: label, Message: err.Error()})
+			continue
+		}
+		result = append(result, gateio.BatchFuturesOrder{Succeeded: true, FuturesOrder: *o})
+	}
+	return result, nil
+}
+
+func (s *Server) listOrders(r *http.Request) (interface{}, error) {
+	q := r.URL.Query()
+	limit, err := queryInt(r, "limit")
+	if err != nil {
+		return nil, err
+	}
+	offset, err := queryInt(r, "offset")
+	if err != nil {
+		return nil, err
+	}
+	var from, to *int64
+	for name, dst := range map[string]**int64{"from": &from, "to": &to} {
+		if v := q.Get(name); v != "" {
+			n, err := strconv.ParseInt(v, 10, 64)
+			if err != nil {
+				return nil, invalid("invalid %s %q", name, v)
+			}
+			*dst = &n
+		}
+	}
+	return s.trader.ListFuturesOrders(r.Context(), s.settle, q.Get("status"), queryString(r, "contract"), limit, offset, queryString(r, "last_id"), from, to)
+}
+
+func (s *Server) cancelOrders(r *http.Request) (interface{}, error) {
+	return s.trader.CancelAllFuturesOrders(r.Context(), s.settle, r.URL.Query().Get("contract"), queryString(r, "side"))
+}
+
+func (s *Server) getOrder(r *http.Request) (interface{}, error) {
+	return s.trader.GetFuturesOrder(r.Context(), s.settle, r.PathValue("order_id"))
+}
+
+func (s *Server) cancelOrder(r *http.Request) (interface{}, error) {
+	return s.trader.CancelFuturesOrder(r.Context(), s.settle, r.PathValue("order_id"))
+}
+
+func (s *Server) amendOrder(r *http.Request) (interface{}, error) {
+	var size *int64
+	if v := r.URL.Query().Get("size"); v != "" {
+		n, err := strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			return nil, invalid("invalid size %q", v)
+		}
+		size = &n
+	}
+	return s.trader.AmendFuturesOrder(r.Context(), s.settle, r.PathValue("order_id"), size, queryString(r, "price"), queryString(r, "amend_text"))
+}
+
+// --- Account and positions ---
+
+func (s *Server) account(r *http.Request) (interface{}, error) {
+	account, err := s.trader.GetFuturesAccount(r.Context(), s.settle)
+	if err != nil {
+		return nil, err
+	}
+	account.User = UserID
+	return account, nil
+}
+
+func (s *Server) listPositions(r *http.Request) (interface{}, error) {
+	return s.trader.ListPositions(r.Context(), s.settle, nil)
+}
+
+func (s *Server) getPosition(r *http.Request) (interface{}, error) {
+	return s.trader.GetPosition(r.Context(), s.settle, r.PathValue("contract"))
+}
+
+func (s *Server) updateMargin(r *http.Request) (interface{}, error) {
+	return s.trader.UpdatePositionMargin(r.Context(), s.settle, r.PathValue("contract"), r.URL.Query().Get("change"))
+}
+
+func (s *Server) updateLeverage(r *http.Request) (interface{}, error) {
+	return s.trader.UpdatePositionLeverage(r.Context(), s.settle, r.PathValue("contract"), r.URL.Query().Get("leverage"), queryString(r, "cross_leverage_limit"))
+}
+
+// --- Price-triggered orders ---
+
+func (s *Server) createTrigger(r *http.Request) (interface{}, error) {
+	var order gateio.CreateTriggerOrderRequest
+	if err := decodeBody(r, &order); err != nil {
+		return nil, err
+	}
+	t, err := s.trader.CreateTriggerOrder(r.Context(), s.settle, order)
+	if err != nil {
+		return nil, err
+	}
+	return struct {
+		ID int64 `json:"id"`
+	}{t.ID}, nil
+}
+
+func (s *Server) listTriggers(r *http.Request) (interface{}, error) {
+	limit, err := queryInt(r, "limit")
+	if err != nil {
+		return nil, err
+	}
+	offset, err := queryInt(r, "offset")
+	if err != nil {
+		return nil, err
+	}
+	return s.trader.ListTriggerOrders(r.Context(), s.settle, r.URL.Query().Get("status"), queryString(r, "contract"), limit, offset)
+}
+
+func (s *Server) cancelTriggers(r *http.Request) (interface{}, error) {
+	return s.trader.CancelAllTriggerOrders(r.Context(), s.settle, r.URL.Query().Get("contract"))
+}
+
+func (s *Server) getTrigger(r *http.Request) (interface{}, error) {
+	return s.trader.GetTriggerOrder(r.Context(), s.settle, r.PathValue("order_id"))
+}
+
+func (s *Server) cancelTrigger(r *http.Request) (interface{}, error) {
+	return s.trader.CancelTriggerOrder(r.Context(), s.settle, r.PathValue("order_id"))
+}
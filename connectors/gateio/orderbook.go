@@ -0,0 +1,88 @@
+package gateio
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/neqin/futures/orderbook"
+)
+
+// OrderBookSource feeds an orderbook.Book for one contract from the
+// ListFuturesOrderBook snapshot and the futures.order_book_update channel.
+type OrderBookSource struct {
+	client    *Client
+	ws        *WSClient
+	settle    string
+	contract  string
+	frequency string
+	level     string
+}
+
+// NewOrderBookSource creates an order book source for contract.
+// settle: "usdt" or "btc"
+// The source subscribes to 100 levels at 100ms; use SetDepth to change this.
+func NewOrderBookSource(client *Client, ws *WSClient, settle, contract string) *OrderBookSource {
+	return &OrderBookSource{
+		client:    client,
+		ws:        ws,
+		settle:    settle,
+		contract:  contract,
+		frequency: "100ms",
+		level:     "100",
+	}
+}
+
+// SetDepth sets the update frequency ("20ms" or "100ms") and depth level
+// ("100", "50" or "20") of the diff stream. The snapshot uses the same depth.
+func (s *OrderBookSource) SetDepth(frequency, level string) {
+	s.frequency = frequency
+	s.level = level
+}
+
+// Snapshot fetches the order book with its update ID.
+func (s *OrderBookSource) Snapshot(ctx context.Context) (*orderbook.Snapshot, error) {
+	interval := "0"
+	withID := true
+	limit, err := strconv.Atoi(s.level)
+	if err != nil {
+		return nil, fmt.Errorf("invalid order book level %q: %w", s.level, err)
+	}
+	ob, err := s.client.ListFuturesOrderBook(ctx, s.settle, s.contract, &interval, &limit, &withID)
+	if err != nil {
+		return nil, err
+	}
+	return &orderbook.Snapshot{
+		UpdateID: ob.ID,
+		Bids:     toLevels(ob.Bids),
+		Asks:     toLevels(ob.Asks),
+	}, nil
+}
+
+// Diffs subscribes to futures.order_book_update for the contract.
+func (s *OrderBookSource) Diffs(ctx context.Context) (<-chan orderbook.Diff, error) {
+	updates, err := s.ws.SubscribeOrderBookUpdate(ctx, s.contract, s.frequency, s.level)
+	if err != nil {
+		return nil, err
+	}
+	diffs := make(chan orderbook.Diff, wsSubscriptionBuffer)
+	go func() {
+		defer close(diffs)
+		for u := range updates {
+			d := orderbook.Diff{
+				FirstID: u.FirstID,
+				LastID:  u.LastID,
+				Bids:    toLevels(u.Bids),
+				Asks:    toLevels(u.Asks),
+				Time:    time.UnixMilli(u.Time),
+			}
+			select {
+			case diffs <- d:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return diffs, nil
+}
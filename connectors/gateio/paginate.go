@@ -0,0 +1,129 @@
+package gateio
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"iter"
+	"strconv"
+
+	"github.com/neqin/futures/paginate"
+)
+
+// pageLimit is the page size requested by the iterators (the API maximum).
+const pageLimit = 1000
+
+// IterFuturesTrades walks the public trade history of contract, newest first,
+// following last_id from page to page. Each page is a rate-limited request;
+// iteration stops at the first error (which is yielded) or when ctx is done.
+// settle: "usdt" or "btc"
+// contract: Futures contract name
+// from: Start timestamp (seconds) (optional)
+// to: End timestamp (seconds) (optional)
+func (c *Client) IterFuturesTrades(ctx context.Context, settle, contract string, from, to *int64) iter.Seq2[FuturesTrade, error] {
+	return func(yield func(FuturesTrade, error) bool) {
+		var lastID *string
+		limit := pageLimit
+		paginate.Walk(ctx, yield, func(t FuturesTrade) int64 { return t.ID }, func() ([]FuturesTrade, bool, error) {
+			page, err := c.ListFuturesTrades(ctx, settle, contract, &limit, nil, lastID, from, to)
+			if err != nil {
+				return nil, false, err
+			}
+			if len(*page) == 0 {
+				return nil, true, nil
+			}
+			id := strconv.FormatInt((*page)[len(*page)-1].ID, 10)
+			lastID = &id
+			return *page, len(*page) < limit, nil
+		})
+	}
+}
+
+// IterMyFuturesTrades walks the personal trading history, newest first, using
+// offset pagination. Trades filled while iterating shift the offsets, so
+// trades already returned can reappear on later pages, possibly a whole page
+// of them. Since trade IDs grow with time, only trades older than the oldest
+// one returned so far are kept, and a page with none of them is read past
+// instead of ending the iteration.
+// settle: "usdt" or "btc"
+// contract: Filter by contract name (optional)
+// from: Start timestamp (seconds) (optional)
+// to: End timestamp (seconds) (optional)
+func (c *Client) IterMyFuturesTrades(ctx context.Context, settle string, contract *string, from, to *int64) iter.Seq2[FuturesTrade, error] {
+	return func(yield func(FuturesTrade, error) bool) {
+		offset := 0
+		limit := pageLimit
+		var oldest int64 // ID of the oldest trade returned, 0 before the first page
+		paginate.Walk(ctx, yield, func(t FuturesTrade) int64 { return t.ID }, func() ([]FuturesTrade, bool, error) {
+			for {
+				page, err := c.ListMyFuturesTrades(ctx, settle, contract, nil, &limit, &offset, nil, from, to)
+				if err != nil {
+					return nil, false, err
+				}
+				offset += len(*page)
+				last := len(*page) < limit
+				trades := make([]FuturesTrade, 0, len(*page))
+				for _, t := range *page {
+					if oldest == 0 || t.ID < oldest {
+						trades = append(trades, t)
+					}
+				}
+				if len(trades) == 0 && !last {
+					continue // Shifted by at least a page of new trades
+				}
+				if len(trades) > 0 {
+					oldest = trades[len(trades)-1].ID
+				}
+				return trades, last, nil
+			}
+		})
+	}
+}
+
+// accountBookKey identifies an account book entry, which has no ID of its own.
+type accountBookKey struct {
+	time, change, typ, text, contract, tradeID string
+}
+
+// ErrAccountBookGap is yielded by IterFuturesAccountBook after a full page of
+// entries that fall within a single second. The API pages by whole seconds,
+// so further entries of that second cannot be fetched; the iteration moves on
+// to older entries if the consumer continues.
+var ErrAccountBookGap = errors.New("gateio account book has a full page within one second, its remaining entries skipped")
+
+// IterFuturesAccountBook walks the account ledger, newest first, by moving the
+// end of the time window back to the oldest entry of each page. Entries
+// repeated at the boundary second are skipped. If a full page falls within a
+// single second, ErrAccountBookGap is yielded after its entries and the window
+// moves back past that second.
+// settle: "usdt" or "btc"
+// contract: Filter by contract name (optional)
+// from: Start timestamp (seconds) (optional)
+// to: End timestamp (seconds) (optional)
+// typeFilter: Filter by entry type (optional), see ListFuturesAccountBook
+func (c *Client) IterFuturesAccountBook(ctx context.Context, settle string, contract *string, from, to *int64, typeFilter *string) iter.Seq2[FuturesAccountBookEntry, error] {
+	return func(yield func(FuturesAccountBookEntry, error) bool) {
+		end := to
+		limit := pageLimit
+		key := func(e FuturesAccountBookEntry) accountBookKey {
+			return accountBookKey{e.Time.String(), e.Change.String(), e.Type, e.Text, e.Contract, e.TradeID}
+		}
+		paginate.Walk(ctx, yield, key, func() ([]FuturesAccountBookEntry, bool, error) {
+			page, err := c.ListFuturesAccountBook(ctx, settle, contract, &limit, from, end, typeFilter)
+			if err != nil {
+				return nil, false, err
+			}
+			if len(*page) < limit {
+				return *page, true, nil
+			}
+			newest, oldest := (*page)[0].Time.IntPart(), (*page)[len(*page)-1].Time.IntPart()
+			var gap error
+			if oldest >= newest {
+				oldest-- // Whole page within one second, step past it
+				gap = fmt.Errorf("%w (%d)", ErrAccountBookGap, newest)
+			}
+			end = &oldest
+			return *page, from != nil && oldest < *from, gap
+		})
+	}
+}
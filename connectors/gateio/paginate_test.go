@@ -0,0 +1,106 @@
+package gateio_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/neqin/futures/connectors/gateio"
+)
+
+func TestIterFuturesAccountBookSingleSecondPage(t *testing.T) {
+	// 1200 entries within second 1000, more than one page, then 5 older ones.
+	var ledger []map[string]any
+	for i := range 1200 {
+		ledger = append(ledger, map[string]any{"time": 1000, "change": "1", "type": "fee", "text": "fee " + strconv.Itoa(i)})
+	}
+	for sec := 999; sec > 994; sec-- {
+		ledger = append(ledger, map[string]any{"time": sec, "change": "1", "type": "pnl", "text": "pnl"})
+	}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+		to, err := strconv.Atoi(r.URL.Query().Get("to"))
+		if err != nil {
+			to = 1 << 30
+		}
+		page := []map[string]any{}
+		for _, e := range ledger {
+			if e["time"].(int) <= to && len(page) < limit {
+				page = append(page, e)
+			}
+		}
+		json.NewEncoder(w).Encode(page)
+	}))
+	defer srv.Close()
+	client := gateio.NewClient("key", "secret", nil)
+	client.SetBaseURL(srv.URL)
+
+	entries, gaps := 0, 0
+	for e, err := range client.IterFuturesAccountBook(context.Background(), settle, nil, nil, nil, nil) {
+		if errors.Is(err, gateio.ErrAccountBookGap) {
+			gaps++
+			continue
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		if entries >= 1000 && e.Time.IntPart() == 1000 {
+			t.Errorf("entry %d of the skipped second returned: %+v", entries, e)
+		}
+		entries++
+	}
+	if entries != 1005 || gaps != 1 {
+		t.Errorf("got %d entries and %d gaps, want 1005 entries (a full page and the older ones) and 1 gap", entries, gaps)
+	}
+}
+
+func TestIterMyFuturesTradesOffsetDrift(t *testing.T) {
+	// 2500 trades, newest first; a page's worth of new trades is filled after
+	// the first request, so the second offset page repeats the first.
+	var ids []int64
+	for id := int64(2500); id > 0; id-- {
+		ids = append(ids, id)
+	}
+	requests := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+		offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+		page := []map[string]any{}
+		for i := offset; i < len(ids) && len(page) < limit; i++ {
+			page = append(page, map[string]any{"id": ids[i], "contract": "BTC_USDT"})
+		}
+		requests++
+		if requests == 1 {
+			var filled []int64
+			for id := int64(2500 + limit); id > 2500; id-- {
+				filled = append(filled, id)
+			}
+			ids = append(filled, ids...)
+		}
+		json.NewEncoder(w).Encode(page)
+	}))
+	defer srv.Close()
+	client := gateio.NewClient("key", "secret", nil)
+	client.SetBaseURL(srv.URL)
+
+	want := int64(2500)
+	for trade, err := range client.IterMyFuturesTrades(context.Background(), settle, nil, nil, nil) {
+		if err != nil {
+			t.Fatal(err)
+		}
+		if trade.ID != want {
+			t.Fatalf("trade %d, want %d", trade.ID, want)
+		}
+		want--
+	}
+	if want != 0 {
+		t.Errorf("iteration ended before trade %d", want)
+	}
+	if requests != 4 {
+		t.Errorf("%d requests, want 4 (one page repeated)", requests)
+	}
+}
@@ -0,0 +1,552 @@
+package gateio
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/neqin/futures/decimal"
+	"github.com/neqin/futures/exchange"
+	"github.com/neqin/futures/paper"
+	"github.com/neqin/futures/symbols"
+)
+
+// FuturesTrader is the set of Client methods for orders, positions, leverage,
+// margin and price-triggered orders that PaperClient simulates. Strategies
+// written against it can be run on a simulated account without changes.
+type FuturesTrader interface {
+	CreateFuturesOrder(ctx context.Context, settle string, order CreateFuturesOrderRequest) (*FuturesOrder, error)
+	ListFuturesOrders(ctx context.Context, settle, status string, contract *string, limit, offset *int, lastID *string, from, to *int64) (*[]FuturesOrder, error)
+	GetFuturesOrder(ctx context.Context, settle, orderID string) (*FuturesOrder, error)
+	CancelFuturesOrder(ctx context.Context, settle, orderID string) (*CancelOrderResult, error)
+	CancelAllFuturesOrders(ctx context.Context, settle, contract string, side *string) (*BatchCancelOrdersResult, error)
+	AmendFuturesOrder(ctx context.Context, settle, orderID string, size *int64, price *string, amendText *string) (*FuturesOrder, error)
+
+	GetFuturesAccount(ctx context.Context, settle string) (*FuturesAccount, error)
+	ListPositions(ctx context.Context, settle string, holdingID *string) (*[]Position, error)
+	GetPosition(ctx context.Context, settle, contract string) (*Position, error)
+	UpdatePositionMargin(ctx context.Context, settle, contract, change string) (*Position, error)
+	UpdatePositionLeverage(ctx context.Context, settle, contract, leverage string, crossLeverageLimit *string) (*Position, error)
+
+	CreateTriggerOrder(ctx context.Context, settle string, order CreateTriggerOrderRequest) (*TriggerOrder, error)
+	ListTriggerOrders(ctx context.Context, settle, status string, contract *string, limit, offset *int) (*ListPriceTriggeredOrdersResult, error)
+	CancelAllTriggerOrders(ctx context.Context, settle, contract string) (*ListPriceTriggeredOrdersResult, error)
+	GetTriggerOrder(ctx context.Context, settle, orderID string) (*PriceTriggeredOrder, error)
+	CancelTriggerOrder(ctx context.Context, settle, orderID string) (*CancelPriceTriggeredOrderResult, error)
+}
+
+var (
+	_ FuturesTrader = (*Client)(nil)
+	_ FuturesTrader = (*PaperClient)(nil)
+)
+
+// PaperClient implements FuturesTrader on a simulated paper.Engine account in
+// single (one-way) position mode. The settle argument must match the engine's
+// currency. Orders are identified by their numeric ID or their "t-" text.
+// Trigger rules follow Gate.io (1: price >= trigger, 2: price <= trigger) and
+// are evaluated against the book's mid price whatever the price_type.
+type PaperClient struct {
+	engine *paper.Engine
+}
+
+// NewPaperClient creates a client trading on engine, e.g.
+//
+//	engine := paper.NewEngine(paper.Config{Currency: "USDT", Balance: decimal.NewFromInt(10000)},
+//		paper.PolledBooks(gateio.NewExchange(client, "usdt"), 50))
+//	err := engine.LoadContracts(ctx, gateio.NewSymbolLoader(client, "usdt"))
+//	trader := gateio.NewPaperClient(engine)
+func NewPaperClient(engine *paper.Engine) *PaperClient {
+	return &PaperClient{engine: engine}
+}
+
+// Engine returns the simulated account.
+func (c *PaperClient) Engine() *paper.Engine {
+	return c.engine
+}
+
+// --- Orders ---
+
+// CreateFuturesOrder places a simulated order.
+func (c *PaperClient) CreateFuturesOrder(ctx context.Context, settle string, order CreateFuturesOrderRequest) (*FuturesOrder, error) {
+	if err := c.checkSettle(settle); err != nil {
+		return nil, err
+	}
+	req, err := paperOrderRequest(order)
+	if err != nil {
+		return nil, err
+	}
+	o, err := c.engine.PlaceOrder(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return c.futuresOrder(o), nil
+}
+
+// ListFuturesOrders lists simulated orders, newest first. lastID returns
+// orders older than that ID; from and to filter by creation time (seconds).
+func (c *PaperClient) ListFuturesOrders(ctx context.Context, settle, status string, contract *string, limit, offset *int, lastID *string, from, to *int64) (*[]FuturesOrder, error) {
+	if err := c.checkSettle(settle); err != nil {
+		return nil, err
+	}
+	var before int64
+	if lastID != nil {
+		id, err := strconv.ParseInt(*lastID, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid last_id %q: %w", *lastID, exchange.ErrInvalidParameter)
+		}
+		before = id
+	}
+	result := []FuturesOrder{}
+	for _, o := range c.engine.Orders(paperSymbol(deref(contract)), status == "open") {
+		if before > 0 && o.ID >= before {
+			continue
+		}
+		if (from != nil && o.CreateTime.Unix() < *from) || (to != nil && o.CreateTime.Unix() > *to) {
+			continue
+		}
+		result = append(result, *c.futuresOrder(&o))
+	}
+	result = page(result, limit, offset)
+	return &result, nil
+}
+
+// GetFuturesOrder returns a simulated order by ID or "t-" text.
+func (c *PaperClient) GetFuturesOrder(ctx context.Context, settle, orderID string) (*FuturesOrder, error) {
+	if err := c.checkSettle(settle); err != nil {
+		return nil, err
+	}
+	id, err := c.orderID(orderID)
+	if err != nil {
+		return nil, err
+	}
+	o, err := c.engine.Order(id)
+	if err != nil {
+		return nil, err
+	}
+	return c.futuresOrder(o), nil
+}
+
+// CancelFuturesOrder cancels a simulated order by ID or "t-" text.
+func (c *PaperClient) CancelFuturesOrder(ctx context.Context, settle, orderID string) (*CancelOrderResult, error) {
+	if err := c.checkSettle(settle); err != nil {
+		return nil, err
+	}
+	id, err := c.orderID(orderID)
+	if err != nil {
+		return nil, err
+	}
+	o, err := c.engine.CancelOrder(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	result := CancelOrderResult(*c.futuresOrder(o))
+	return &result, nil
+}
+
+// CancelAllFuturesOrders cancels the open simulated orders of a contract,
+// optionally only those on side ("buy" or "sell").
+func (c *PaperClient) CancelAllFuturesOrders(ctx context.Context, settle, contract string, side *string) (*BatchCancelOrdersResult, error) {
+	if err := c.checkSettle(settle); err != nil {
+		return nil, err
+	}
+	orders, err := c.engine.CancelOrders(ctx, paperSymbol(contract), exchange.Side(deref(side)))
+	if err != nil {
+		return nil, err
+	}
+	result := BatchCancelOrdersResult{}
+	for i := range orders {
+		result = append(result, *c.futuresOrder(&orders[i]))
+	}
+	return &result, nil
+}
+
+// AmendFuturesOrder changes the size (including the filled part, with the
+// sign of the order) and/or price of an open simulated order.
+func (c *PaperClient) AmendFuturesOrder(ctx context.Context, settle, orderID string, size *int64, price *string, amendText *string) (*FuturesOrder, error) {
+	if err := c.checkSettle(settle); err != nil {
+		return nil, err
+	}
+	id, err := c.orderID(orderID)
+	if err != nil {
+		return nil, err
+	}
+	var newSize, newPrice *decimal.Decimal
+	if size != nil {
+		s := decimal.NewFromInt(*size).Abs()
+		newSize = &s
+	}
+	if price != nil {
+		p, err := decimal.Parse(*price)
+		if err != nil {
+			return nil, fmt.Errorf("invalid price %q: %w", *price, exchange.ErrInvalidParameter)
+		}
+		newPrice = &p
+	}
+	o, err := c.engine.AmendOrder(ctx, id, newSize, newPrice)
+	if err != nil {
+		return nil, err
+	}
+	return c.futuresOrder(o), nil
+}
+
+// --- Account and positions ---
+
+// GetFuturesAccount returns the simulated wallet.
+func (c *PaperClient) GetFuturesAccount(ctx context.Context, settle string) (*FuturesAccount, error) {
+	if err := c.checkSettle(settle); err != nil {
+		return nil, err
+	}
+	a := c.engine.Account()
+	result := &FuturesAccount{
+		Total:          a.Balance,
+		UnrealisedPnl:  a.UnrealizedPnL,
+		PositionMargin: a.PositionMargin,
+		OrderMargin:    a.OrderMargin,
+		Available:      a.Available,
+		Currency:       strings.ToUpper(a.Currency),
+	}
+	result.History.Pnl = a.RealizedPnL
+	result.History.Fee = a.Fees
+	return result, nil
+}
+
+// ListPositions returns the open simulated positions. holdingID is ignored.
+func (c *PaperClient) ListPositions(ctx context.Context, settle string, holdingID *string) (*[]Position, error) {
+	if err := c.checkSettle(settle); err != nil {
+		return nil, err
+	}
+	result := []Position{}
+	for _, p := range c.engine.Positions() {
+		if p.Side == "" {
+			result = append(result, c.position(p))
+		}
+	}
+	return &result, nil
+}
+
+// GetPosition returns the simulated position of a contract, which may be empty.
+func (c *PaperClient) GetPosition(ctx context.Context, settle, contract string) (*Position, error) {
+	if err := c.checkSettle(settle); err != nil {
+		return nil, err
+	}
+	p, err := c.engine.Position(paperSymbol(contract), "")
+	if err != nil {
+		return nil, err
+	}
+	result := c.position(p)
+	return &result, nil
+}
+
+// UpdatePositionMargin adds change (negative to withdraw) to the margin of a
+// simulated position.
+func (c *PaperClient) UpdatePositionMargin(ctx context.Context, settle, contract, change string) (*Position, error) {
+	if err := c.checkSettle(settle); err != nil {
+		return nil, err
+	}
+	amount, err := decimal.Parse(change)
+	if err != nil {
+		return nil, fmt.Errorf("invalid margin change %q: %w", change, exchange.ErrInvalidParameter)
+	}
+	p, err := c.engine.AddMargin(paperSymbol(contract), "", amount)
+	if err != nil {
+		return nil, err
+	}
+	result := c.position(p)
+	return &result, nil
+}
+
+// UpdatePositionLeverage sets the leverage of a simulated position. Cross
+// margin (leverage "0") is not simulated.
+func (c *PaperClient) UpdatePositionLeverage(ctx context.Context, settle, contract, leverage string, crossLeverageLimit *string) (*Position, error) {
+	if err := c.checkSettle(settle); err != nil {
+		return nil, err
+	}
+	lev, err := decimal.Parse(leverage)
+	if err != nil || lev.Sign() <= 0 {
+		return nil, fmt.Errorf("invalid leverage %q, cross margin is not simulated: %w", leverage, exchange.ErrInvalidParameter)
+	}
+	p, err := c.engine.SetLeverage(paperSymbol(contract), "", int(lev.IntPart()))
+	if err != nil {
+		return nil, err
+	}
+	result := c.position(p)
+	return &result, nil
+}
+
+// --- Price-triggered orders ---
+
+// CreateTriggerOrder registers a simulated price-triggered order.
+func (c *PaperClient) CreateTriggerOrder(ctx context.Context, settle string, order CreateTriggerOrderRequest) (*TriggerOrder, error) {
+	if err := c.checkSettle(settle); err != nil {
+		return nil, err
+	}
+	initial := order.Initial
+	price := initial.Price.String()
+	req, err := paperOrderRequest(CreateFuturesOrderRequest{
+		Contract:   initial.Contract,
+		Size:       initial.Size,
+		Price:      &price,
+		Close:      initial.Close,
+		ReduceOnly: initial.ReduceOnly || initial.IsReduceOnly,
+		Tif:        initial.Tif,
+		Text:       initial.Text,
+		AutoSize:   initial.AutoSize,
+	})
+	if err != nil {
+		return nil, err
+	}
+	t, err := c.engine.PlaceTrigger(ctx, paper.TriggerRequest{
+		Order:      req,
+		Price:      order.Trigger.Price,
+		Rule:       paper.TriggerRule(order.Trigger.Rule),
+		Expiration: time.Duration(order.Trigger.Expiration) * time.Second,
+	})
+	if err != nil {
+		return nil, err
+	}
+	p := c.triggerOrder(t)
+	return &TriggerOrder{ID: p.ID, Initial: p.Initial, Trigger: p.Trigger, Status: p.Status, Reason: p.Reason}, nil
+}
+
+// ListTriggerOrders lists simulated price-triggered orders, newest first.
+func (c *PaperClient) ListTriggerOrders(ctx context.Context, settle, status string, contract *string, limit, offset *int) (*ListPriceTriggeredOrdersResult, error) {
+	if err := c.checkSettle(settle); err != nil {
+		return nil, err
+	}
+	result := ListPriceTriggeredOrdersResult{}
+	for _, t := range c.engine.Triggers(paperSymbol(deref(contract)), status == "open") {
+		result = append(result, c.triggerOrder(&t))
+	}
+	result = page(result, limit, offset)
+	return &result, nil
+}
+
+// CancelAllTriggerOrders cancels the open simulated price-triggered orders of
+// a contract.
+func (c *PaperClient) CancelAllTriggerOrders(ctx context.Context, settle, contract string) (*ListPriceTriggeredOrdersResult, error) {
+	if err := c.checkSettle(settle); err != nil {
+		return nil, err
+	}
+	triggers, err := c.engine.CancelTriggers(ctx, paperSymbol(contract))
+	if err != nil {
+		return nil, err
+	}
+	result := ListPriceTriggeredOrdersResult{}
+	for i := range triggers {
+		result = append(result, c.triggerOrder(&triggers[i]))
+	}
+	return &result, nil
+}
+
+// GetTriggerOrder returns a simulated price-triggered order.
+func (c *PaperClient) GetTriggerOrder(ctx context.Context, settle, orderID string) (*PriceTriggeredOrder, error) {
+	if err := c.checkSettle(settle); err != nil {
+		return nil, err
+	}
+	id, err := strconv.ParseInt(orderID, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid order ID %q: %w", orderID, exchange.ErrInvalidParameter)
+	}
+	t, err := c.engine.Trigger(id)
+	if err != nil {
+		return nil, err
+	}
+	result := c.triggerOrder(t)
+	return &result, nil
+}
+
+// CancelTriggerOrder cancels a simulated price-triggered order.
+func (c *PaperClient) CancelTriggerOrder(ctx context.Context, settle, orderID string) (*CancelPriceTriggeredOrderResult, error) {
+	if err := c.checkSettle(settle); err != nil {
+		return nil, err
+	}
+	id, err := strconv.ParseInt(orderID, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid order ID %q: %w", orderID, exchange.ErrInvalidParameter)
+	}
+	t, err := c.engine.CancelTrigger(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	result := CancelPriceTriggeredOrderResult(c.triggerOrder(t))
+	return &result, nil
+}
+
+// --- Conversion helpers ---
+
+func (c *PaperClient) checkSettle(settle string) error {
+	if currency := c.engine.Config().Currency; !strings.EqualFold(settle, currency) {
+		return fmt.Errorf("settle %q does not match the simulated %s account: %w", settle, currency, exchange.ErrInvalidParameter)
+	}
+	return nil
+}
+
+// orderID resolves an order ID or a "t-" text label.
+func (c *PaperClient) orderID(orderID string) (int64, error) {
+	if !strings.HasPrefix(orderID, "t-") {
+		id, err := strconv.ParseInt(orderID, 10, 64)
+		if err != nil {
+			return 0, fmt.Errorf("invalid order ID %q: %w", orderID, exchange.ErrInvalidParameter)
+		}
+		return id, nil
+	}
+	for _, open := range []bool{true, false} {
+		for _, o := range c.engine.Orders("", open) {
+			if o.ClientOrderID == orderID {
+				return o.ID, nil
+			}
+		}
+	}
+	return 0, fmt.Errorf("order %s: %w", orderID, exchange.ErrOrderNotFound)
+}
+
+// paperSymbol converts a contract name to the engine's canonical symbol.
+func paperSymbol(contract string) string {
+	if contract == "" {
+		return ""
+	}
+	return symbols.Canonical(contract)
+}
+
+func deref(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
+
+// paperOrderRequest converts a Gate.io order: the sign of Size gives the
+// side, a missing or zero price makes a market order and Close with size 0
+// closes the position.
+func paperOrderRequest(order CreateFuturesOrderRequest) (paper.OrderRequest, error) {
+	if order.AutoSize != "" {
+		return paper.OrderRequest{}, fmt.Errorf("auto_size: dual position mode is not simulated: %w", exchange.ErrInvalidParameter)
+	}
+	req := paper.OrderRequest{
+		Symbol:        paperSymbol(order.Contract),
+		Side:          exchange.Buy,
+		Type:          exchange.Limit,
+		Size:          decimal.NewFromInt(order.Size).Abs(),
+		TimeInForce:   fromGateTif(order.Tif),
+		ReduceOnly:    order.ReduceOnly,
+		Close:         order.Close && order.Size == 0,
+		ClientOrderID: order.Text,
+	}
+	if order.Size < 0 {
+		req.Side = exchange.Sell
+	}
+	if order.Price != nil {
+		price, err := decimal.Parse(*order.Price)
+		if err != nil {
+			return paper.OrderRequest{}, fmt.Errorf("invalid price %q: %w", *order.Price, exchange.ErrInvalidParameter)
+		}
+		req.Price = price
+	}
+	if req.Price.IsZero() {
+		req.Type = exchange.Market
+	}
+	return req, nil
+}
+
+func (c *PaperClient) futuresOrder(o *paper.Order) *FuturesOrder {
+	cfg := c.engine.Config()
+	size, left := o.Size.IntPart(), o.Left().IntPart()
+	if o.Side == exchange.Sell {
+		size, left = -size, -left
+	}
+	result := &FuturesOrder{
+		ID:           o.ID,
+		CreateTime:   decimal.New(o.CreateTime.UnixMilli(), -3),
+		Status:       "open",
+		Contract:     o.Symbol,
+		Size:         size,
+		Close:        o.Close,
+		IsClose:      o.Close,
+		ReduceOnly:   o.ReduceOnly,
+		IsReduceOnly: o.ReduceOnly,
+		Tif:          toGateTif(o.TimeInForce),
+		Left:         left,
+		FillPrice:    o.AvgPrice,
+		Text:         o.ClientOrderID,
+		Tkfr:         cfg.TakerFee,
+		Mkfr:         cfg.MakerFee,
+	}
+	if o.Type == exchange.Limit {
+		result.Price = o.Price
+	}
+	if !o.IsOpen() {
+		result.Status = "finished"
+		result.FinishTime = decimal.New(o.UpdateTime.UnixMilli(), -3)
+		switch o.FinishAs {
+		case paper.FinishPostOnly:
+			result.FinishAs = "poc"
+		case paper.FinishFOK:
+			result.FinishAs = "ioc"
+		default:
+			result.FinishAs = string(o.FinishAs)
+		}
+	}
+	return result
+}
+
+func (c *PaperClient) position(p paper.Position) Position {
+	var value decimal.Decimal
+	if contract, err := c.engine.Contract(p.Symbol); err == nil {
+		value = contract.Notional(p.Size, p.MarkPrice)
+	}
+	pending := 0
+	for _, o := range c.engine.Orders(p.Symbol, true) {
+		if o.PositionSide == p.Side {
+			pending++
+		}
+	}
+	return Position{
+		Contract:      p.Symbol,
+		Size:          p.Size.IntPart(),
+		Leverage:      decimal.NewFromInt(int64(p.Leverage)),
+		Value:         value,
+		Margin:        p.Margin,
+		EntryPrice:    p.EntryPrice,
+		MarkPrice:     p.MarkPrice,
+		InitialMargin: p.Margin,
+		UnrealisedPnl: p.UnrealizedPnL,
+		RealisedPnl:   p.RealizedPnL,
+		PendingOrders: pending,
+		Mode:          "single",
+	}
+}
+
+func (c *PaperClient) triggerOrder(t *paper.Trigger) PriceTriggeredOrder {
+	initial := c.futuresOrder(&paper.Order{OrderRequest: t.Order, Status: exchange.StatusOpen, CreateTime: t.CreateTime})
+	initial.ID, initial.Status, initial.Left = 0, "", 0
+	return PriceTriggeredOrder{
+		ID:         t.ID,
+		Contract:   t.Order.Symbol,
+		CreateTime: t.CreateTime.Unix(),
+		Trigger: Trigger{
+			Price:      t.Price,
+			Rule:       int(t.Rule),
+			Expiration: int(t.Expiration / time.Second),
+		},
+		Initial: *initial,
+		Status:  string(t.Status),
+		Reason:  t.Reason,
+	}
+}
+
+// page applies Gate.io's limit (default 100) and offset to a list.
+func page[T any](list []T, limit, offset *int) []T {
+	start, n := 0, 100
+	if offset != nil && *offset > 0 {
+		start = *offset
+	}
+	if limit != nil && *limit > 0 {
+		n = *limit
+	}
+	if start >= len(list) {
+		return list[:0]
+	}
+	return list[start:min(start+n, len(list))]
+}
@@ -0,0 +1,69 @@
+package gateio
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/neqin/futures/ratelimit"
+)
+
+// DefaultRateLimits returns Gate.io's published futures REST limits:
+// public endpoints 200 requests per 10s, order placement/amendment/cancellation
+// 100 requests per second, and other private endpoints 200 requests per 10s.
+func DefaultRateLimits() map[ratelimit.Group]ratelimit.Rate {
+	return map[ratelimit.Group]ratelimit.Rate{
+		ratelimit.PublicMarket:   ratelimit.Every(200, 10*time.Second),
+		ratelimit.PrivateTrading: ratelimit.Every(100, time.Second),
+		ratelimit.PrivateAccount: ratelimit.Every(200, 10*time.Second),
+	}
+}
+
+// publicEndpoints are the path segments (after /futures/{settle}/ or
+// /delivery/{settle}/) served without authentication.
+var publicEndpoints = map[string]bool{
+	"contracts":          true,
+	"order_book":         true,
+	"trades":             true,
+	"candlesticks":       true,
+	"premium_index":      true,
+	"tickers":            true,
+	"funding_rate":       true,
+	"insurance":          true,
+	"contract_stats":     true,
+	"index_constituents": true,
+	"liq_orders":         true,
+	"risk_limit_tiers":   true,
+}
+
+// tradingEndpoints are the path segments whose POST, PUT and DELETE requests
+// create, amend or cancel orders.
+var tradingEndpoints = map[string]bool{
+	"orders":               true,
+	"batch_orders":         true,
+	"batch_amend_orders":   true,
+	"price_orders":         true,
+	"countdown_cancel_all": true,
+}
+
+// endpointGroup classifies a request such as GET "/futures/usdt/orders/123".
+// Listing and fetching orders count against the account limit; only the
+// writing methods of the trading endpoints use the trading bucket.
+func endpointGroup(method, endpointPath string) ratelimit.Group {
+	parts := strings.Split(strings.TrimPrefix(endpointPath, "/"), "/")
+	if len(parts) < 3 {
+		return ratelimit.PrivateAccount
+	}
+	segment := parts[2]
+	if segment == "dual_comp" && len(parts) > 3 {
+		segment = parts[3]
+	}
+	switch {
+	case publicEndpoints[segment]:
+		return ratelimit.PublicMarket
+	case tradingEndpoints[segment] && (method == http.MethodPost || method == http.MethodPut || method == http.MethodDelete):
+		return ratelimit.PrivateTrading
+	default:
+		return ratelimit.PrivateAccount
+	}
+}
@@ -0,0 +1,35 @@
+package gateio
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/neqin/futures/ratelimit"
+)
+
+func TestEndpointGroup(t *testing.T) {
+	tests := []struct {
+		method, path string
+		want         ratelimit.Group
+	}{
+		{http.MethodGet, "/futures/usdt/contracts", ratelimit.PublicMarket},
+		{http.MethodGet, "/delivery/usdt/order_book", ratelimit.PublicMarket},
+		{http.MethodPost, "/futures/usdt/orders", ratelimit.PrivateTrading},
+		{http.MethodPut, "/futures/usdt/orders/123", ratelimit.PrivateTrading},
+		{http.MethodDelete, "/futures/usdt/orders", ratelimit.PrivateTrading},
+		{http.MethodPost, "/futures/usdt/batch_orders", ratelimit.PrivateTrading},
+		{http.MethodPost, "/futures/usdt/countdown_cancel_all", ratelimit.PrivateTrading},
+		{http.MethodGet, "/futures/usdt/orders", ratelimit.PrivateAccount},
+		{http.MethodGet, "/futures/usdt/orders/123", ratelimit.PrivateAccount},
+		{http.MethodGet, "/futures/usdt/price_orders", ratelimit.PrivateAccount},
+		{http.MethodGet, "/futures/usdt/accounts", ratelimit.PrivateAccount},
+		{http.MethodPost, "/futures/usdt/positions/BTC_USDT/leverage", ratelimit.PrivateAccount},
+		{http.MethodGet, "/futures/usdt/dual_comp/positions/BTC_USDT", ratelimit.PrivateAccount},
+		{http.MethodGet, "/wallet", ratelimit.PrivateAccount},
+	}
+	for _, tt := range tests {
+		if got := endpointGroup(tt.method, tt.path); got != tt.want {
+			t.Errorf("%s %s: got %s, want %s", tt.method, tt.path, got, tt.want)
+		}
+	}
+}
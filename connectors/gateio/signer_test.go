@@ -0,0 +1,75 @@
+package gateio_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/neqin/futures/connectors/gateio"
+	"github.com/neqin/futures/connectors/gateio/gateiotest"
+)
+
+// Known-answer vectors for the secret "gate-secret".
+var signatureVectors = []struct {
+	name                             string
+	method, path, query, body, stamp string
+	payload, sign                    string
+}{
+	{
+		name:   "GET with query",
+		method: "GET", path: "/api/v4/futures/usdt/orders", query: "contract=BTC_USDT&status=open", stamp: "1700000000",
+		payload: "GET\n/api/v4/futures/usdt/orders\ncontract=BTC_USDT&status=open\n" +
+			"cf83e1357eefb8bdf1542850d66d8007d620e4050b5715dc83f4a921d36ce9ce47d0d13c5d85f2b0ff8318d2877eec2f63b931bd47417a81a538327af927da3e\n1700000000",
+		sign: "20bbc25f29b39a5bd63b01ec40c87a8f8714e91523fed8df5c64947b5d6428db61fc8fcca368867ccb973c415bfad764bcd5bb104893d043e2e177f9192d41c7",
+	},
+	{
+		name:   "POST with body",
+		method: "POST", path: "/api/v4/futures/usdt/orders", body: `{"contract":"BTC_USDT","size":10,"price":"30000","tif":"gtc"}`, stamp: "1700000000",
+		payload: "POST\n/api/v4/futures/usdt/orders\n\n" +
+			"b6d6d18451e2765523430f17feaba2c0a0232d55f9b5f93323604c4520d62d35ddb92256547c3b1968e6f09877fd2853d268fca4cb5ac6bfab876ae2503aea86\n1700000000",
+		sign: "3914344e0dd6f0605d23de1ecc0afbab026633031f99411f4bc910193dfe3073e6547cc529e39095d0d8990fc5e8b714bed5c00073fc3361c608d7f00c0d7101",
+	},
+}
+
+func TestRequestSignatureVectors(t *testing.T) {
+	signer := gateio.NewHMACSigner("gate-secret")
+	for _, v := range signatureVectors {
+		payload := gateio.RequestPayload(v.method, v.path, v.query, v.body, v.stamp)
+		if payload != v.payload {
+			t.Errorf("%s: payload = %q, want %q", v.name, payload, v.payload)
+		}
+		sign, err := signer.Sign(context.Background(), payload)
+		if err != nil || sign != v.sign {
+			t.Errorf("%s: sign = %s, %v, want %s", v.name, sign, err, v.sign)
+		}
+	}
+}
+
+func TestWSSignatureVector(t *testing.T) {
+	payload := gateio.WSPayload("futures.orders", "subscribe", 1700000000)
+	if want := "channel=futures.orders&event=subscribe&time=1700000000"; payload != want {
+		t.Errorf("payload = %q, want %q", payload, want)
+	}
+	sign, _ := gateio.NewHMACSigner("gate-secret").Sign(context.Background(), payload)
+	if want := "e3de3a5b86e32bed07ab1b4f84f7d4643fcd268c6a17baaa84d9160c254ffe6f29f64cd71aaa4f60b8dc27be99dd9a8ef4a5f4d6021274e0e660066554689758"; sign != want {
+		t.Errorf("sign = %s, want %s", sign, want)
+	}
+}
+
+func TestExternalSigner(t *testing.T) {
+	srv, _ := newServer(t)
+	remote := gateio.NewHMACSigner(gateiotest.SecretKey) // Stands in for a signing service
+	calls := 0
+
+	client := gateio.NewClient(gateiotest.APIKey, "", nil)
+	client.SetBaseURL(srv.URL)
+	client.SetSigner(gateio.SignerFunc(func(ctx context.Context, payload string) (string, error) {
+		calls++
+		return remote.Sign(ctx, payload)
+	}))
+	if _, err := client.GetFuturesAccount(context.Background(), settle); err != nil {
+		t.Fatal(err)
+	}
+	if calls != 1 {
+		t.Errorf("signer calls = %d, want 1", calls)
+	}
+}
@@ -0,0 +1,76 @@
+package gateio
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/neqin/futures/decimal"
+	"github.com/neqin/futures/symbols"
+)
+
+// SymbolLoader implements symbols.Loader with ListFuturesContracts.
+type SymbolLoader struct {
+	client  *Client
+	settles []string
+}
+
+// NewSymbolLoader creates a loader for the perpetual contracts of the given
+// settlement currencies ("usdt", "btc"); both are loaded if none are given.
+func NewSymbolLoader(client *Client, settles ...string) *SymbolLoader {
+	if len(settles) == 0 {
+		settles = []string{"usdt", "btc"}
+	}
+	return &SymbolLoader{client: client, settles: settles}
+}
+
+// Venue returns "gateio".
+func (l *SymbolLoader) Venue() string {
+	return "gateio"
+}
+
+// LoadContracts lists the contracts of every settlement currency. Contracts
+// of the currencies that loaded are returned together with the joined errors
+// of those that failed.
+func (l *SymbolLoader) LoadContracts(ctx context.Context) ([]symbols.Contract, error) {
+	var result []symbols.Contract
+	var errs []error
+	for _, settle := range l.settles {
+		contracts, err := l.client.ListFuturesContracts(ctx, settle)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", settle, err))
+			continue
+		}
+		for _, t := range *contracts {
+			result = append(result, symbolContract(t, settle))
+		}
+	}
+	return result, errors.Join(errs...)
+}
+
+// symbolContract converts a contract listed under settle to symbols.Contract.
+// Gate.io sizes are whole contracts; an inverse contract is worth 1 USD.
+func symbolContract(t Ticker, settle string) symbols.Contract {
+	base, quote := symbols.SplitSymbol(t.Name)
+	inverse := t.Type == "inverse"
+	multiplier := t.QuantoMultiplier
+	if inverse && multiplier.IsZero() {
+		multiplier = decimal.NewFromInt(1)
+	}
+	return symbols.Contract{
+		Symbol:            symbols.Canonical(t.Name),
+		VenueSymbol:       t.Name,
+		Base:              base,
+		Quote:             quote,
+		Settle:            strings.ToUpper(settle),
+		Inverse:           inverse,
+		TickSize:          t.OrderPriceRound,
+		LotSize:           decimal.NewFromInt(1),
+		MinSize:           decimal.NewFromInt(int64(t.OrderSizeMin)),
+		MaxSize:           decimal.NewFromInt(int64(t.OrderSizeMax)),
+		Multiplier:        multiplier,
+		PricePrecision:    symbols.Precision(t.OrderPriceRound),
+		QuantityPrecision: 0,
+	}
+}
@@ -10,8 +10,14 @@ import (
 // CreateFuturesOrder places a new futures order.
 // settle: "usdt" or "btc"
 // order: The order details defined in CreateFuturesOrderRequest.
+// If pre-trade validation is enabled (SetOrderValidation), the order is checked against the contract specs first.
 func (c *Client) CreateFuturesOrder(ctx context.Context, settle string, order CreateFuturesOrderRequest) (*FuturesOrder, error) {
 	endpoint := fmt.Sprintf("/futures/%s/orders", settle)
+	if c.validation != nil {
+		if err := c.validateOrder(ctx, settle, &order); err != nil {
+			return nil, err
+		}
+	}
 	var result FuturesOrder
 	err := c.post(ctx, endpoint, nil, order, &result)
 	if err != nil {
@@ -99,6 +105,28 @@ func (c *Client) BatchCancelFuturesOrders(ctx context.Context, settle string, or
 	return &result, nil
 }
 
+// BatchCreateFuturesOrders places multiple futures orders in one request.
+// settle: "usdt" or "btc"
+// orders: Up to 10 orders. Results are returned in request order; check Succeeded (or Err) of each.
+// If pre-trade validation is enabled, the whole batch is rejected when any order fails it.
+func (c *Client) BatchCreateFuturesOrders(ctx context.Context, settle string, orders []CreateFuturesOrderRequest) (*BatchFuturesOrdersResult, error) {
+	endpoint := fmt.Sprintf("/futures/%s/batch_orders", settle)
+	if c.validation != nil {
+		orders = append([]CreateFuturesOrderRequest(nil), orders...) // Normalization must not modify the caller's slice
+		for i := range orders {
+			if err := c.validateOrder(ctx, settle, &orders[i]); err != nil {
+				return nil, fmt.Errorf("order %d: %w", i, err)
+			}
+		}
+	}
+	var result BatchFuturesOrdersResult
+	err := c.post(ctx, endpoint, nil, BatchCreateFuturesOrdersRequest(orders), &result)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
 // GetFuturesOrder retrieves details of a single futures order.
 // settle: "usdt" or "btc"
 // orderID: The ID of the order to retrieve.
@@ -152,6 +180,19 @@ func (c *Client) AmendFuturesOrder(ctx context.Context, settle, orderID string,
 	return &result, nil
 }
 
+// BatchAmendFuturesOrders modifies multiple open orders in one request.
+// settle: "usdt" or "btc"
+// amends: Up to 10 amendments, each identified by OrderID or Text. Results are returned in request order; check Succeeded (or Err) of each.
+func (c *Client) BatchAmendFuturesOrders(ctx context.Context, settle string, amends []AmendFuturesOrderRequest) (*BatchFuturesOrdersResult, error) {
+	endpoint := fmt.Sprintf("/futures/%s/batch_amend_orders", settle)
+	var result BatchFuturesOrdersResult
+	err := c.post(ctx, endpoint, nil, amends, &result)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
 // ListMyFuturesTrades retrieves personal trading history.
 // settle: "usdt" or "btc"
 // contract: Filter by contract name (optional)
@@ -1,91 +1,95 @@
 package gateio
 
-import "fmt" // Added for APIError
+import (
+	"fmt" // Added for APIError
+
+	"github.com/neqin/futures/decimal"
+)
 
 // TickerResult defines the result for listing contracts or dual contracts.
 type TickerResult []Ticker
 
 // Ticker defines the structure for contract details.
 type Ticker struct {
-	FundingRateIndicative string  `json:"funding_rate_indicative"`
-	MarkPriceRound        string  `json:"mark_price_round"`
-	FundingOffset         int     `json:"funding_offset"`
-	InDelisting           bool    `json:"in_delisting"`
-	RiskLimitBase         string  `json:"risk_limit_base"`
-	InterestRate          string  `json:"interest_rate"`
-	IndexPrice            string  `json:"index_price"`
-	OrderPriceRound       string  `json:"order_price_round"`
-	OrderSizeMin          int     `json:"order_size_min"`
-	RefRebateRate         string  `json:"ref_rebate_rate"`
-	Name                  string  `json:"name"` // Contract name
-	RefDiscountRate       string  `json:"ref_discount_rate"`
-	OrderPriceDeviate     string  `json:"order_price_deviate"`
-	MaintenanceRate       string  `json:"maintenance_rate"`
-	MarkType              string  `json:"mark_type"`
-	FundingInterval       int     `json:"funding_interval"`
-	Type                  string  `json:"type"`
-	RiskLimitStep         string  `json:"risk_limit_step"`
-	EnableBonus           bool    `json:"enable_bonus"`
-	EnableCredit          bool    `json:"enable_credit"`
-	LeverageMin           string  `json:"leverage_min"`
-	FundingRate           string  `json:"funding_rate"`
-	LastPrice             float64 `json:"last_price,string"` // Use float64 and string tag for potential flexibility
-	MarkPrice             string  `json:"mark_price"`
-	OrderSizeMax          int     `json:"order_size_max"`
-	FundingNextApply      int     `json:"funding_next_apply"`
-	ShortUsers            int     `json:"short_users"`
-	ConfigChangeTime      int     `json:"config_change_time"`
-	CreateTime            int     `json:"create_time"`
-	TradeSize             int     `json:"trade_size"`
-	PositionSize          int     `json:"position_size"`
-	LongUsers             int     `json:"long_users"`
-	QuantoMultiplier      string  `json:"quanto_multiplier"`
-	FundingImpactValue    string  `json:"funding_impact_value"`
-	LeverageMax           string  `json:"leverage_max"`
-	CrossLeverageDefault  string  `json:"cross_leverage_default"`
-	RiskLimitMax          string  `json:"risk_limit_max"`
-	MakerFeeRate          string  `json:"maker_fee_rate"`
-	TakerFeeRate          string  `json:"taker_fee_rate"`
-	OrdersLimit           int     `json:"orders_limit"`
-	TradeID               int     `json:"trade_id"`
-	OrderbookID           int     `json:"orderbook_id"`
-	FundingCapRatio       string  `json:"funding_cap_ratio"`
-	VoucherLeverage       string  `json:"voucher_leverage"`
-	IsPreMarket           bool    `json:"is_pre_market"`
+	FundingRateIndicative decimal.Decimal `json:"funding_rate_indicative"`
+	MarkPriceRound        decimal.Decimal `json:"mark_price_round"`
+	FundingOffset         int             `json:"funding_offset"`
+	InDelisting           bool            `json:"in_delisting"`
+	RiskLimitBase         decimal.Decimal `json:"risk_limit_base"`
+	InterestRate          decimal.Decimal `json:"interest_rate"`
+	IndexPrice            decimal.Decimal `json:"index_price"`
+	OrderPriceRound       decimal.Decimal `json:"order_price_round"`
+	OrderSizeMin          int             `json:"order_size_min"`
+	RefRebateRate         decimal.Decimal `json:"ref_rebate_rate"`
+	Name                  string          `json:"name"` // Contract name
+	RefDiscountRate       decimal.Decimal `json:"ref_discount_rate"`
+	OrderPriceDeviate     decimal.Decimal `json:"order_price_deviate"`
+	MaintenanceRate       decimal.Decimal `json:"maintenance_rate"`
+	MarkType              string          `json:"mark_type"`
+	FundingInterval       int             `json:"funding_interval"`
+	Type                  string          `json:"type"`
+	RiskLimitStep         decimal.Decimal `json:"risk_limit_step"`
+	EnableBonus           bool            `json:"enable_bonus"`
+	EnableCredit          bool            `json:"enable_credit"`
+	LeverageMin           decimal.Decimal `json:"leverage_min"`
+	FundingRate           decimal.Decimal `json:"funding_rate"`
+	LastPrice             decimal.Decimal `json:"last_price"`
+	MarkPrice             decimal.Decimal `json:"mark_price"`
+	OrderSizeMax          int             `json:"order_size_max"`
+	FundingNextApply      int             `json:"funding_next_apply"`
+	ShortUsers            int             `json:"short_users"`
+	ConfigChangeTime      int             `json:"config_change_time"`
+	CreateTime            int             `json:"create_time"`
+	TradeSize             int             `json:"trade_size"`
+	PositionSize          int             `json:"position_size"`
+	LongUsers             int             `json:"long_users"`
+	QuantoMultiplier      decimal.Decimal `json:"quanto_multiplier"`
+	FundingImpactValue    decimal.Decimal `json:"funding_impact_value"`
+	LeverageMax           decimal.Decimal `json:"leverage_max"`
+	CrossLeverageDefault  decimal.Decimal `json:"cross_leverage_default"`
+	RiskLimitMax          decimal.Decimal `json:"risk_limit_max"`
+	MakerFeeRate          decimal.Decimal `json:"maker_fee_rate"`
+	TakerFeeRate          decimal.Decimal `json:"taker_fee_rate"`
+	OrdersLimit           int             `json:"orders_limit"`
+	TradeID               int             `json:"trade_id"`
+	OrderbookID           int             `json:"orderbook_id"`
+	FundingCapRatio       decimal.Decimal `json:"funding_cap_ratio"`
+	VoucherLeverage       decimal.Decimal `json:"voucher_leverage"`
+	IsPreMarket           bool            `json:"is_pre_market"`
 }
 
 // ContractStats defines the statistics of a futures contract.
 type ContractStats struct {
-	Time                  int64   `json:"time"`                    // Timestamp of the start of the candlestick, in milliseconds
-	Loi                   int64   `json:"loi"`                     // Long/Short open interest ratio
-	LsrAccount            float64 `json:"lsr_account"`             // Long/Short account ratio (Corrected type)
-	LsrTaker              float64 `json:"lsr_taker"`               // Long/Short taker ratio (Corrected type)
-	OpenInterest          int64   `json:"open_interest"`           // Open interest size
-	MarkPrice             float64 `json:"mark_price"`              // Mark price (Corrected type)
-	TopLsrSize            float64 `json:"top_lsr_size"`            // Top L/S size ratio (Corrected type)
-	FundingRate           float64 `json:"funding_rate"`            // Funding rate (Corrected type)
-	TopLsrAccount         float64 `json:"top_lsr_account"`         // Top L/S account ratio (Corrected type)
-	IndexPrice            float64 `json:"index_price"`             // Index price (Corrected type)
-	OpenInterestUsd       float64 `json:"open_interest_usd"`       // Open interest in USDT (Corrected type)
-	FundingRateIndicative float64 `json:"funding_rate_indicative"` // Indicative Funding rate (Corrected type)
-	Contract              string  `json:"contract"`                // Contract name
-	Volume                int64   `json:"volume"`                  // Trade size accumulated in 1 day
-	VolumeUsd             float64 `json:"volume_usd"`              // Trade volume in USDT accumulated in 1 day (Corrected type)
+	Time                  int64           `json:"time"`                    // Timestamp of the start of the candlestick, in milliseconds
+	Loi                   int64           `json:"loi"`                     // Long/Short open interest ratio
+	LsrAccount            decimal.Decimal `json:"lsr_account"`             // Long/Short account ratio (Corrected type)
+	LsrTaker              decimal.Decimal `json:"lsr_taker"`               // Long/Short taker ratio (Corrected type)
+	OpenInterest          int64           `json:"open_interest"`           // Open interest size
+	MarkPrice             decimal.Decimal `json:"mark_price"`              // Mark price (Corrected type)
+	TopLsrSize            decimal.Decimal `json:"top_lsr_size"`            // Top L/S size ratio (Corrected type)
+	FundingRate           decimal.Decimal `json:"funding_rate"`            // Funding rate (Corrected type)
+	TopLsrAccount         decimal.Decimal `json:"top_lsr_account"`         // Top L/S account ratio (Corrected type)
+	IndexPrice            decimal.Decimal `json:"index_price"`             // Index price (Corrected type)
+	OpenInterestUsd       decimal.Decimal `json:"open_interest_usd"`       // Open interest in USDT (Corrected type)
+	FundingRateIndicative decimal.Decimal `json:"funding_rate_indicative"` // Indicative Funding rate (Corrected type)
+	Contract              string          `json:"contract"`                // Contract name
+	Volume                int64           `json:"volume"`                  // Trade size accumulated in 1 day
+	VolumeUsd             decimal.Decimal `json:"volume_usd"`              // Trade volume in USDT accumulated in 1 day (Corrected type)
 	// Added fields based on error output
-	LongLiqSize     int64   `json:"long_liq_size"`
-	ShortLiqSize    int64   `json:"short_liq_size"`
-	ShortLiqUsd     float64 `json:"short_liq_usd"`
-	TopLongSize     int64   `json:"top_long_size"`
-	TopShortSize    int64   `json:"top_short_size"`
-	ShortLiqAmount  float64 `json:"short_liq_amount"`
-	LongLiqAmount   float64 `json:"long_liq_amount"`
-	TopLongAccount  int64   `json:"top_long_account"`
-	TopShortAccount int64   `json:"top_short_account"`
-	LongLiqUsd      float64 `json:"long_liq_usd"`
-	LongTakerSize   int64   `json:"long_taker_size"`
-	ShortTakerSize  int64   `json:"short_taker_size"`
-	LongUsers       int64   `json:"long_users"`
-	ShortUsers      int64   `json:"short_users"`
+	LongLiqSize     int64           `json:"long_liq_size"`
+	ShortLiqSize    int64           `json:"short_liq_size"`
+	ShortLiqUsd     decimal.Decimal `json:"short_liq_usd"`
+	TopLongSize     int64           `json:"top_long_size"`
+	TopShortSize    int64           `json:"top_short_size"`
+	ShortLiqAmount  decimal.Decimal `json:"short_liq_amount"`
+	LongLiqAmount   decimal.Decimal `json:"long_liq_amount"`
+	TopLongAccount  int64           `json:"top_long_account"`
+	TopShortAccount int64           `json:"top_short_account"`
+	LongLiqUsd      decimal.Decimal `json:"long_liq_usd"`
+	LongTakerSize   int64           `json:"long_taker_size"`
+	ShortTakerSize  int64           `json:"short_taker_size"`
+	LongUsers       int64           `json:"long_users"`
+	ShortUsers      int64           `json:"short_users"`
 }
 
 // ListContractStatsResult defines the result for listing contract stats.
@@ -93,15 +97,15 @@ type ListContractStatsResult []ContractStats
 
 // FutureOrderBookEntry defines a single entry in the order book.
 type FutureOrderBookEntry struct {
-	Price string `json:"p"` // Price
-	Size  int64  `json:"s"` // Size
+	Price decimal.Decimal `json:"p"` // Price
+	Size  int64           `json:"s"` // Size
 }
 
 // FutureOrderBook defines the structure for the futures order book response.
 type FutureOrderBook struct {
 	ID       int64                  `json:"id"`      // Order Book ID
-	Current  float64                `json:"current"` // Current timestamp (seconds with microseconds)
-	Update   float64                `json:"update"`  // Update timestamp (seconds with microseconds)
+	Current  decimal.Decimal        `json:"current"` // Current timestamp (seconds with microseconds)
+	Update   decimal.Decimal        `json:"update"`  // Update timestamp (seconds with microseconds)
 	Asks     []FutureOrderBookEntry `json:"asks"`
 	Bids     []FutureOrderBookEntry `json:"bids"`
 	Contract string                 `json:"contract"` // Added based on documentation example
@@ -109,11 +113,11 @@ type FutureOrderBook struct {
 
 // FuturesTrade defines the structure for a single futures trade.
 type FuturesTrade struct {
-	ID         int64   `json:"id"`          // Trade ID
-	CreateTime float64 `json:"create_time"` // Trading time (seconds with microseconds)
-	Contract   string  `json:"contract"`    // Futures contract name
-	Size       int64   `json:"size"`        // Trading size, >0 means buy, <0 means sell
-	Price      string  `json:"price"`       // Trading price
+	ID         int64           `json:"id"`          // Trade ID
+	CreateTime decimal.Decimal `json:"create_time"` // Trading time (seconds with microseconds)
+	Contract   string          `json:"contract"`    // Futures contract name
+	Size       int64           `json:"size"`        // Trading size, >0 means buy, <0 means sell
+	Price      decimal.Decimal `json:"price"`       // Trading price
 }
 
 // ListFuturesTradesResult defines the result for listing futures trades.
@@ -121,13 +125,13 @@ type ListFuturesTradesResult []FuturesTrade
 
 // CandlestickData represents the structure of a single candlestick object from the API.
 type CandlestickData struct {
-	Timestamp int64   `json:"t"`          // Timestamp (seconds) - Corrected type
-	Volume    int64   `json:"v"`          // Volume (Using float64 with ,string for flexibility)
-	Close     float64 `json:"c,string"`   // Close price (Using float64 with ,string)
-	High      float64 `json:"h,string"`   // High price (Using float64 with ,string)
-	Low       float64 `json:"l,string"`   // Low price (Using float64 with ,string)
-	Open      float64 `json:"o,string"`   // Open price (Using float64 with ,string)
-	Sum       float64 `json:"sum,string"` // Total traded value (Using float64 with ,string)
+	Timestamp int64           `json:"t"`   // Timestamp (seconds) - Corrected type
+	Volume    int64           `json:"v"`   // Volume
+	Close     decimal.Decimal `json:"c"`   // Close price
+	High      decimal.Decimal `json:"h"`   // High price
+	Low       decimal.Decimal `json:"l"`   // Low price
+	Open      decimal.Decimal `json:"o"`   // Open price
+	Sum       decimal.Decimal `json:"sum"` // Total traded value
 }
 
 // FuturesCandlestick represents a single candlestick entry (now an object).
@@ -139,9 +143,9 @@ type ListFuturesCandlesticksResult []FuturesCandlestick // Reverted: Array of ob
 // PremiumIndexData represents the structure of a single premium index object from the API.
 // Corrected based on API documentation: [timestamp, mark_price, index_price]
 type PremiumIndexData struct {
-	Timestamp  int64   `json:"t"`        // Timestamp (seconds) - Corrected type
-	MarkPrice  float64 `json:"m,string"` // Mark price (Assuming 'm', using float64 with ,string)
-	IndexPrice float64 `json:"i,string"` // Index price (Assuming 'i', using float64 with ,string)
+	Timestamp  int64           `json:"t"` // Timestamp (seconds) - Corrected type
+	MarkPrice  decimal.Decimal `json:"m"` // Mark price
+	IndexPrice decimal.Decimal `json:"i"` // Index price
 }
 
 // FuturesPremiumIndex defines the structure for premium index K-line data (now an object).
@@ -152,23 +156,23 @@ type ListFuturesPremiumIndexResult []FuturesPremiumIndex // Reverted: Array of o
 
 // FuturesTicker defines the structure for a futures ticker.
 type FuturesTicker struct {
-	Contract              string  `json:"contract"`                // Futures contract name
-	Last                  string  `json:"last"`                    // Last traded price
-	ChangePercentage      string  `json:"change_percentage"`       // Change percentage.
-	TotalSize             string  `json:"total_size"`              // Total size traded in the last 24 hours
-	Low24H                string  `json:"low_24h"`                 // Lowest price in 24h
-	High24H               string  `json:"high_24h"`                // Highest price in 24h
-	Volume24H             string  `json:"volume_24h"`              // Trade size in the last 24 hours
-	Volume24HBtc          string  `json:"volume_24h_btc"`          // Trade volumes in BTC in the last 24 hours
-	Volume24HUsd          string  `json:"volume_24h_usd"`          // Trade volumes in USD in the last 24 hours
-	Volume24HQuote        string  `json:"volume_24h_quote"`        // Trade volumes in quote currency in the last 24 hours
-	MarkPrice             string  `json:"mark_price"`              // Mark price
-	FundingRate           string  `json:"funding_rate"`            // Funding rate
-	FundingRateIndicative string  `json:"funding_rate_indicative"` // Indicative Funding rate
-	IndexPrice            string  `json:"index_price"`             // Index price
-	QuantoBaseRate        *string `json:"quanto_base_rate"`        // Quanto base rate (nullable)
-	HighestBid            *string `json:"highest_bid"`             // Highest bid price (nullable)
-	LowestAsk             *string `json:"lowest_ask"`              // Lowest ask price (nullable)
+	Contract              string           `json:"contract"`                // Futures contract name
+	Last                  decimal.Decimal  `json:"last"`                    // Last traded price
+	ChangePercentage      decimal.Decimal  `json:"change_percentage"`       // Change percentage.
+	TotalSize             decimal.Decimal  `json:"total_size"`              // Total size traded in the last 24 hours
+	Low24H                decimal.Decimal  `json:"low_24h"`                 // Lowest price in 24h
+	High24H               decimal.Decimal  `json:"high_24h"`                // Highest price in 24h
+	Volume24H             decimal.Decimal  `json:"volume_24h"`              // Trade size in the last 24 hours
+	Volume24HBtc          decimal.Decimal  `json:"volume_24h_btc"`          // Trade volumes in BTC in the last 24 hours
+	Volume24HUsd          decimal.Decimal  `json:"volume_24h_usd"`          // Trade volumes in USD in the last 24 hours
+	Volume24HQuote        decimal.Decimal  `json:"volume_24h_quote"`        // Trade volumes in quote currency in the last 24 hours
+	MarkPrice             decimal.Decimal  `json:"mark_price"`              // Mark price
+	FundingRate           decimal.Decimal  `json:"funding_rate"`            // Funding rate
+	FundingRateIndicative decimal.Decimal  `json:"funding_rate_indicative"` // Indicative Funding rate
+	IndexPrice            decimal.Decimal  `json:"index_price"`             // Index price
+	QuantoBaseRate        *decimal.Decimal `json:"quanto_base_rate"`        // Quanto base rate (nullable)
+	HighestBid            *decimal.Decimal `json:"highest_bid"`             // Highest bid price (nullable)
+	LowestAsk             *decimal.Decimal `json:"lowest_ask"`              // Lowest ask price (nullable)
 }
 
 // ListFuturesTickersResult defines the result for listing futures tickers.
@@ -176,8 +180,8 @@ type ListFuturesTickersResult []FuturesTicker
 
 // FundingRate defines the structure for a funding rate history entry.
 type FundingRate struct {
-	Timestamp int64  `json:"t"` // Timestamp (seconds)
-	Rate      string `json:"r"` // Funding rate
+	Timestamp int64           `json:"t"` // Timestamp (seconds)
+	Rate      decimal.Decimal `json:"r"` // Funding rate
 }
 
 // ListFuturesFundingRateHistoryResult defines the result for listing funding rate history.
@@ -185,8 +189,8 @@ type ListFuturesFundingRateHistoryResult []FundingRate
 
 // InsuranceRecord defines the structure for an insurance ledger entry.
 type InsuranceRecord struct {
-	Timestamp int64  `json:"t"` // Timestamp (seconds)
-	Change    string `json:"d"` // Change amount
+	Timestamp int64           `json:"t"` // Timestamp (seconds)
+	Change    decimal.Decimal `json:"d"` // Change amount
 }
 
 // ListFuturesInsuranceLedgerResult defines the result for listing insurance ledger records.
@@ -205,18 +209,18 @@ type ListDualCompIndexConstituentsResult map[string][]string
 
 // LiquidationOrder defines the structure for a liquidation order record.
 type LiquidationOrder struct {
-	Time       int64  `json:"time"`        // Liquidation time (seconds)
-	Contract   string `json:"contract"`    // Futures contract
-	Size       int64  `json:"size"`        // Position size liquidated
-	Leverage   string `json:"leverage"`    // Position leverage
-	Margin     string `json:"margin"`      // Position margin
-	EntryPrice string `json:"entry_price"` // Average entry price
-	LiqPrice   string `json:"liq_price"`   // Liquidation price
-	MarkPrice  string `json:"mark_price"`  // Mark price at liquidation time
-	OrderID    int64  `json:"order_id"`    // Order ID of the liquidation order
-	OrderPrice string `json:"order_price"` // Order price of the liquidation order
-	FillPrice  string `json:"fill_price"`  // Fill price of the liquidation order
-	Left       int64  `json:"left"`        // Size left after liquidation
+	Time       int64           `json:"time"`        // Liquidation time (seconds)
+	Contract   string          `json:"contract"`    // Futures contract
+	Size       int64           `json:"size"`        // Position size liquidated
+	Leverage   decimal.Decimal `json:"leverage"`    // Position leverage
+	Margin     decimal.Decimal `json:"margin"`      // Position margin
+	EntryPrice decimal.Decimal `json:"entry_price"` // Average entry price
+	LiqPrice   decimal.Decimal `json:"liq_price"`   // Liquidation price
+	MarkPrice  decimal.Decimal `json:"mark_price"`  // Mark price at liquidation time
+	OrderID    int64           `json:"order_id"`    // Order ID of the liquidation order
+	OrderPrice decimal.Decimal `json:"order_price"` // Order price of the liquidation order
+	FillPrice  decimal.Decimal `json:"fill_price"`  // Fill price of the liquidation order
+	Left       int64           `json:"left"`        // Size left after liquidation
 }
 
 // GetLiquidationHistoryResult defines the result for listing liquidation history.
@@ -224,11 +228,11 @@ type GetLiquidationHistoryResult []LiquidationOrder
 
 // RiskLimitTier defines the structure for a risk limit tier.
 type RiskLimitTier struct {
-	Tier            int    `json:"tier"`             // Risk limit tier
-	RiskLimit       string `json:"risk_limit"`       // Risk limit
-	InitialRate     string `json:"initial_rate"`     // Initial margin rate
-	MaintenanceRate string `json:"maintenance_rate"` // Maintenance margin rate
-	LeverageMax     string `json:"leverage_max"`     // Maximum leverage
+	Tier            int             `json:"tier"`             // Risk limit tier
+	RiskLimit       decimal.Decimal `json:"risk_limit"`       // Risk limit
+	InitialRate     decimal.Decimal `json:"initial_rate"`     // Initial margin rate
+	MaintenanceRate decimal.Decimal `json:"maintenance_rate"` // Maintenance margin rate
+	LeverageMax     decimal.Decimal `json:"leverage_max"`     // Maximum leverage
 }
 
 // GetRiskLimitTiersResult defines the result for listing risk limit tiers.
@@ -236,29 +240,29 @@ type GetRiskLimitTiersResult []RiskLimitTier
 
 // FuturesAccount defines the structure for futures account details.
 type FuturesAccount struct {
-	User                  int      `json:"user"`                    // User ID
-	Total                 string   `json:"total"`                   // Total account balance in USDT
-	UnrealisedPnl         string   `json:"unrealised_pnl"`          // Unrealized PNL
-	PositionMargin        string   `json:"position_margin"`         // Position margin
-	OrderMargin           string   `json:"order_margin"`            // Order margin
-	Available             string   `json:"available"`               // Available balance
-	Point                 string   `json:"point"`                   // POINT amount
-	Currency              string   `json:"currency"`                // Settle currency
-	InDualMode            bool     `json:"in_dual_mode"`            // Whether dual mode is enabled
-	EnableCredit          bool     `json:"enable_credit"`           // Whether portfolio margin account mode is enabled
-	PositionInitialMargin string   `json:"position_initial_margin"` // Initial margin position
-	MaintenanceMargin     string   `json:"maintenance_margin"`      // Maintenance margin position
-	Bonus                 string   `json:"bonus"`                   // Perpetual Contract Bonus
-	History               struct { // History stats
-		Pnl         string `json:"pnl"`          // PNL
-		Fee         string `json:"fee"`          // Fee
-		Refr        string `json:"refr"`         // Referral fee
-		Fund        string `json:"fund"`         // Funding fee
-		PointPnl    string `json:"point_pnl"`    // POINT PNL
-		PointFee    string `json:"point_fee"`    // POINT fee
-		PointRefr   string `json:"point_refr"`   // POINT referral fee
-		BonusPnl    string `json:"bonus_pnl"`    // Bonus PNL
-		BonusOffset string `json:"bonus_offset"` // Bonus deduction
+	User                  int             `json:"user"`                    // User ID
+	Total                 decimal.Decimal `json:"total"`                   // Total account balance in USDT
+	UnrealisedPnl         decimal.Decimal `json:"unrealised_pnl"`          // Unrealized PNL
+	PositionMargin        decimal.Decimal `json:"position_margin"`         // Position margin
+	OrderMargin           decimal.Decimal `json:"order_margin"`            // Order margin
+	Available             decimal.Decimal `json:"available"`               // Available balance
+	Point                 decimal.Decimal `json:"point"`                   // POINT amount
+	Currency              string          `json:"currency"`                // Settle currency
+	InDualMode            bool            `json:"in_dual_mode"`            // Whether dual mode is enabled
+	EnableCredit          bool            `json:"enable_credit"`           // Whether portfolio margin account mode is enabled
+	PositionInitialMargin decimal.Decimal `json:"position_initial_margin"` // Initial margin position
+	MaintenanceMargin     decimal.Decimal `json:"maintenance_margin"`      // Maintenance margin position
+	Bonus                 decimal.Decimal `json:"bonus"`                   // Perpetual Contract Bonus
+	History               struct {        // History stats
+		Pnl         decimal.Decimal `json:"pnl"`          // PNL
+		Fee         decimal.Decimal `json:"fee"`          // Fee
+		Refr        decimal.Decimal `json:"refr"`         // Referral fee
+		Fund        decimal.Decimal `json:"fund"`         // Funding fee
+		PointPnl    decimal.Decimal `json:"point_pnl"`    // POINT PNL
+		PointFee    decimal.Decimal `json:"point_fee"`    // POINT fee
+		PointRefr   decimal.Decimal `json:"point_refr"`   // POINT referral fee
+		BonusPnl    decimal.Decimal `json:"bonus_pnl"`    // Bonus PNL
+		BonusOffset decimal.Decimal `json:"bonus_offset"` // Bonus deduction
 	} `json:"history"`
 }
 
@@ -267,64 +271,64 @@ type Position struct {
 	User               int                 `json:"user"`                 // User ID
 	Contract           string              `json:"contract"`             // Futures contract
 	Size               int64               `json:"size"`                 // Position size
-	Leverage           string              `json:"leverage"`             // Position leverage
-	RiskLimit          string              `json:"risk_limit"`           // Position risk limit
-	LeverageMax        string              `json:"leverage_max"`         // Maximum leverage under current risk limit
-	MaintenanceRate    string              `json:"maintenance_rate"`     // Maintenance rate under current risk limit
-	Value              string              `json:"value"`                // Position value
-	Margin             string              `json:"margin"`               // Position margin
-	EntryPrice         string              `json:"entry_price"`          // Entry price
-	LiqPrice           string              `json:"liq_price"`            // Liquidation price
-	MarkPrice          string              `json:"mark_price"`           // Mark price
-	InitialMargin      string              `json:"initial_margin"`       // Initial margin
-	MaintenanceMargin  string              `json:"maintenance_margin"`   // Maintenance margin
-	UnrealisedPnl      string              `json:"unrealised_pnl"`       // Unrealized PNL
-	RealisedPnl        string              `json:"realised_pnl"`         // Realized PNL
-	HistoryPnl         string              `json:"history_pnl"`          // History PNL
-	LastClosePnl       string              `json:"last_close_pnl"`       // PNL from last close
-	RealisedPoint      string              `json:"realised_point"`       // Realized POINT PNL
-	HistoryPoint       string              `json:"history_point"`        // History POINT PNL
+	Leverage           decimal.Decimal     `json:"leverage"`             // Position leverage
+	RiskLimit          decimal.Decimal     `json:"risk_limit"`           // Position risk limit
+	LeverageMax        decimal.Decimal     `json:"leverage_max"`         // Maximum leverage under current risk limit
+	MaintenanceRate    decimal.Decimal     `json:"maintenance_rate"`     // Maintenance rate under current risk limit
+	Value              decimal.Decimal     `json:"value"`                // Position value
+	Margin             decimal.Decimal     `json:"margin"`               // Position margin
+	EntryPrice         decimal.Decimal     `json:"entry_price"`          // Entry price
+	LiqPrice           decimal.Decimal     `json:"liq_price"`            // Liquidation price
+	MarkPrice          decimal.Decimal     `json:"mark_price"`           // Mark price
+	InitialMargin      decimal.Decimal     `json:"initial_margin"`       // Initial margin
+	MaintenanceMargin  decimal.Decimal     `json:"maintenance_margin"`   // Maintenance margin
+	UnrealisedPnl      decimal.Decimal     `json:"unrealised_pnl"`       // Unrealized PNL
+	RealisedPnl        decimal.Decimal     `json:"realised_pnl"`         // Realized PNL
+	HistoryPnl         decimal.Decimal     `json:"history_pnl"`          // History PNL
+	LastClosePnl       decimal.Decimal     `json:"last_close_pnl"`       // PNL from last close
+	RealisedPoint      decimal.Decimal     `json:"realised_point"`       // Realized POINT PNL
+	HistoryPoint       decimal.Decimal     `json:"history_point"`        // History POINT PNL
 	AdlRanking         int                 `json:"adl_ranking"`          // ADL ranking, range from 1 to 5
 	PendingOrders      int                 `json:"pending_orders"`       // Current open orders count for the position
 	CloseOrder         *PositionCloseOrder `json:"close_order"`          // Position close order (nullable)
 	Mode               string              `json:"mode"`                 // Position mode, single or dual.
-	CrossLeverageLimit string              `json:"cross_leverage_limit"` // Cross margin leverage(valid only when cross margin is used)
+	CrossLeverageLimit decimal.Decimal     `json:"cross_leverage_limit"` // Cross margin leverage(valid only when cross margin is used)
 }
 
 // PositionCloseOrder defines the structure for a position's close order.
 type PositionCloseOrder struct {
-	ID    int64  `json:"id"`     // Close order ID
-	Price string `json:"price"`  // Close order price
-	IsLiq bool   `json:"is_liq"` // Is the close order a liquidation order
+	ID    int64           `json:"id"`     // Close order ID
+	Price decimal.Decimal `json:"price"`  // Close order price
+	IsLiq bool            `json:"is_liq"` // Is the close order a liquidation order
 }
 
 // FuturesOrder defines the structure for a futures order.
 type FuturesOrder struct {
-	ID           int64   `json:"id"`             // Futures order ID
-	User         int     `json:"user"`           // User ID
-	CreateTime   float64 `json:"create_time"`    // Creation time
-	FinishTime   float64 `json:"finish_time"`    // Finish time
-	FinishAs     string  `json:"finish_as"`      // How the order was finished. Enum: "filled", "cancelled", "liquidated", "ioc", "auto_deleveraged", "reduce_only", "position_closed", "reduce_out"
-	Status       string  `json:"status"`         // Order status. Enum: "open", "finished"
-	Contract     string  `json:"contract"`       // Futures contract
-	Size         int64   `json:"size"`           // Order size. Positive means buy, negative means sell. Set to 0 to close the position
-	Iceberg      int64   `json:"iceberg"`        // Display size for iceberg order. 0 for non-iceberg. Note that you will have to pay the taker fee for the hidden size
-	Price        string  `json:"price"`          // Order price. 0 for market order with tif set as ioc
-	Close        bool    `json:"close"`          // Set as true to close the position, with size set to 0
-	IsClose      bool    `json:"is_close"`       // Is the order to close position
-	ReduceOnly   bool    `json:"reduce_only"`    // Set as true to be reduce-only order
-	IsReduceOnly bool    `json:"is_reduce_only"` // Is the order reduce-only
-	IsLiq        bool    `json:"is_liq"`         // Is the order for liquidation
-	Tif          string  `json:"tif"`            // Time in force. Enum: "gtc", "ioc", "poc", "fok"
-	Left         int64   `json:"left"`           // Size left to be traded
-	FillPrice    string  `json:"fill_price"`     // Fill price of the order
-	Text         string  `json:"text"`           // User defined information. If not empty, must follow the rules below:  1. prefixed with t- 2. no longer than 28 bytes without prefix, consisting of letters, numbers, underscores, hyphen -, periods .
-	Tkfr         string  `json:"tkfr"`           // Taker fee
-	Mkfr         string  `json:"mkfr"`           // Maker fee
-	Refu         int     `json:"refu"`           // Reference user ID
-	AutoSize     string  `json:"auto_size"`      // Set side to close dual-mode position. Required if close is true. ("long", "short")
-	StpAct       string  `json:"stp_act"`        // Self-Trading Prevention Action. Enum: "cn", "co", "cb", ""
-	StpID        int     `json:"stp_id"`         // Self-Trading Prevention ID. Orders with the same stp_id will be prevented from matching. Valid range: [1, 9223372036854775807]
+	ID           int64           `json:"id"`             // Futures order ID
+	User         int             `json:"user"`           // User ID
+	CreateTime   decimal.Decimal `json:"create_time"`    // Creation time
+	FinishTime   decimal.Decimal `json:"finish_time"`    // Finish time
+	FinishAs     string          `json:"finish_as"`      // How the order was finished. Enum: "filled", "cancelled", "liquidated", "ioc", "auto_deleveraged", "reduce_only", "position_closed", "reduce_out"
+	Status       string          `json:"status"`         // Order status. Enum: "open", "finished"
+	Contract     string          `json:"contract"`       // Futures contract
+	Size         int64           `json:"size"`           // Order size. Positive means buy, negative means sell. Set to 0 to close the position
+	Iceberg      int64           `json:"iceberg"`        // Display size for iceberg order. 0 for non-iceberg. Note that you will have to pay the taker fee for the hidden size
+	Price        decimal.Decimal `json:"price"`          // Order price. 0 for market order with tif set as ioc
+	Close        bool            `json:"close"`          // Set as true to close the position, with size set to 0
+	IsClose      bool            `json:"is_close"`       // Is the order to close position
+	ReduceOnly   bool            `json:"reduce_only"`    // Set as true to be reduce-only order
+	IsReduceOnly bool            `json:"is_reduce_only"` // Is the order reduce-only
+	IsLiq        bool            `json:"is_liq"`         // Is the order for liquidation
+	Tif          string          `json:"tif"`            // Time in force. Enum: "gtc", "ioc", "poc", "fok"
+	Left         int64           `json:"left"`           // Size left to be traded
+	FillPrice    decimal.Decimal `json:"fill_price"`     // Fill price of the order
+	Text         string          `json:"text"`           // User defined information. If not empty, must follow the rules below:  1. prefixed with t- 2. no longer than 28 bytes without prefix, consisting of letters, numbers, underscores, hyphen -, periods .
+	Tkfr         decimal.Decimal `json:"tkfr"`           // Taker fee
+	Mkfr         decimal.Decimal `json:"mkfr"`           // Maker fee
+	Refu         int             `json:"refu"`           // Reference user ID
+	AutoSize     string          `json:"auto_size"`      // Set side to close dual-mode position. Required if close is true. ("long", "short")
+	StpAct       string          `json:"stp_act"`        // Self-Trading Prevention Action. Enum: "cn", "co", "cb", ""
+	StpID        int             `json:"stp_id"`         // Self-Trading Prevention ID. Orders with the same stp_id will be prevented from matching. Valid range: [1, 9223372036854775807]
 }
 
 // CreateFuturesOrderRequest defines the structure for creating a futures order.
@@ -344,13 +348,13 @@ type CreateFuturesOrderRequest struct {
 
 // FuturesAccountBookEntry defines the structure for an account book entry.
 type FuturesAccountBookEntry struct {
-	Time     float64 `json:"time"`     // Change time
-	Change   string  `json:"change"`   // Change amount
-	Balance  string  `json:"balance"`  // Balance after change
-	Type     string  `json:"type"`     // Changing Type: - dnw: Deposit & Withdraw - pnl: PNL - fee: Trading fee - refr: Referrer rebate - fund: Funding fee - point_dnw: POINT Deposit & Withdraw - point_fee: POINT Trading fee - point_refr: POINT Referrer rebate - bonus_offset: bonus offset
-	Text     string  `json:"text"`     // Comment
-	Contract string  `json:"contract"` // Futures contract, Required for pnl, fee, fund type
-	TradeID  string  `json:"trade_id"` // Trade ID, Required for fee, pnl type
+	Time     decimal.Decimal `json:"time"`     // Change time
+	Change   decimal.Decimal `json:"change"`   // Change amount
+	Balance  decimal.Decimal `json:"balance"`  // Balance after change
+	Type     string          `json:"type"`     // Changing Type: - dnw: Deposit & Withdraw - pnl: PNL - fee: Trading fee - refr: Referrer rebate - fund: Funding fee - point_dnw: POINT Deposit & Withdraw - point_fee: POINT Trading fee - point_refr: POINT Referrer rebate - bonus_offset: bonus offset
+	Text     string          `json:"text"`     // Comment
+	Contract string          `json:"contract"` // Futures contract, Required for pnl, fee, fund type
+	TradeID  string          `json:"trade_id"` // Trade ID, Required for fee, pnl type
 }
 
 // ListFuturesAccountBookResult defines the result for listing account book entries.
@@ -358,18 +362,45 @@ type ListFuturesAccountBookResult []FuturesAccountBookEntry
 
 // PositionClose defines the structure for closing a position.
 type PositionClose struct {
-	Time     float64 `json:"time"`     // Position close time
-	Contract string  `json:"contract"` // Futures contract
-	Side     string  `json:"side"`     // Position side, long or short
-	Pnl      string  `json:"pnl"`      // PNL
-	Text     string  `json:"text"`     // Text of close order
+	Time     decimal.Decimal `json:"time"`     // Position close time
+	Contract string          `json:"contract"` // Futures contract
+	Side     string          `json:"side"`     // Position side, long or short
+	Pnl      decimal.Decimal `json:"pnl"`      // PNL
+	Text     string          `json:"text"`     // Text of close order
 }
 
 // ListPositionCloseResult defines the result for listing position close history.
 type ListPositionCloseResult []PositionClose
 
+// FuturesFee defines the personal trading fee rates of a contract.
+type FuturesFee struct {
+	TakerFee decimal.Decimal `json:"taker_fee"` // Taker fee rate
+	MakerFee decimal.Decimal `json:"maker_fee"` // Maker fee rate (negative means rebate)
+}
+
+// GetFuturesFeeResult defines the result for querying trading fees, keyed by contract name.
+type GetFuturesFeeResult map[string]FuturesFee
+
+// AutoDeleverage defines the structure for an auto-deleverage (ADL) record.
+type AutoDeleverage struct {
+	Time               int64           `json:"time"`                 // Automatic deleveraging time (seconds)
+	User               int64           `json:"user"`                 // User ID
+	OrderID            int64           `json:"order_id"`             // Order ID. Order IDs before 2023-02-20 are null
+	Contract           string          `json:"contract"`             // Futures contract
+	Leverage           decimal.Decimal `json:"leverage"`             // Position leverage
+	CrossLeverageLimit decimal.Decimal `json:"cross_leverage_limit"` // Cross margin leverage (valid only when leverage is 0)
+	EntryPrice         decimal.Decimal `json:"entry_price"`          // Average entry price
+	FillPrice          decimal.Decimal `json:"fill_price"`           // Average fill price
+	TradeSize          int64           `json:"trade_size"`           // Trading size
+	PositionSize       int64           `json:"position_size"`        // Position size after auto-deleveraging
+}
+
+// ListAutoDeleveragesResult defines the result for listing auto-deleverage history.
+type ListAutoDeleveragesResult []AutoDeleverage
+
 // TriggerOrder defines the structure for a price trigger order.
 type TriggerOrder struct {
+	ID         int64        `json:"id"`          // Auto order ID (the only field returned on creation)
 	Initial    FuturesOrder `json:"initial"`     // Order details upon creation
 	Trigger    Trigger      `json:"trigger"`     // Trigger condition
 	Trail      *Trail       `json:"trail"`       // Trailing parameters (nullable)
@@ -384,16 +415,16 @@ type TriggerOrder struct {
 
 // Trigger defines the trigger condition for a price trigger order.
 type Trigger struct {
-	Price      string `json:"price"`      // Trigger price
-	Rule       int    `json:"rule"`       // Trigger rule. 1: >=, 2: <=
-	Expiration int    `json:"expiration"` // Trigger expiration time in seconds
-	PriceType  string `json:"price_type"` // Price type, 0 - latest price, 1 - mark price, 2 - index price
+	Price      decimal.Decimal `json:"price"`      // Trigger price
+	Rule       int             `json:"rule"`       // Trigger rule. 1: >=, 2: <=
+	Expiration int             `json:"expiration"` // Trigger expiration time in seconds
+	PriceType  string          `json:"price_type"` // Price type, 0 - latest price, 1 - mark price, 2 - index price
 }
 
 // Trail defines the trailing parameters for a price trigger order.
 type Trail struct {
-	Amount string `json:"amount"` // Trailing amount
-	Offset string `json:"offset"` // Trailing offset
+	Amount decimal.Decimal `json:"amount"` // Trailing amount
+	Offset decimal.Decimal `json:"offset"` // Trailing offset
 }
 
 // CreateTriggerOrderRequest defines the structure for creating a price trigger order.
@@ -417,6 +448,41 @@ type BatchCancelOrdersRequest struct {
 // BatchCancelOrdersResult defines the result of batch cancelling orders.
 type BatchCancelOrdersResult []FuturesOrder // List of cancelled orders
 
+// BatchCreateFuturesOrdersRequest defines the body for creating orders in a batch (max 10 per request).
+type BatchCreateFuturesOrdersRequest []CreateFuturesOrderRequest
+
+// AmendFuturesOrderRequest defines one entry of a batch amend. The order is
+// identified by OrderID or, if OrderID is 0, by its Text label.
+type AmendFuturesOrderRequest struct {
+	OrderID   int64   `json:"order_id,omitempty"`   // Order ID
+	Text      string  `json:"text,omitempty"`       // User defined text of the order (alternative to order_id)
+	Size      *int64  `json:"size,omitempty"`       // New order size, including the filled part. Sign must match the original order
+	Price     *string `json:"price,omitempty"`      // New order price
+	AmendText string  `json:"amend_text,omitempty"` // Custom info shown in the amendment record
+}
+
+// BatchFuturesOrder defines the per-order result of a batch create or amend.
+// When Succeeded is false Label, Detail and Message describe the error and
+// Text still identifies the order it belongs to.
+type BatchFuturesOrder struct {
+	Succeeded bool   `json:"succeeded"`         // Whether the order was created or amended
+	Label     string `json:"label,omitempty"`   // Error label if the order failed
+	Detail    string `json:"detail,omitempty"`  // Error detail if the order failed
+	Message   string `json:"message,omitempty"` // Error message if the order failed
+	FuturesOrder
+}
+
+// Err returns the per-order error as an APIError, or nil if the order succeeded.
+func (o BatchFuturesOrder) Err() error {
+	if o.Succeeded {
+		return nil
+	}
+	return APIError{Label: o.Label, Message: o.Message}
+}
+
+// BatchFuturesOrdersResult defines the result of a batch create or amend, in request order.
+type BatchFuturesOrdersResult []BatchFuturesOrder
+
 // CountdownCancelAllFuturesRequest defines the structure for countdown cancel all orders.
 type CountdownCancelAllFuturesRequest struct {
 	Timeout  int    `json:"timeout"`            // Countdown time in seconds. 0 to cancel the countdown
@@ -452,3 +518,124 @@ type APIError struct {
 func (e APIError) Error() string {
 	return fmt.Sprintf("Gate.io API Error: %s - %s", e.Label, e.Message)
 }
+
+// --- Delivery Structs ---
+
+// DeliveryContract defines the structure for delivery (dated) contract details.
+type DeliveryContract struct {
+	Name                string          `json:"name"`                  // Contract name, e.g. BTC_USDT_20241227
+	Underlying          string          `json:"underlying"`            // Underlying perpetual contract
+	Cycle               string          `json:"cycle"`                 // Cycle type: WEEKLY, BI-WEEKLY, QUARTERLY, BI-QUARTERLY
+	Type                string          `json:"type"`                  // Contract type: inverse or direct
+	QuantoMultiplier    decimal.Decimal `json:"quanto_multiplier"`     // Multiplier used in converting from invoicing to settlement currency
+	LeverageMin         decimal.Decimal `json:"leverage_min"`          // Minimum leverage
+	LeverageMax         decimal.Decimal `json:"leverage_max"`          // Maximum leverage
+	MaintenanceRate     decimal.Decimal `json:"maintenance_rate"`      // Maintenance rate of margin
+	MarkType            string          `json:"mark_type"`             // Mark price type: internal or index
+	MarkPrice           decimal.Decimal `json:"mark_price"`            // Current mark price
+	IndexPrice          decimal.Decimal `json:"index_price"`           // Current index price
+	LastPrice           decimal.Decimal `json:"last_price"`            // Last trading price
+	MakerFeeRate        decimal.Decimal `json:"maker_fee_rate"`        // Maker fee rate, negative means rebate
+	TakerFeeRate        decimal.Decimal `json:"taker_fee_rate"`        // Taker fee rate
+	OrderPriceRound     decimal.Decimal `json:"order_price_round"`     // Minimum order price increment
+	MarkPriceRound      decimal.Decimal `json:"mark_price_round"`      // Minimum mark price increment
+	BasisRate           decimal.Decimal `json:"basis_rate"`            // Fair basis rate
+	BasisValue          decimal.Decimal `json:"basis_value"`           // Fair basis value
+	BasisImpactValue    decimal.Decimal `json:"basis_impact_value"`    // Funding used for calculating impact bid, ask price
+	SettlePrice         decimal.Decimal `json:"settle_price"`          // Settle price
+	SettlePriceInterval int             `json:"settle_price_interval"` // Settle price update interval (seconds)
+	SettlePriceDuration int             `json:"settle_price_duration"` // Settle price update duration (seconds)
+	ExpireTime          int64           `json:"expire_time"`           // Contract expiry timestamp (seconds)
+	RiskLimitBase       decimal.Decimal `json:"risk_limit_base"`       // Risk limit base
+	RiskLimitStep       decimal.Decimal `json:"risk_limit_step"`       // Step of adjusting risk limit
+	RiskLimitMax        decimal.Decimal `json:"risk_limit_max"`        // Maximum risk limit the contract allowed
+	OrderSizeMin        int64           `json:"order_size_min"`        // Minimum order size the contract allowed
+	OrderSizeMax        int64           `json:"order_size_max"`        // Maximum order size the contract allowed
+	OrderPriceDeviate   decimal.Decimal `json:"order_price_deviate"`   // Maximum deviation between order price and mark price
+	RefDiscountRate     decimal.Decimal `json:"ref_discount_rate"`     // Referral fee rate discount
+	RefRebateRate       decimal.Decimal `json:"ref_rebate_rate"`       // Referrer commission rate
+	OrderbookID         int64           `json:"orderbook_id"`          // Current orderbook ID
+	TradeID             int64           `json:"trade_id"`              // Current trade ID
+	TradeSize           int64           `json:"trade_size"`            // Historical accumulated trade size
+	PositionSize        int64           `json:"position_size"`         // Current total long position size
+	ConfigChangeTime    decimal.Decimal `json:"config_change_time"`    // Last changed time of configuration
+	InDelisting         bool            `json:"in_delisting"`          // Contract is delisting
+	OrdersLimit         int             `json:"orders_limit"`          // Maximum number of open orders
+}
+
+// ListDeliveryContractsResult defines the result for listing delivery contracts.
+type ListDeliveryContractsResult []DeliveryContract
+
+// DeliveryTicker defines the structure for a delivery contract ticker.
+type DeliveryTicker struct {
+	FuturesTicker
+	BasisRate  decimal.Decimal `json:"basis_rate"`  // Basis rate
+	BasisValue decimal.Decimal `json:"basis_value"` // Basis value
+}
+
+// ListDeliveryTickersResult defines the result for listing delivery tickers.
+type ListDeliveryTickersResult []DeliveryTicker
+
+// DeliverySettlement defines the structure for a delivery settlement record.
+type DeliverySettlement struct {
+	Time        int64           `json:"time"`         // Settlement time (seconds)
+	Contract    string          `json:"contract"`     // Futures contract
+	Leverage    decimal.Decimal `json:"leverage"`     // Position leverage
+	Size        int64           `json:"size"`         // Position size
+	Margin      decimal.Decimal `json:"margin"`       // Position margin
+	EntryPrice  decimal.Decimal `json:"entry_price"`  // Average entry price
+	SettlePrice decimal.Decimal `json:"settle_price"` // Settled price
+	Profit      decimal.Decimal `json:"profit"`       // Settled profit
+	Fee         decimal.Decimal `json:"fee"`          // Fee deducted
+}
+
+// ListDeliverySettlementsResult defines the result for listing delivery settlement history.
+type ListDeliverySettlementsResult []DeliverySettlement
+
+// --- WebSocket Structs ---
+
+// FuturesOrderBookUpdate defines an incremental order book update pushed on futures.order_book_update.
+// Levels with a size of 0 must be removed from the local book.
+type FuturesOrderBookUpdate struct {
+	Time     int64                  `json:"t"` // Update timestamp (milliseconds)
+	Contract string                 `json:"s"` // Futures contract name
+	FirstID  int64                  `json:"U"` // First order book update ID in this message
+	LastID   int64                  `json:"u"` // Last order book update ID in this message
+	Bids     []FutureOrderBookEntry `json:"b"` // Changed bids
+	Asks     []FutureOrderBookEntry `json:"a"` // Changed asks
+}
+
+// FuturesCandlestickUpdate defines a candlestick pushed on futures.candlesticks.
+type FuturesCandlestickUpdate struct {
+	CandlestickData
+	Name   string          `json:"n"` // Subscription name, "<interval>_<contract>"
+	Amount decimal.Decimal `json:"a"` // Trading amount in quote currency
+	Closed bool            `json:"w"` // Whether the window is closed
+}
+
+// FuturesUserTrade defines a personal trade pushed on futures.usertrades.
+type FuturesUserTrade struct {
+	ID           string          `json:"id"`             // Trade ID
+	OrderID      string          `json:"order_id"`       // Order ID
+	Contract     string          `json:"contract"`       // Futures contract name
+	CreateTime   int64           `json:"create_time"`    // Trade time (seconds)
+	CreateTimeMs int64           `json:"create_time_ms"` // Trade time (milliseconds)
+	Size         int64           `json:"size"`           // Trade size, >0 means buy, <0 means sell
+	Price        decimal.Decimal `json:"price"`          // Trade price
+	Role         string          `json:"role"`           // Trade role, "taker" or "maker"
+	Text         string          `json:"text"`           // User defined information of the order
+	Fee          decimal.Decimal `json:"fee"`            // Fee deducted
+	PointFee     decimal.Decimal `json:"point_fee"`      // Point fee deducted
+}
+
+// FuturesBalanceUpdate defines a balance change pushed on futures.balances.
+type FuturesBalanceUpdate struct {
+	Balance  decimal.Decimal `json:"balance"`  // Balance after change
+	Change   decimal.Decimal `json:"change"`   // Change amount
+	Text     string          `json:"text"`     // Comment
+	Time     int64           `json:"time"`     // Change time (seconds)
+	TimeMs   int64           `json:"time_ms"`  // Change time (milliseconds)
+	Type     string          `json:"type"`     // Changing type: dnw, pnl, fee, refr, fund, point_dnw, point_fee, point_refr
+	User     string          `json:"user"`     // User ID
+	Currency string          `json:"currency"` // Settle currency
+}
@@ -0,0 +1,158 @@
+package gateio
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/neqin/futures/decimal"
+	"github.com/neqin/futures/exchange"
+)
+
+// contractsTTL is how long a settlement currency's contract list is used for
+// pre-trade checks before it is fetched again.
+const contractsTTL = 5 * time.Minute
+
+// OrderValidation configures the local checks CreateFuturesOrder and
+// BatchCreateFuturesOrders run against the contract specs before sending an
+// order. Violations are returned as *exchange.ValidationError.
+//
+// The checks cover the size limits (order_size_min, order_size_max) and the
+// price tick (order_price_round). Contract specs are fetched with
+// ListFuturesContracts and cached for a few minutes per settlement currency.
+type OrderValidation struct {
+	// Normalize rounds a limit price to the tick instead of rejecting it:
+	// buy prices down and sell prices up, so the order is never more
+	// aggressive than requested.
+	Normalize bool
+	// PriceBands rejects limit prices further from the mark price than the
+	// contract's order_price_deviate. It costs one ListFuturesTickers request
+	// per order.
+	PriceBands bool
+}
+
+// SetOrderValidation enables pre-trade validation of futures orders, or
+// disables it if v is nil (the default).
+func (c *Client) SetOrderValidation(v *OrderValidation) {
+	c.validation = v
+}
+
+// contractCache holds the contract specs of each settlement currency.
+type contractCache struct {
+	mu     sync.Mutex
+	specs  map[string]map[string]Ticker // settle -> contract name -> spec
+	loaded map[string]time.Time
+}
+
+func newContractCache() *contractCache {
+	return &contractCache{
+		specs:  make(map[string]map[string]Ticker),
+		loaded: make(map[string]time.Time),
+	}
+}
+
+// contractSpec returns the spec of contract, refreshing the cached list of
+// settle if it is stale or does not contain the contract.
+func (c *Client) contractSpec(ctx context.Context, settle, contract string) (Ticker, error) {
+	m := c.contracts
+	m.mu.Lock()
+	spec, ok := m.specs[settle][contract]
+	fresh := time.Since(m.loaded[settle]) < contractsTTL
+	m.mu.Unlock()
+	if ok && fresh {
+		return spec, nil
+	}
+
+	list, err := c.ListFuturesContracts(ctx, settle)
+	if err != nil {
+		return Ticker{}, fmt.Errorf("load contract specs: %w", err)
+	}
+	specs := make(map[string]Ticker, len(*list))
+	for _, t := range *list {
+		specs[t.Name] = t
+	}
+	m.mu.Lock()
+	m.specs[settle] = specs
+	m.loaded[settle] = time.Now()
+	m.mu.Unlock()
+
+	spec, ok = specs[contract]
+	if !ok {
+		return Ticker{}, &exchange.ValidationError{Symbol: contract, Field: "contract", Reason: "is not listed under settle " + settle}
+	}
+	return spec, nil
+}
+
+// validateOrder checks order against the contract spec and, with Normalize,
+// rounds its price to the tick in place.
+func (c *Client) validateOrder(ctx context.Context, settle string, order *CreateFuturesOrderRequest) error {
+	v := c.validation
+	spec, err := c.contractSpec(ctx, settle, order.Contract)
+	if err != nil {
+		return err
+	}
+	invalid := func(field, format string, args ...interface{}) error {
+		return &exchange.ValidationError{Symbol: order.Contract, Field: field, Reason: fmt.Sprintf(format, args...)}
+	}
+
+	size := order.Size
+	if size < 0 {
+		size = -size
+	}
+	switch {
+	case size == 0 && !order.Close && order.AutoSize == "":
+		return invalid("size", "must not be 0 unless closing a position")
+	case size != 0 && size < int64(spec.OrderSizeMin):
+		return invalid("size", "%d is below the minimum of %d contracts", size, spec.OrderSizeMin)
+	case spec.OrderSizeMax > 0 && size > int64(spec.OrderSizeMax):
+		return invalid("size", "%d exceeds the maximum of %d contracts", size, spec.OrderSizeMax)
+	}
+
+	if order.Price == nil {
+		return nil
+	}
+	price, err := decimal.Parse(*order.Price)
+	if err != nil {
+		return invalid("price", "%q is not a number", *order.Price)
+	}
+	if price.IsZero() {
+		return nil // Market order
+	}
+	if price.Sign() < 0 {
+		return invalid("price", "%s must be positive", price)
+	}
+	tick := spec.OrderPriceRound
+	if !price.IsMultipleOf(tick) {
+		if !v.Normalize {
+			return invalid("price", "%s is not a multiple of the tick size %s", price, tick)
+		}
+		if order.Size > 0 {
+			price = price.FloorToTick(tick)
+		} else {
+			price = price.CeilToTick(tick)
+		}
+		if price.IsZero() {
+			return invalid("price", "%s rounds to 0 with tick size %s", *order.Price, tick)
+		}
+		s := price.String()
+		order.Price = &s
+	}
+
+	if v.PriceBands && spec.OrderPriceDeviate.Sign() > 0 {
+		contract := order.Contract
+		tickers, err := c.ListFuturesTickers(ctx, settle, &contract)
+		if err != nil {
+			return fmt.Errorf("load mark price: %w", err)
+		}
+		if len(*tickers) == 0 {
+			return fmt.Errorf("load mark price: no ticker for %s", contract)
+		}
+		mark := (*tickers)[0].MarkPrice
+		band := mark.Mul(spec.OrderPriceDeviate)
+		if price.Sub(mark).Abs().GreaterThan(band) {
+			return invalid("price", "%s is outside the band %s-%s (mark price %s ± %s)", price, mark.Sub(band), mark.Add(band), mark, spec.OrderPriceDeviate)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,110 @@
+package gateio_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/neqin/futures/connectors/gateio"
+	"github.com/neqin/futures/decimal"
+	"github.com/neqin/futures/exchange"
+)
+
+// newValidatingServer adds an ETH_USDT contract with a 0.05 tick, sizes of 5
+// to 100 contracts and a 10% price band around the 100.25 mark price.
+func newValidatingServer(t *testing.T) (*gateio.Client, func() gateio.CreateFuturesOrderRequest) {
+	t.Helper()
+	srv, client := newServer(t)
+	srv.AddContract(gateio.Ticker{
+		Name:              "ETH_USDT",
+		OrderPriceRound:   decimal.MustParse("0.05"),
+		QuantoMultiplier:  decimal.MustParse("0.01"),
+		OrderSizeMin:      5,
+		OrderSizeMax:      100,
+		OrderPriceDeviate: decimal.MustParse("0.1"),
+	})
+	err := srv.SetOrderBook(&exchange.OrderBook{
+		Symbol: "ETH_USDT",
+		Bids:   []exchange.OrderBookLevel{{Price: 100, Size: 500}},
+		Asks:   []exchange.OrderBookLevel{{Price: 100.5, Size: 500}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// sent returns the last order the server received.
+	sent := func() gateio.CreateFuturesOrderRequest {
+		t.Helper()
+		var order gateio.CreateFuturesOrderRequest
+		reqs := srv.Requests()
+		for i := len(reqs) - 1; i >= 0; i-- {
+			if reqs[i].Method == http.MethodPost && reqs[i].Path == "/futures/usdt/orders" {
+				if err := json.Unmarshal(reqs[i].Body, &order); err != nil {
+					t.Fatal(err)
+				}
+				return order
+			}
+		}
+		t.Fatal("no order was sent")
+		return order
+	}
+	return client, sent
+}
+
+func TestValidateOrder(t *testing.T) {
+	tests := []struct {
+		name       string
+		validation gateio.OrderValidation
+		size       int64
+		price      string
+		field      string // Field of the expected ValidationError, "" if accepted
+		sent       string // Price sent to the venue
+	}{
+		{"on tick", gateio.OrderValidation{}, 5, "100.05", "", "100.05"},
+		{"off tick", gateio.OrderValidation{}, 5, "100.03", "price", ""},
+		{"buy rounded down", gateio.OrderValidation{Normalize: true}, 5, "100.03", "", "100.00"},
+		{"sell rounded up", gateio.OrderValidation{Normalize: true}, -5, "100.03", "", "100.05"},
+		{"rounds to zero", gateio.OrderValidation{Normalize: true}, 5, "0.01", "price", ""},
+		{"negative price", gateio.OrderValidation{}, 5, "-100", "price", ""},
+		{"not a number", gateio.OrderValidation{}, 5, "abc", "price", ""},
+		{"below minimum", gateio.OrderValidation{}, 4, "100", "size", ""},
+		{"sell below minimum", gateio.OrderValidation{}, -4, "100", "size", ""},
+		{"above maximum", gateio.OrderValidation{}, 101, "100", "size", ""},
+		{"zero size", gateio.OrderValidation{}, 0, "100", "size", ""},
+		{"inside the band", gateio.OrderValidation{PriceBands: true}, 5, "90.25", "", "90.25"},
+		{"below the band", gateio.OrderValidation{PriceBands: true}, 5, "90.2", "price", ""},
+		{"above the band", gateio.OrderValidation{PriceBands: true}, -5, "110.3", "price", ""},
+		{"band after rounding", gateio.OrderValidation{Normalize: true, PriceBands: true}, 5, "90.24", "price", ""},
+	}
+	for _, tt := range tests {
+		client, sent := newValidatingServer(t)
+		client.SetOrderValidation(&tt.validation)
+		_, err := client.CreateFuturesOrder(context.Background(), settle, gateio.CreateFuturesOrderRequest{
+			Contract: "ETH_USDT", Size: tt.size, Price: ptr(tt.price),
+		})
+		var verr *exchange.ValidationError
+		switch {
+		case tt.field != "":
+			if !errors.As(err, &verr) || verr.Field != tt.field || !errors.Is(err, exchange.ErrInvalidParameter) {
+				t.Errorf("%s: got %v, want a %s ValidationError", tt.name, err, tt.field)
+			}
+		case err != nil:
+			t.Errorf("%s: %v", tt.name, err)
+		case *sent().Price != tt.sent:
+			t.Errorf("%s: sent price %s, want %s", tt.name, *sent().Price, tt.sent)
+		}
+	}
+}
+
+func TestValidateUnlistedContract(t *testing.T) {
+	client, _ := newValidatingServer(t)
+	client.SetOrderValidation(&gateio.OrderValidation{})
+	_, err := client.CreateFuturesOrder(context.Background(), settle, gateio.CreateFuturesOrderRequest{
+		Contract: "SOL_USDT", Size: 1, Price: ptr("10"),
+	})
+	var verr *exchange.ValidationError
+	if !errors.As(err, &verr) || verr.Field != "contract" {
+		t.Errorf("unlisted contract: got %v, want a contract ValidationError", err)
+	}
+}
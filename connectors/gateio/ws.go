@@ -0,0 +1,559 @@
+package gateio
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/neqin/futures/clock"
+	"github.com/neqin/futures/credentials"
+	"github.com/neqin/futures/wsconn"
+)
+
+const (
+	defaultWSBaseURL     = "wss://fx-ws.gateio.ws/v4/ws"
+	wsPingInterval       = 10 * time.Second
+	wsReadTimeout        = 30 * time.Second
+	wsWriteTimeout       = 10 * time.Second
+	wsMaxReconnectDelay  = 30 * time.Second
+	wsSubscriptionBuffer = 256
+)
+
+var (
+	// ErrWSClosed is returned when using a WSClient after Close has been called.
+	ErrWSClosed = errors.New("gateio websocket client closed")
+	// ErrWSBufferFull is reported on Errors when an update is dropped because
+	// its subscription's consumer has fallen behind.
+	ErrWSBufferFull = wsconn.ErrBufferFull
+)
+
+// wsRequest is a client-to-server frame on the Gate.io futures v4 stream.
+type wsRequest struct {
+	Time    int64    `json:"time"`
+	ID      int64    `json:"id,omitempty"`
+	Channel string   `json:"channel"`
+	Event   string   `json:"event,omitempty"`
+	Payload []string `json:"payload,omitempty"`
+	Auth    *wsAuth  `json:"auth,omitempty"`
+}
+
+// wsAuth carries the signed credentials for private channels.
+type wsAuth struct {
+	Method string `json:"method"`
+	Key    string `json:"KEY"`
+	Sign   string `json:"SIGN"`
+}
+
+// wsMessage is a server-to-client frame (subscription ack or channel update).
+type wsMessage struct {
+	Time    int64           `json:"time"`
+	TimeMs  int64           `json:"time_ms"`
+	ID      int64           `json:"id"`
+	Channel string          `json:"channel"`
+	Event   string          `json:"event"`
+	Error   *WSError        `json:"error"`
+	Result  json.RawMessage `json:"result"`
+}
+
+// WSError is an error returned by the Gate.io websocket server.
+type WSError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+// Error returns the error message string.
+func (e *WSError) Error() string {
+	return fmt.Sprintf("Gate.io WS Error: %d - %s", e.Code, e.Message)
+}
+
+// wsSubscription tracks an active channel subscription so it can be
+// dispatched to and replayed after a reconnect.
+type wsSubscription struct {
+	channel string
+	payload []string
+	private bool
+	raw     chan json.RawMessage // Updates read off the socket, consumed by the subscription goroutine
+	done    chan struct{}        // Closed when the subscription ends
+}
+
+// WSClient is a Gate.io futures websocket client. A single connection is
+// shared by all subscriptions; it is established lazily on the first
+// subscription and transparently re-established (with all subscriptions
+// replayed) if it drops.
+type WSClient struct {
+	baseURL     string
+	settle      string
+	credentials credentials.Provider // Key pair for private channels; nil for public-only clients
+	ownsCreds   bool                 // Whether Close closes credentials
+	signer      Signer               // Custom signer; nil signs with the credentials' secret
+	clock       *clock.Offset        // Timestamps of signed requests
+	userID      int
+	ws          *wsconn.Conn
+
+	mu      sync.Mutex
+	subs    map[*wsSubscription]struct{}
+	pending map[int64]chan *wsMessage
+	closed  bool
+
+	nextID atomic.Int64
+}
+
+// NewWSClient creates a websocket client for both public and private futures channels.
+// settle: "usdt" or "btc"
+// userID: Gate.io user ID (FuturesAccount.User), required in private channel payloads.
+// If dialer is nil, websocket.DefaultDialer is used.
+func NewWSClient(apiKey, secretKey, settle string, userID int, dialer *websocket.Dialer) *WSClient {
+	return newWSClient(apiKey, secretKey, settle, userID, dialer)
+}
+
+// NewPublicWSClient creates a websocket client for public futures channels.
+// settle: "usdt" or "btc"
+// If dialer is nil, websocket.DefaultDialer is used.
+func NewPublicWSClient(settle string, dialer *websocket.Dialer) *WSClient {
+	return newWSClient("", "", settle, 0, dialer)
+}
+
+func newWSClient(apiKey, secretKey, settle string, userID int, dialer *websocket.Dialer) *WSClient {
+	w := &WSClient{
+		baseURL: defaultWSBaseURL,
+		settle:  settle,
+		clock:   clock.NewOffset(),
+		userID:  userID,
+		subs:    make(map[*wsSubscription]struct{}),
+		pending: make(map[int64]chan *wsMessage),
+	}
+	w.ws = wsconn.New(w.baseURL+"/"+settle, wsconn.Config{
+		Dialer:            dialer,
+		PingInterval:      wsPingInterval,
+		ReadTimeout:       wsReadTimeout,
+		WriteTimeout:      wsWriteTimeout,
+		MaxReconnectDelay: wsMaxReconnectDelay,
+		Ping:              w.ping,
+		Handle:            w.handle,
+		Resubscribe:       w.resubscribe,
+	})
+	if apiKey != "" || secretKey != "" {
+		w.credentials, w.ownsCreds = credentials.NewStatic(apiKey, secretKey), true
+	}
+	return w
+}
+
+// SetBaseURL allows overriding the default websocket base URL (without the settle suffix).
+// Must be called before the first subscription.
+func (w *WSClient) SetBaseURL(baseURL string) {
+	w.baseURL = strings.TrimSuffix(baseURL, "/")
+	w.ws.SetURL(w.baseURL + "/" + w.settle)
+}
+
+// SetSigner replaces the signer of private channel requests (the secret of the
+// credentials by default). Must be called before the first subscription.
+func (w *WSClient) SetSigner(signer Signer) {
+	w.signer = signer
+}
+
+// SetClock replaces the clock offset used to timestamp private channel
+// requests. Pass the REST client's Clock so that local clock drift, which the
+// REST client measures, does not get the auth signature rejected. Must be
+// called before the first subscription.
+func (w *WSClient) SetClock(offset *clock.Offset) {
+	w.clock = offset
+}
+
+// SetCredentials replaces the source of the API key pair, consulted whenever a
+// private channel is (re)subscribed. The caller keeps ownership of provider.
+// Must be called before the first subscription.
+func (w *WSClient) SetCredentials(provider credentials.Provider) {
+	if w.ownsCreds {
+		w.credentials.Close()
+	}
+	w.credentials, w.ownsCreds = provider, false
+}
+
+// Errors returns a channel of asynchronous errors (decode failures, reconnects,
+// updates dropped with ErrWSBufferFull for a consumer that falls behind).
+// Errors are dropped if the channel is not drained.
+func (w *WSClient) Errors() <-chan error {
+	return w.ws.Errors()
+}
+
+// Close terminates the connection and ends all subscriptions. The secret key
+// passed to NewWSClient is zeroed.
+func (w *WSClient) Close() error {
+	w.mu.Lock()
+	if w.closed {
+		w.mu.Unlock()
+		return nil
+	}
+	w.closed = true
+	for sub := range w.subs {
+		close(sub.done)
+		delete(w.subs, sub)
+	}
+	w.mu.Unlock()
+
+	if w.ownsCreds {
+		w.credentials.Close() // Zero the secret key passed to NewWSClient
+	}
+	return w.ws.Close()
+}
+
+// send writes a single frame on conn.
+func (w *WSClient) send(conn *websocket.Conn, req wsRequest) error {
+	data, err := json.Marshal(req)
+	if err != nil {
+		return err
+	}
+	return w.ws.Write(conn, data)
+}
+
+// request sends an event on a channel and waits for the server's acknowledgement.
+func (w *WSClient) request(ctx context.Context, conn *websocket.Conn, sub *wsSubscription, event string) error {
+	req := wsRequest{
+		Time:    w.clock.Now().Unix(),
+		ID:      w.nextID.Add(1),
+		Channel: sub.channel,
+		Event:   event,
+		Payload: sub.payload,
+	}
+	if sub.private {
+		auth, err := w.authFor(ctx, req.Channel, req.Event, req.Time)
+		if err != nil {
+			return err
+		}
+		req.Auth = auth
+	}
+
+	ack := make(chan *wsMessage, 1)
+	w.mu.Lock()
+	w.pending[req.ID] = ack
+	w.mu.Unlock()
+	defer func() {
+		w.mu.Lock()
+		delete(w.pending, req.ID)
+		w.mu.Unlock()
+	}()
+
+	if err := w.send(conn, req); err != nil {
+		return fmt.Errorf("failed to send %s %s: %w", sub.channel, event, err)
+	}
+
+	select {
+	case msg := <-ack:
+		if msg.Error != nil {
+			return fmt.Errorf("%s %s rejected: %w", sub.channel, event, msg.Error)
+		}
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-w.ws.Done():
+		return ErrWSClosed
+	}
+}
+
+// authFor builds the auth block for a private channel request.
+func (w *WSClient) authFor(ctx context.Context, channel, event string, ts int64) (*wsAuth, error) {
+	var creds *credentials.Credentials
+	if w.credentials != nil {
+		var err error
+		if creds, err = w.credentials.Credentials(ctx); err != nil {
+			return nil, fmt.Errorf("failed to get credentials for %s: %w", channel, err)
+		}
+		defer creds.Zero()
+	}
+	if !canSign(w.signer, creds) {
+		return nil, fmt.Errorf("API key and secret key must be provided for private channel %s", channel)
+	}
+	signature, err := sign(ctx, w.signer, creds, WSPayload(channel, event, ts))
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign %s %s: %w", channel, event, err)
+	}
+	return &wsAuth{
+		Method: "api_key",
+		Key:    creds.APIKey,
+		Sign:   signature,
+	}, nil
+}
+
+// subscribe registers a subscription and sends the subscribe request.
+func (w *WSClient) subscribe(ctx context.Context, sub *wsSubscription) error {
+	if err := w.ws.Connect(ctx); err != nil {
+		if errors.Is(err, wsconn.ErrClosed) {
+			return ErrWSClosed
+		}
+		return err
+	}
+	w.mu.Lock()
+	if w.closed {
+		w.mu.Unlock()
+		return ErrWSClosed
+	}
+	w.subs[sub] = struct{}{}
+	w.mu.Unlock()
+	// Read after registering: a reconnect installs its connection before
+	// replaying the registered subscriptions, so sub is not missed.
+	conn := w.ws.Current()
+
+	// A nil conn means the connection dropped in the meantime; the
+	// reconnect loop replays all registered subscriptions.
+	if conn != nil {
+		if err := w.request(ctx, conn, sub, "subscribe"); err != nil {
+			w.removeSubscription(sub)
+			return err
+		}
+	}
+
+	// End the subscription when the caller's context is done.
+	go func() {
+		select {
+		case <-ctx.Done():
+		case <-sub.done:
+			return
+		}
+		if w.removeSubscription(sub) {
+			w.mu.Lock()
+			payload := w.unsharedPayloadLocked(sub)
+			w.mu.Unlock()
+			conn := w.ws.Current()
+			if conn != nil && payload != nil {
+				unsubCtx, cancel := context.WithTimeout(context.Background(), wsWriteTimeout)
+				defer cancel()
+				unsub := *sub
+				unsub.payload = payload
+				w.request(unsubCtx, conn, &unsub, "unsubscribe") // Best effort
+			}
+		}
+	}()
+	return nil
+}
+
+// removeSubscription unregisters sub and closes its done channel. It reports
+// whether the subscription was still active.
+func (w *WSClient) removeSubscription(sub *wsSubscription) bool {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if _, ok := w.subs[sub]; !ok {
+		return false
+	}
+	delete(w.subs, sub)
+	close(sub.done)
+	return true
+}
+
+// wsListChannels maps the channels whose payload lists contracts that the
+// server subscribes independently to the number of leading payload entries
+// shared by all of them (the user ID of private channels). The payloads of
+// other channels are a single unit, e.g. [contract, frequency, level].
+var wsListChannels = map[string]int{
+	"futures.tickers":    0,
+	"futures.trades":     0,
+	"futures.orders":     1,
+	"futures.usertrades": 1,
+	"futures.positions":  1,
+	"futures.autoorders": 1,
+}
+
+// elements returns keys for the server-side subscriptions sub holds: one per
+// listed contract of list channels, or the whole payload otherwise.
+func (sub *wsSubscription) elements() []string {
+	shared, ok := wsListChannels[sub.channel]
+	if !ok || shared >= len(sub.payload) {
+		return []string{strings.Join(sub.payload, ",")}
+	}
+	prefix := strings.Join(sub.payload[:shared], ",")
+	keys := make([]string, 0, len(sub.payload)-shared)
+	for _, item := range sub.payload[shared:] {
+		keys = append(keys, prefix+","+item)
+	}
+	return keys
+}
+
+// unsharedPayloadLocked returns the payload to unsubscribe when sub ends:
+// the elements of sub that no other active subscription to the same channel
+// still needs, or nil if all of them are needed. w.mu must be held.
+func (w *WSClient) unsharedPayloadLocked(sub *wsSubscription) []string {
+	inUse := make(map[string]bool)
+	for other := range w.subs {
+		if other.channel == sub.channel {
+			for _, key := range other.elements() {
+				inUse[key] = true
+			}
+		}
+	}
+	keys := sub.elements()
+	shared, ok := wsListChannels[sub.channel]
+	if !ok || shared >= len(sub.payload) {
+		if inUse[keys[0]] {
+			return nil
+		}
+		return sub.payload
+	}
+	payload := slices.Clone(sub.payload[:shared])
+	for i, item := range sub.payload[shared:] {
+		if !inUse[keys[i]] && !slices.Contains(payload[shared:], item) {
+			payload = append(payload, item)
+		}
+	}
+	if len(payload) == shared {
+		return nil
+	}
+	return payload
+}
+
+// ping returns the application-level keep-alive frame.
+func (w *WSClient) ping() []byte {
+	data, _ := json.Marshal(wsRequest{Time: w.clock.Now().Unix(), Channel: "futures.ping"})
+	return data
+}
+
+// handle decodes a frame read off the connection and dispatches it.
+func (w *WSClient) handle(data []byte) {
+	var msg wsMessage
+	if err := json.Unmarshal(data, &msg); err != nil {
+		w.reportError(fmt.Errorf("failed to decode websocket message: %w (body: %s)", err, string(data)))
+		return
+	}
+	w.dispatch(&msg)
+}
+
+func (w *WSClient) dispatch(msg *wsMessage) {
+	switch msg.Event {
+	case "subscribe", "unsubscribe":
+		w.mu.Lock()
+		ack, ok := w.pending[msg.ID]
+		w.mu.Unlock()
+		if ok {
+			ack <- msg
+		}
+		return
+	case "update", "all":
+	default:
+		return // futures.pong and other housekeeping frames
+	}
+
+	w.mu.Lock()
+	targets := make([]*wsSubscription, 0, 1)
+	for sub := range w.subs {
+		if sub.channel == msg.Channel {
+			targets = append(targets, sub)
+		}
+	}
+	w.mu.Unlock()
+
+	for _, sub := range targets {
+		w.ws.Deliver(sub.raw, msg.Result, fmt.Sprintf("%s %v", sub.channel, sub.payload))
+	}
+}
+
+// resubscribe replays every active subscription on a new connection.
+func (w *WSClient) resubscribe(conn *websocket.Conn) {
+	w.mu.Lock()
+	subs := make([]*wsSubscription, 0, len(w.subs))
+	for sub := range w.subs {
+		subs = append(subs, sub)
+	}
+	w.mu.Unlock()
+
+	for _, sub := range subs {
+		ctx, cancel := context.WithTimeout(context.Background(), wsWriteTimeout)
+		if err := w.request(ctx, conn, sub, "subscribe"); err != nil {
+			w.reportError(fmt.Errorf("failed to resubscribe %s %v: %w", sub.channel, sub.payload, err))
+		}
+		cancel()
+	}
+}
+
+func (w *WSClient) reportError(err error) {
+	w.ws.Report(err)
+}
+
+// subscribeTyped subscribes to a channel and decodes every update into values
+// of type T delivered on the returned channel. keep filters decoded values
+// (e.g. by contract); it may be nil. The channel is closed when ctx is done or
+// the client is closed.
+func subscribeTyped[T any](ctx context.Context, w *WSClient, channel string, payload []string, private bool, keep func(*T) bool) (<-chan T, error) {
+	return subscribeDecoded(ctx, w, channel, payload, private, decodeWSResult[T], keep)
+}
+
+// subscribeConverted is like subscribeTyped but decodes updates into the wire
+// type W first and converts them with convert. It is used for channels whose
+// payload encodes numbers differently from the equivalent REST types.
+func subscribeConverted[W, T any](ctx context.Context, w *WSClient, channel string, payload []string, private bool, convert func(*W) T, keep func(*T) bool) (<-chan T, error) {
+	decode := func(raw json.RawMessage) ([]T, error) {
+		wire, err := decodeWSResult[W](raw)
+		if err != nil {
+			return nil, err
+		}
+		items := make([]T, 0, len(wire))
+		for i := range wire {
+			items = append(items, convert(&wire[i]))
+		}
+		return items, nil
+	}
+	return subscribeDecoded(ctx, w, channel, payload, private, decode, keep)
+}
+
+func subscribeDecoded[T any](ctx context.Context, w *WSClient, channel string, payload []string, private bool, decode func(json.RawMessage) ([]T, error), keep func(*T) bool) (<-chan T, error) {
+	sub := &wsSubscription{
+		channel: channel,
+		payload: payload,
+		private: private,
+		raw:     make(chan json.RawMessage, wsSubscriptionBuffer),
+		done:    make(chan struct{}),
+	}
+	out := make(chan T, wsSubscriptionBuffer)
+
+	if err := w.subscribe(ctx, sub); err != nil {
+		return nil, err
+	}
+
+	go func() {
+		defer close(out)
+		for {
+			select {
+			case <-sub.done:
+				return
+			case raw := <-sub.raw:
+				items, err := decode(raw)
+				if err != nil {
+					w.reportError(fmt.Errorf("failed to decode %s update: %w (body: %s)", channel, err, string(raw)))
+					continue
+				}
+				for i := range items {
+					if keep != nil && !keep(&items[i]) {
+						continue
+					}
+					select {
+					case out <- items[i]:
+					case <-sub.done:
+						return
+					}
+				}
+			}
+		}
+	}()
+	return out, nil
+}
+
+// decodeWSResult decodes a result that may be either a single object or an array of objects.
+func decodeWSResult[T any](raw json.RawMessage) ([]T, error) {
+	trimmed := strings.TrimSpace(string(raw))
+	if strings.HasPrefix(trimmed, "[") {
+		var items []T
+		if err := json.Unmarshal(raw, &items); err != nil {
+			return nil, err
+		}
+		return items, nil
+	}
+	var item T
+	if err := json.Unmarshal(raw, &item); err != nil {
+		return nil, err
+	}
+	return []T{item}, nil
+}
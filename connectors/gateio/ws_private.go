@@ -0,0 +1,323 @@
+package gateio
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strconv"
+
+	"github.com/neqin/futures/decimal"
+)
+
+// privatePayload builds the [user_id, contract...] payload used by private channels.
+// An empty contract list subscribes to all contracts.
+func (w *WSClient) privatePayload(contracts []string) ([]string, error) {
+	if w.userID == 0 {
+		return nil, fmt.Errorf("user ID is required for private channels, create the client with NewWSClient")
+	}
+	payload := []string{strconv.Itoa(w.userID)}
+	if len(contracts) == 0 {
+		return append(payload, "!all"), nil
+	}
+	return append(payload, contracts...), nil
+}
+
+// contractFilter keeps values whose contract is in contracts, or all values if contracts is empty.
+func contractFilter[T any](contracts []string, contractOf func(*T) string) func(*T) bool {
+	if len(contracts) == 0 {
+		return nil
+	}
+	return func(v *T) bool { return slices.Contains(contracts, contractOf(v)) }
+}
+
+// SubscribeOrders streams futures.orders updates for the given contracts (all contracts if none given).
+// The returned channel is closed when ctx is done or the client is closed.
+func (w *WSClient) SubscribeOrders(ctx context.Context, contracts ...string) (<-chan FuturesOrder, error) {
+	payload, err := w.privatePayload(contracts)
+	if err != nil {
+		return nil, err
+	}
+	keep := contractFilter(contracts, func(o *FuturesOrder) string { return o.Contract })
+	return subscribeConverted(ctx, w, "futures.orders", payload, true, (*wsFuturesOrder).futuresOrder, keep)
+}
+
+// SubscribeUserTrades streams futures.usertrades updates for the given contracts (all contracts if none given).
+// The returned channel is closed when ctx is done or the client is closed.
+func (w *WSClient) SubscribeUserTrades(ctx context.Context, contracts ...string) (<-chan FuturesUserTrade, error) {
+	payload, err := w.privatePayload(contracts)
+	if err != nil {
+		return nil, err
+	}
+	keep := contractFilter(contracts, func(t *FuturesUserTrade) string { return t.Contract })
+	return subscribeConverted(ctx, w, "futures.usertrades", payload, true, (*wsUserTrade).userTrade, keep)
+}
+
+// SubscribePositions streams futures.positions updates for the given contracts (all contracts if none given).
+// The returned channel is closed when ctx is done or the client is closed.
+func (w *WSClient) SubscribePositions(ctx context.Context, contracts ...string) (<-chan Position, error) {
+	payload, err := w.privatePayload(contracts)
+	if err != nil {
+		return nil, err
+	}
+	keep := contractFilter(contracts, func(p *Position) string { return p.Contract })
+	return subscribeConverted(ctx, w, "futures.positions", payload, true, (*wsPosition).position, keep)
+}
+
+// SubscribeBalances streams futures.balances updates.
+// The returned channel is closed when ctx is done or the client is closed.
+func (w *WSClient) SubscribeBalances(ctx context.Context) (<-chan FuturesBalanceUpdate, error) {
+	if w.userID == 0 {
+		return nil, fmt.Errorf("user ID is required for private channels, create the client with NewWSClient")
+	}
+	payload := []string{strconv.Itoa(w.userID)}
+	return subscribeConverted(ctx, w, "futures.balances", payload, true, (*wsBalance).balanceUpdate, nil)
+}
+
+// SubscribeAutoOrders streams futures.autoorders (price-triggered order) updates
+// for the given contracts (all contracts if none given).
+// The returned channel is closed when ctx is done or the client is closed.
+func (w *WSClient) SubscribeAutoOrders(ctx context.Context, contracts ...string) (<-chan PriceTriggeredOrder, error) {
+	payload, err := w.privatePayload(contracts)
+	if err != nil {
+		return nil, err
+	}
+	keep := contractFilter(contracts, func(o *PriceTriggeredOrder) string { return o.Contract })
+	return subscribeConverted(ctx, w, "futures.autoorders", payload, true, (*wsAutoOrder).priceTriggeredOrder, keep)
+}
+
+// --- Wire types ---
+//
+// Private channels push numeric fields (prices, fees, user IDs) as JSON numbers
+// where the REST API uses strings. The types below accept either encoding and
+// are converted into the REST structs so consumers handle a single set of types.
+
+// flexString decodes a JSON string or number into its textual representation.
+type flexString string
+
+func (f *flexString) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(data, []byte("null")) {
+		*f = ""
+		return nil
+	}
+	if len(data) > 0 && data[0] == '"' {
+		var s string
+		if err := json.Unmarshal(data, &s); err != nil {
+			return err
+		}
+		*f = flexString(s)
+		return nil
+	}
+	*f = flexString(data)
+	return nil
+}
+
+// flexInt decodes a JSON number or numeric string into an int64.
+type flexInt int64
+
+func (f *flexInt) UnmarshalJSON(data []byte) error {
+	var s flexString
+	if err := s.UnmarshalJSON(data); err != nil {
+		return err
+	}
+	if s == "" {
+		*f = 0
+		return nil
+	}
+	n, err := strconv.ParseInt(string(s), 10, 64)
+	if err != nil {
+		return err
+	}
+	*f = flexInt(n)
+	return nil
+}
+
+type wsFuturesOrder struct {
+	ID           int64           `json:"id"`
+	User         flexInt         `json:"user"`
+	CreateTime   decimal.Decimal `json:"create_time"`
+	FinishTime   decimal.Decimal `json:"finish_time"`
+	FinishAs     string          `json:"finish_as"`
+	Status       string          `json:"status"`
+	Contract     string          `json:"contract"`
+	Size         int64           `json:"size"`
+	Iceberg      int64           `json:"iceberg"`
+	Price        decimal.Decimal `json:"price"`
+	IsClose      bool            `json:"is_close"`
+	IsReduceOnly bool            `json:"is_reduce_only"`
+	IsLiq        bool            `json:"is_liq"`
+	Tif          string          `json:"tif"`
+	Left         int64           `json:"left"`
+	FillPrice    decimal.Decimal `json:"fill_price"`
+	Text         string          `json:"text"`
+	Tkfr         decimal.Decimal `json:"tkfr"`
+	Mkfr         decimal.Decimal `json:"mkfr"`
+	Refu         int             `json:"refu"`
+	AutoSize     string          `json:"auto_size"`
+	StpAct       string          `json:"stp_act"`
+	StpID        int             `json:"stp_id"`
+}
+
+func (o *wsFuturesOrder) futuresOrder() FuturesOrder {
+	return FuturesOrder{
+		ID:           o.ID,
+		User:         int(o.User),
+		CreateTime:   o.CreateTime,
+		FinishTime:   o.FinishTime,
+		FinishAs:     o.FinishAs,
+		Status:       o.Status,
+		Contract:     o.Contract,
+		Size:         o.Size,
+		Iceberg:      o.Iceberg,
+		Price:        o.Price,
+		IsClose:      o.IsClose,
+		IsReduceOnly: o.IsReduceOnly,
+		ReduceOnly:   o.IsReduceOnly,
+		IsLiq:        o.IsLiq,
+		Tif:          o.Tif,
+		Left:         o.Left,
+		FillPrice:    o.FillPrice,
+		Text:         o.Text,
+		Tkfr:         o.Tkfr,
+		Mkfr:         o.Mkfr,
+		Refu:         o.Refu,
+		AutoSize:     o.AutoSize,
+		StpAct:       o.StpAct,
+		StpID:        o.StpID,
+	}
+}
+
+type wsUserTrade struct {
+	ID           flexString      `json:"id"`
+	OrderID      flexString      `json:"order_id"`
+	Contract     string          `json:"contract"`
+	CreateTime   int64           `json:"create_time"`
+	CreateTimeMs int64           `json:"create_time_ms"`
+	Size         int64           `json:"size"`
+	Price        decimal.Decimal `json:"price"`
+	Role         string          `json:"role"`
+	Text         string          `json:"text"`
+	Fee          decimal.Decimal `json:"fee"`
+	PointFee     decimal.Decimal `json:"point_fee"`
+}
+
+func (t *wsUserTrade) userTrade() FuturesUserTrade {
+	return FuturesUserTrade{
+		ID:           string(t.ID),
+		OrderID:      string(t.OrderID),
+		Contract:     t.Contract,
+		CreateTime:   t.CreateTime,
+		CreateTimeMs: t.CreateTimeMs,
+		Size:         t.Size,
+		Price:        t.Price,
+		Role:         t.Role,
+		Text:         t.Text,
+		Fee:          t.Fee,
+		PointFee:     t.PointFee,
+	}
+}
+
+type wsPosition struct {
+	User               flexInt         `json:"user"`
+	Contract           string          `json:"contract"`
+	Size               int64           `json:"size"`
+	Leverage           decimal.Decimal `json:"leverage"`
+	RiskLimit          decimal.Decimal `json:"risk_limit"`
+	LeverageMax        decimal.Decimal `json:"leverage_max"`
+	MaintenanceRate    decimal.Decimal `json:"maintenance_rate"`
+	Margin             decimal.Decimal `json:"margin"`
+	EntryPrice         decimal.Decimal `json:"entry_price"`
+	LiqPrice           decimal.Decimal `json:"liq_price"`
+	RealisedPnl        decimal.Decimal `json:"realised_pnl"`
+	HistoryPnl         decimal.Decimal `json:"history_pnl"`
+	LastClosePnl       decimal.Decimal `json:"last_close_pnl"`
+	RealisedPoint      decimal.Decimal `json:"realised_point"`
+	HistoryPoint       decimal.Decimal `json:"history_point"`
+	Mode               string          `json:"mode"`
+	CrossLeverageLimit decimal.Decimal `json:"cross_leverage_limit"`
+}
+
+func (p *wsPosition) position() Position {
+	return Position{
+		User:               int(p.User),
+		Contract:           p.Contract,
+		Size:               p.Size,
+		Leverage:           p.Leverage,
+		RiskLimit:          p.RiskLimit,
+		LeverageMax:        p.LeverageMax,
+		MaintenanceRate:    p.MaintenanceRate,
+		Margin:             p.Margin,
+		EntryPrice:         p.EntryPrice,
+		LiqPrice:           p.LiqPrice,
+		RealisedPnl:        p.RealisedPnl,
+		HistoryPnl:         p.HistoryPnl,
+		LastClosePnl:       p.LastClosePnl,
+		RealisedPoint:      p.RealisedPoint,
+		HistoryPoint:       p.HistoryPoint,
+		Mode:               p.Mode,
+		CrossLeverageLimit: p.CrossLeverageLimit,
+	}
+}
+
+type wsBalance struct {
+	Balance  decimal.Decimal `json:"balance"`
+	Change   decimal.Decimal `json:"change"`
+	Text     string          `json:"text"`
+	Time     int64           `json:"time"`
+	TimeMs   int64           `json:"time_ms"`
+	Type     string          `json:"type"`
+	User     flexString      `json:"user"`
+	Currency string          `json:"currency"`
+}
+
+func (b *wsBalance) balanceUpdate() FuturesBalanceUpdate {
+	return FuturesBalanceUpdate{
+		Balance:  b.Balance,
+		Change:   b.Change,
+		Text:     b.Text,
+		Time:     b.Time,
+		TimeMs:   b.TimeMs,
+		Type:     b.Type,
+		User:     string(b.User),
+		Currency: b.Currency,
+	}
+}
+
+type wsTrigger struct {
+	Price      decimal.Decimal `json:"price"`
+	Rule       int             `json:"rule"`
+	Expiration int             `json:"expiration"`
+	PriceType  flexString      `json:"price_type"`
+}
+
+type wsAutoOrder struct {
+	ID         int64          `json:"id"`
+	User       flexInt        `json:"user"`
+	CreateTime int64          `json:"create_time"`
+	Trigger    wsTrigger      `json:"trigger"`
+	Initial    wsFuturesOrder `json:"initial"`
+	Status     string         `json:"status"`
+	Reason     string         `json:"reason"`
+	OrderType  string         `json:"order_type"`
+}
+
+func (o *wsAutoOrder) priceTriggeredOrder() PriceTriggeredOrder {
+	initial := o.Initial.futuresOrder()
+	return PriceTriggeredOrder{
+		ID:         o.ID,
+		User:       int(o.User),
+		Contract:   initial.Contract,
+		CreateTime: o.CreateTime,
+		Trigger: Trigger{
+			Price:      o.Trigger.Price,
+			Rule:       o.Trigger.Rule,
+			Expiration: o.Trigger.Expiration,
+			PriceType:  string(o.Trigger.PriceType),
+		},
+		Initial:   initial,
+		Status:    o.Status,
+		Reason:    o.Reason,
+		OrderType: o.OrderType,
+	}
+}
@@ -0,0 +1,49 @@
+package gateio
+
+import (
+	"context"
+	"fmt"
+	"slices"
+)
+
+// SubscribeTickers streams futures.tickers updates for the given contracts.
+// The returned channel is closed when ctx is done or the client is closed.
+func (w *WSClient) SubscribeTickers(ctx context.Context, contracts ...string) (<-chan FuturesTicker, error) {
+	if len(contracts) == 0 {
+		return nil, fmt.Errorf("at least one contract is required")
+	}
+	keep := func(t *FuturesTicker) bool { return slices.Contains(contracts, t.Contract) }
+	return subscribeTyped(ctx, w, "futures.tickers", contracts, false, keep)
+}
+
+// SubscribeTrades streams futures.trades updates for the given contracts.
+// Trade.Size is positive for buys and negative for sells.
+// The returned channel is closed when ctx is done or the client is closed.
+func (w *WSClient) SubscribeTrades(ctx context.Context, contracts ...string) (<-chan FuturesTrade, error) {
+	if len(contracts) == 0 {
+		return nil, fmt.Errorf("at least one contract is required")
+	}
+	keep := func(t *FuturesTrade) bool { return slices.Contains(contracts, t.Contract) }
+	return subscribeTyped(ctx, w, "futures.trades", contracts, false, keep)
+}
+
+// SubscribeOrderBookUpdate streams incremental order book updates for a contract.
+// contract: Futures contract name
+// frequency: Update frequency, "20ms" (level 20 only) or "100ms"
+// level: Depth level, "100", "50" or "20"
+// Updates carry FirstID/LastID so consumers can detect gaps against a snapshot
+// fetched with ListFuturesOrderBook(withID=true).
+// The returned channel is closed when ctx is done or the client is closed.
+func (w *WSClient) SubscribeOrderBookUpdate(ctx context.Context, contract, frequency, level string) (<-chan FuturesOrderBookUpdate, error) {
+	keep := func(u *FuturesOrderBookUpdate) bool { return u.Contract == contract }
+	return subscribeTyped(ctx, w, "futures.order_book_update", []string{contract, frequency, level}, false, keep)
+}
+
+// SubscribeCandlesticks streams futures.candlesticks updates for a contract.
+// interval: 10s, 1m, 5m, 15m, 30m, 1h, 4h, 8h, 1d, 7d
+// The returned channel is closed when ctx is done or the client is closed.
+func (w *WSClient) SubscribeCandlesticks(ctx context.Context, interval, contract string) (<-chan FuturesCandlestickUpdate, error) {
+	name := interval + "_" + contract
+	keep := func(c *FuturesCandlestickUpdate) bool { return c.Name == "" || c.Name == name }
+	return subscribeTyped(ctx, w, "futures.candlesticks", []string{interval, contract}, false, keep)
+}
@@ -0,0 +1,148 @@
+package gateio
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/neqin/futures/clock"
+)
+
+func TestUnsharedPayload(t *testing.T) {
+	tests := []struct {
+		name         string
+		channel      string
+		ending       []string
+		otherChannel string // Channel of others if not channel
+		others       [][]string
+		want         []string
+	}{
+		{
+			name: "contract still covered by a wider subscription", channel: "futures.tickers",
+			ending: []string{"BTC_USDT"}, others: [][]string{{"BTC_USDT", "ETH_USDT"}},
+			want: nil,
+		},
+		{
+			name: "only the uncovered contracts", channel: "futures.tickers",
+			ending: []string{"BTC_USDT", "ETH_USDT", "SOL_USDT"}, others: [][]string{{"ETH_USDT"}},
+			want: []string{"BTC_USDT", "SOL_USDT"},
+		},
+		{
+			name: "identical subscription", channel: "futures.trades",
+			ending: []string{"BTC_USDT"}, others: [][]string{{"BTC_USDT"}},
+			want: nil,
+		},
+		{
+			name: "other channels do not count", channel: "futures.trades",
+			ending: []string{"BTC_USDT"}, otherChannel: "futures.tickers", others: [][]string{{"BTC_USDT"}},
+			want: []string{"BTC_USDT"},
+		},
+		{
+			name: "private channel keeps the user ID", channel: "futures.orders",
+			ending: []string{"42", "BTC_USDT", "ETH_USDT"}, others: [][]string{{"42", "ETH_USDT"}},
+			want: []string{"42", "BTC_USDT"},
+		},
+		{
+			name: "unit payload shared", channel: "futures.order_book_update",
+			ending: []string{"BTC_USDT", "100ms", "20"}, others: [][]string{{"BTC_USDT", "100ms", "20"}},
+			want: nil,
+		},
+		{
+			name: "unit payload not shared", channel: "futures.order_book_update",
+			ending: []string{"BTC_USDT", "100ms", "20"}, others: [][]string{{"BTC_USDT", "20ms", "20"}},
+			want: []string{"BTC_USDT", "100ms", "20"},
+		},
+	}
+	for _, tt := range tests {
+		w := NewPublicWSClient("usdt", nil)
+		for _, payload := range tt.others {
+			channel := tt.channel
+			if tt.otherChannel != "" {
+				channel = tt.otherChannel
+			}
+			w.subs[&wsSubscription{channel: channel, payload: payload}] = struct{}{}
+		}
+		got := w.unsharedPayloadLocked(&wsSubscription{channel: tt.channel, payload: tt.ending})
+		if !slices.Equal(got, tt.want) {
+			t.Errorf("%s: unsubscribe payload = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestDispatchDropsWhenBufferFull(t *testing.T) {
+	w := NewPublicWSClient("usdt", nil)
+	slow := &wsSubscription{channel: "futures.tickers", raw: make(chan json.RawMessage, 1), done: make(chan struct{})}
+	fast := &wsSubscription{channel: "futures.tickers", raw: make(chan json.RawMessage, 2), done: make(chan struct{})}
+	w.subs[slow] = struct{}{}
+	w.subs[fast] = struct{}{}
+
+	// Neither subscription is drained; the second update must not block.
+	w.dispatch(&wsMessage{Channel: "futures.tickers", Event: "update", Result: json.RawMessage(`1`)})
+	w.dispatch(&wsMessage{Channel: "futures.tickers", Event: "update", Result: json.RawMessage(`2`)})
+
+	if len(fast.raw) != 2 {
+		t.Errorf("fast subscription received %d updates, want 2", len(fast.raw))
+	}
+	if got := string(<-slow.raw); got != "1" {
+		t.Errorf("slow subscription kept update %s, want 1", got)
+	}
+	select {
+	case err := <-w.Errors():
+		if !errors.Is(err, ErrWSBufferFull) {
+			t.Errorf("reported %v, want ErrWSBufferFull", err)
+		}
+	default:
+		t.Error("dropped update not reported")
+	}
+}
+
+func TestPrivateAuthUsesClock(t *testing.T) {
+	frames := make(chan wsRequest, 1)
+	upgrader := websocket.Upgrader{}
+	srv := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(rw, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		var req wsRequest
+		if err := conn.ReadJSON(&req); err != nil {
+			return
+		}
+		frames <- req
+		conn.WriteJSON(wsMessage{ID: req.ID, Channel: req.Channel, Event: req.Event})
+		conn.ReadMessage() // Hold the connection until the client closes it
+	}))
+	defer srv.Close()
+
+	// The server clock is an hour ahead of the local one.
+	now := time.Now()
+	offset := clock.NewOffset()
+	offset.Observe(now, now, now.Add(time.Hour))
+
+	w := NewWSClient("key", "secret", "usdt", 42, nil)
+	defer w.Close()
+	w.SetBaseURL("ws" + strings.TrimPrefix(srv.URL, "http"))
+	w.SetClock(offset)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if _, err := w.SubscribeOrders(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	req := <-frames
+	if skew := time.Unix(req.Time, 0).Sub(now.Add(time.Hour)); skew < -2*time.Second || skew > 2*time.Second {
+		t.Errorf("auth timestamp %d is %v off the server clock", req.Time, skew)
+	}
+	want, _ := NewHMACSigner("secret").Sign(ctx, WSPayload(req.Channel, req.Event, req.Time))
+	if req.Auth == nil || req.Auth.Sign != want {
+		t.Errorf("auth = %+v, want a signature over timestamp %d", req.Auth, req.Time)
+	}
+}
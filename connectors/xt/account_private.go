@@ -13,7 +13,7 @@ import (
 // Endpoint: GET /future/user/v1/account/info
 func (c *Client) GetAccountInfo(ctx context.Context) (*AccountInfoResult, error) {
 	path := "/future/user/v1/account/info"
-	baseURL := c.getBaseURL("USDT-M") // Assuming USDT-M
+	baseURL := c.defaultBaseURL()
 	var result AccountInfoResult
 	err := c.SendPrivateRequest(ctx, http.MethodGet, baseURL, path, nil, nil, &result)
 	if err != nil {
@@ -26,7 +26,7 @@ func (c *Client) GetAccountInfo(ctx context.Context) (*AccountInfoResult, error)
 // Endpoint: GET /future/user/v1/user/listen-key
 func (c *Client) GetListenKey(ctx context.Context) (*ListenKeyResult, error) {
 	path := "/future/user/v1/user/listen-key"
-	baseURL := c.getBaseURL("USDT-M") // Assuming USDT-M
+	baseURL := c.defaultBaseURL()
 	var result ListenKeyResult
 	// Docs say GET, but xt.txt example uses POST? Let's try GET first based on docs.
 	err := c.SendPrivateRequest(ctx, http.MethodGet, baseURL, path, nil, nil, &result)
@@ -40,7 +40,7 @@ func (c *Client) GetListenKey(ctx context.Context) (*ListenKeyResult, error) {
 // Endpoint: POST /future/user/v1/account/open
 func (c *Client) AccountOpen(ctx context.Context) (*AccountOpenResult, error) {
 	path := "/future/user/v1/account/open"
-	baseURL := c.getBaseURL("USDT-M") // Assuming USDT-M
+	baseURL := c.defaultBaseURL()
 	var result AccountOpenResult
 	err := c.SendPrivateRequest(ctx, http.MethodPost, baseURL, path, nil, nil, &result) // POST with empty body
 	if err != nil {
@@ -53,7 +53,7 @@ func (c *Client) AccountOpen(ctx context.Context) (*AccountOpenResult, error) {
 // Endpoint: GET /future/user/v1/balance/detail
 func (c *Client) GetBalance(ctx context.Context, coin string) (*GetBalanceResult, error) {
 	path := "/future/user/v1/balance/detail"
-	baseURL := c.getBaseURL("USDT-M") // Assuming USDT-M
+	baseURL := c.defaultBaseURL()
 	params := map[string]string{
 		"coin": coin,
 	}
@@ -69,7 +69,7 @@ func (c *Client) GetBalance(ctx context.Context, coin string) (*GetBalanceResult
 // Endpoint: GET /future/user/v1/balance/list
 func (c *Client) GetBalanceList(ctx context.Context) (*BalanceListResult, error) {
 	path := "/future/user/v1/balance/list"
-	baseURL := c.getBaseURL("USDT-M") // Assuming USDT-M
+	baseURL := c.defaultBaseURL()
 	var result BalanceListResult
 	err := c.SendPrivateRequest(ctx, http.MethodGet, baseURL, path, nil, nil, &result)
 	if err != nil {
@@ -82,7 +82,7 @@ func (c *Client) GetBalanceList(ctx context.Context) (*BalanceListResult, error)
 // Endpoint: GET /future/user/v1/compat/balance/list
 func (c *Client) GetCompatBalanceList(ctx context.Context, queryAccountID *string) (*CompatBalanceListResult, error) {
 	path := "/future/user/v1/compat/balance/list"
-	baseURL := c.getBaseURL("USDT-M") // Assuming USDT-M
+	baseURL := c.defaultBaseURL()
 	params := map[string]string{}
 	if queryAccountID != nil {
 		params["queryAccountId"] = *queryAccountID
@@ -99,7 +99,7 @@ func (c *Client) GetCompatBalanceList(ctx context.Context, queryAccountID *strin
 // Endpoint: GET /future/user/v1/balance/bills
 func (c *Client) GetBalanceBills(ctx context.Context, symbol string, direction *string, id *int64, limit *int, startTime, endTime *int64) (*GetBalanceBillsResult, error) {
 	path := "/future/user/v1/balance/bills"
-	baseURL := c.getBaseURL("USDT-M") // Assuming USDT-M
+	baseURL := c.symbolBaseURL(ctx, symbol)
 	params := map[string]string{
 		"symbol": symbol, // Required
 	}
@@ -132,7 +132,7 @@ func (c *Client) GetBalanceBills(ctx context.Context, symbol string, direction *
 // Endpoint: GET /future/user/v1/balance/funding-rate-list
 func (c *Client) GetFundingRateList(ctx context.Context, symbol string, direction *string, id *int64, limit *int, startTime, endTime *int64) (*GetUserFundingRateListResult, error) {
 	path := "/future/user/v1/balance/funding-rate-list"
-	baseURL := c.getBaseURL("USDT-M") // Assuming USDT-M
+	baseURL := c.symbolBaseURL(ctx, symbol)
 	params := map[string]string{
 		"symbol": symbol, // Required
 	}
@@ -164,7 +164,7 @@ func (c *Client) GetFundingRateList(ctx context.Context, symbol string, directio
 // Endpoint: GET /future/user/v1/position/list
 func (c *Client) GetPositions(ctx context.Context, symbol *string) (*GetPositionsResult, error) {
 	path := "/future/user/v1/position/list"
-	baseURL := c.getBaseURL("USDT-M") // Assuming USDT-M
+	baseURL := c.symbolBaseURL(ctx, deref(symbol))
 	params := map[string]string{}
 	if symbol != nil {
 		params["symbol"] = *symbol
@@ -185,7 +185,7 @@ func (c *Client) GetPositions(ctx context.Context, symbol *string) (*GetPosition
 // Endpoint: GET /future/user/v1/position
 func (c *Client) GetActivePositions(ctx context.Context, symbol *string) (*GetPositionsResult, error) {
 	path := "/future/user/v1/position" // Different endpoint path
-	baseURL := c.getBaseURL("USDT-M")  // Assuming USDT-M
+	baseURL := c.symbolBaseURL(ctx, deref(symbol))
 	params := map[string]string{}
 	if symbol != nil {
 		params["symbol"] = *symbol
@@ -206,7 +206,7 @@ func (c *Client) GetActivePositions(ctx context.Context, symbol *string) (*GetPo
 // Endpoint: GET /future/user/v1/user/step-rate
 func (c *Client) GetUserStepRate(ctx context.Context) (*StepRateResult, error) {
 	path := "/future/user/v1/user/step-rate"
-	baseURL := c.getBaseURL("USDT-M") // Assuming USDT-M
+	baseURL := c.defaultBaseURL()
 	var result StepRateResult
 	err := c.SendPrivateRequest(ctx, http.MethodGet, baseURL, path, nil, nil, &result)
 	if err != nil {
@@ -219,8 +219,8 @@ func (c *Client) GetUserStepRate(ctx context.Context) (*StepRateResult, error) {
 // Endpoint: POST /future/user/v1/position/adjust-leverage
 func (c *Client) AdjustLeverage(ctx context.Context, symbol, positionSide string, leverage int) (*AdjustLeverageResult, error) {
 	path := "/future/user/v1/position/adjust-leverage"
-	baseURL := c.getBaseURL("USDT-M") // Assuming USDT-M
-	bodyParams := map[string]string{  // Docs indicate x-www-form-urlencoded or JSON, let's try map for form
+	baseURL := c.symbolBaseURL(ctx, symbol)
+	bodyParams := map[string]string{ // Docs indicate x-www-form-urlencoded or JSON, let's try map for form
 		"symbol":       symbol,
 		"positionSide": positionSide,
 		"leverage":     strconv.Itoa(leverage),
@@ -237,7 +237,7 @@ func (c *Client) AdjustLeverage(ctx context.Context, symbol, positionSide string
 // Endpoint: POST /future/user/v1/position/margin
 func (c *Client) UpdatePositionMargin(ctx context.Context, symbol, margin, marginType string, positionSide *string) (*UpdatePositionMarginResult, error) {
 	path := "/future/user/v1/position/margin"
-	baseURL := c.getBaseURL("USDT-M") // Assuming USDT-M
+	baseURL := c.symbolBaseURL(ctx, symbol)
 
 	if marginType != "ADD" && marginType != "SUB" {
 		return nil, fmt.Errorf("invalid marginType: must be ADD or SUB")
@@ -264,7 +264,7 @@ func (c *Client) UpdatePositionMargin(ctx context.Context, symbol, margin, margi
 // Endpoint: POST /future/user/v1/position/close-all
 func (c *Client) AllPositionClose(ctx context.Context) (*AllPositionCloseResult, error) {
 	path := "/future/user/v1/position/close-all"
-	baseURL := c.getBaseURL("USDT-M") // Assuming USDT-M
+	baseURL := c.defaultBaseURL()
 	var result AllPositionCloseResult
 	err := c.SendPrivateRequest(ctx, http.MethodPost, baseURL, path, nil, nil, &result) // POST with empty body
 	if err != nil {
@@ -277,7 +277,7 @@ func (c *Client) AllPositionClose(ctx context.Context) (*AllPositionCloseResult,
 // Endpoint: GET /future/user/v1/position/adl
 func (c *Client) PositionADL(ctx context.Context) (*PositionADLResult, error) {
 	path := "/future/user/v1/position/adl"
-	baseURL := c.getBaseURL("USDT-M") // Assuming USDT-M
+	baseURL := c.defaultBaseURL()
 	var result PositionADLResult
 	err := c.SendPrivateRequest(ctx, http.MethodGet, baseURL, path, nil, nil, &result)
 	if err != nil {
@@ -290,8 +290,8 @@ func (c *Client) PositionADL(ctx context.Context) (*PositionADLResult, error) {
 // Endpoint: POST /future/user/v1/user/collection/add
 func (c *Client) CollectionAdd(ctx context.Context, symbol string) (*CollectionAddResult, error) {
 	path := "/future/user/v1/user/collection/add"
-	baseURL := c.getBaseURL("USDT-M") // Assuming USDT-M
-	bodyParams := map[string]string{  // Docs indicate x-www-form-urlencoded or JSON
+	baseURL := c.symbolBaseURL(ctx, symbol)
+	bodyParams := map[string]string{ // Docs indicate x-www-form-urlencoded or JSON
 		"symbol": symbol,
 	}
 	var result CollectionAddResult
@@ -306,8 +306,8 @@ func (c *Client) CollectionAdd(ctx context.Context, symbol string) (*CollectionA
 // Endpoint: POST /future/user/v1/user/collection/cancel
 func (c *Client) CollectionCancel(ctx context.Context, symbol string) (*CollectionCancelResult, error) {
 	path := "/future/user/v1/user/collection/cancel"
-	baseURL := c.getBaseURL("USDT-M") // Assuming USDT-M
-	bodyParams := map[string]string{  // Docs indicate x-www-form-urlencoded or JSON
+	baseURL := c.symbolBaseURL(ctx, symbol)
+	bodyParams := map[string]string{ // Docs indicate x-www-form-urlencoded or JSON
 		"symbol": symbol,
 	}
 	var result CollectionCancelResult
@@ -322,7 +322,7 @@ func (c *Client) CollectionCancel(ctx context.Context, symbol string) (*Collecti
 // Endpoint: GET /future/user/v1/user/collection/list
 func (c *Client) CollectionList(ctx context.Context) (*CollectionListResult, error) {
 	path := "/future/user/v1/user/collection/list"
-	baseURL := c.getBaseURL("USDT-M") // Assuming USDT-M
+	baseURL := c.defaultBaseURL()
 	var result CollectionListResult
 	err := c.SendPrivateRequest(ctx, http.MethodGet, baseURL, path, nil, nil, &result)
 	if err != nil {
@@ -335,8 +335,8 @@ func (c *Client) CollectionList(ctx context.Context) (*CollectionListResult, err
 // Endpoint: POST /future/user/v1/position/change-type
 func (c *Client) ChangePositionType(ctx context.Context, symbol, positionSide, positionType string) (*ChangePositionTypeResult, error) {
 	path := "/future/user/v1/position/change-type"
-	baseURL := c.getBaseURL("USDT-M") // Assuming USDT-M
-	bodyParams := map[string]string{  // Docs indicate x-www-form-urlencoded or JSON
+	baseURL := c.symbolBaseURL(ctx, symbol)
+	bodyParams := map[string]string{ // Docs indicate x-www-form-urlencoded or JSON
 		"symbol":       symbol,
 		"positionSide": positionSide,
 		"positionType": positionType,
@@ -353,7 +353,7 @@ func (c *Client) ChangePositionType(ctx context.Context, symbol, positionSide, p
 // Endpoint: GET /future/user/v1/position/break-list
 func (c *Client) GetBreakList(ctx context.Context, symbol *string) (*BreakListResult, error) {
 	path := "/future/user/v1/position/break-list"
-	baseURL := c.getBaseURL("USDT-M") // Assuming USDT-M
+	baseURL := c.symbolBaseURL(ctx, deref(symbol))
 	params := map[string]string{}
 	if symbol != nil {
 		params["symbol"] = *symbol
@@ -0,0 +1,50 @@
+package xt
+
+import (
+	"context"
+	"time"
+
+	"github.com/neqin/futures/candles"
+)
+
+// klinesPerRequest is the maximum number of k-lines returned by GetKlines.
+const klinesPerRequest = 1000
+
+// CandleSource implements candles.Source with GetKlines.
+type CandleSource struct {
+	client *Client
+}
+
+// NewCandleSource creates a candle source for client. Symbols are routed to
+// the USDT-M or COIN-M host like any other request.
+func NewCandleSource(client *Client) *CandleSource {
+	return &CandleSource{client: client}
+}
+
+// Limit returns the maximum number of candles per request.
+func (s *CandleSource) Limit() int {
+	return klinesPerRequest
+}
+
+// Fetch returns the candles of symbol opened within [from, to]. Volume is the
+// k-line amount ("a"), as in Exchange.Candles.
+func (s *CandleSource) Fetch(ctx context.Context, symbol, interval string, from, to time.Time) ([]candles.Candle, error) {
+	startMs, endMs := from.UnixMilli(), to.UnixMilli()
+	limit := klinesPerRequest
+	res, err := s.client.GetKlines(ctx, symbol, interval, &startMs, &endMs, &limit)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]candles.Candle, 0, len(res.Result))
+	for _, k := range res.Result {
+		result = append(result, candles.Candle{
+			Time:   time.UnixMilli(k.Time),
+			Open:   k.Open,
+			High:   k.High,
+			Low:    k.Low,
+			Close:  k.Close,
+			Volume: k.Amount,
+		})
+	}
+	return result, nil
+}
@@ -3,19 +3,23 @@ package xt
 import (
 	"bytes"
 	"context"
-	"crypto/hmac"
-	"crypto/sha256"
-	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
-	"log"
+	"log/slog"
 	"net/http"
 	"net/url"
 	"sort"
 	"strconv"
 	"strings"
 	"time"
+
+	"github.com/neqin/futures/clock"
+	"github.com/neqin/futures/credentials"
+	"github.com/neqin/futures/httplog"
+	"github.com/neqin/futures/ratelimit"
+	"github.com/neqin/futures/retry"
 )
 
 const (
@@ -26,12 +30,21 @@ const (
 
 // Client is the main XT.com Futures API client.
 type Client struct {
-	apiKey      string
-	secretKey   string
+	credentials credentials.Provider // Key pair consulted per request; nil for public-only clients
+	ownsCreds   bool                 // Whether Close closes credentials
+	signer      Signer               // Custom signer; nil signs with the credentials' secret
 	usdtBaseURL string
 	coinBaseURL string
 	httpClient  *http.Client
 	recvWindow  string // Receive window in milliseconds as a string
+	sendWindow  bool   // Whether validate-recvwindow is sent and signed
+	clock       *clock.Offset
+	limiter     *ratelimit.Limiter
+	retryPolicy retry.Policy
+	log         *httplog.Logger
+	underlying  UnderlyingType   // Host for requests without a symbol
+	markets     *marketCache     // Underlying type and specs per symbol, shared with WithUnderlying copies
+	validation  *OrderValidation // Pre-trade checks for PlaceOrder; nil disables them
 }
 
 // NewClient creates a new XT.com Futures API client.
@@ -39,72 +52,133 @@ func NewClient(apiKey, secretKey string, httpClient *http.Client) *Client {
 	if httpClient == nil {
 		httpClient = &http.Client{Timeout: 10 * time.Second} // Default timeout
 	}
-	return &Client{
+	c := &Client{
 		usdtBaseURL: defaultUsdtBaseURL,
 		coinBaseURL: defaultCoinBaseURL,
-		apiKey:      apiKey,
-		secretKey:   secretKey,
 		httpClient:  httpClient,
 		recvWindow:  defaultRecvWindow,
+		clock:       clock.NewOffset(),
+		limiter:     ratelimit.New(DefaultRateLimits()),
+		retryPolicy: retry.DefaultPolicy(),
+		log:         httplog.New("xt"),
+		underlying:  USDTMargined,
+		markets:     newMarketCache(),
+	}
+	if apiKey != "" || secretKey != "" {
+		c.credentials, c.ownsCreds = credentials.NewStatic(apiKey, secretKey), true
 	}
+	return c
 }
 
 // SetUsdtBaseURL allows overriding the default USDT-M base URL.
 func (c *Client) SetUsdtBaseURL(baseURL string) {
 	c.usdtBaseURL = strings.TrimSuffix(baseURL, "/")
-	log.Printf("XT Client USDT Base URL set to: %s", c.usdtBaseURL)
+	c.log.Info("base URL set", "underlying", "usdt", "url", c.usdtBaseURL)
 }
 
 // SetCoinBaseURL allows overriding the default COIN-M base URL.
 func (c *Client) SetCoinBaseURL(baseURL string) {
 	c.coinBaseURL = strings.TrimSuffix(baseURL, "/")
-	log.Printf("XT Client COIN Base URL set to: %s", c.coinBaseURL)
+	c.log.Info("base URL set", "underlying", "coin", "url", c.coinBaseURL)
+}
+
+// SetLogger replaces the logger of requests and configuration changes
+// (slog.Default() by default). Successful requests are logged at Debug level,
+// failed ones, including responses with a non-zero returnCode, at Warn level;
+// see package httplog. A nil logger disables logging.
+func (c *Client) SetLogger(logger *slog.Logger) {
+	c.log.SetLogger(logger)
+}
+
+// SetLogBodies enables logging of request and response bodies, redacted and
+// truncated to maxBytes (in full if negative). 0, the default, disables it.
+func (c *Client) SetLogBodies(maxBytes int) {
+	c.log.SetMaxBody(maxBytes)
 }
 
 // SetRecvWindow sets the request validity window in milliseconds. Default is 5000 (5 seconds).
+// The window is only transmitted after SetSendRecvWindow(true).
 func (c *Client) SetRecvWindow(ms int64) {
 	c.recvWindow = strconv.FormatInt(ms, 10)
 }
 
-// getBaseURL returns the appropriate base URL based on underlying type (e.g., USDT-M or COIN-M).
-// For now, defaulting to USDT-M as most examples use it. This might need refinement
-// if methods need to dynamically choose based on symbol or explicit parameter.
-func (c *Client) getBaseURL(underlyingType string) string {
-	// TODO: Add logic to select URL based on underlyingType if needed
-	// if underlyingType == "COIN-M" { return c.coinBaseURL }
-	return c.usdtBaseURL
+// SetSendRecvWindow controls whether signed requests carry the validate-recvwindow
+// header (included in the signature). Disabled by default, in which case XT applies
+// its server-side default window.
+func (c *Client) SetSendRecvWindow(enabled bool) {
+	c.sendWindow = enabled
 }
 
-// generateSignature creates the HMAC SHA256 signature based on XT documentation (xt2.txt).
-func (c *Client) generateSignature(timestamp, path, sortedQuery, bodyString string) string {
-	// X = Sorted header parameters
-	headerPart := fmt.Sprintf("validate-appkey=%s&validate-timestamp=%s", c.apiKey, timestamp)
-	// Optional: Add recvWindow if needed
-	// headerPart += "&validate-recvwindow=" + c.recvWindow
+// SetClock replaces the clock offset used to timestamp signed requests, e.g. to
+// share one offset between several clients. See SyncClock and RunClockSync.
+func (c *Client) SetClock(offset *clock.Offset) {
+	c.clock = offset
+}
 
-	// Y = #path#query#body (adjust if query or body is empty)
-	dataPart := "#" + path
-	if sortedQuery != "" {
-		dataPart += "#" + sortedQuery
-	}
-	if bodyString != "" {
-		dataPart += "#" + bodyString
-	}
+// Clock returns the clock offset used to timestamp signed requests.
+func (c *Client) Clock() *clock.Offset {
+	return c.clock
+}
 
-	// sign = XY
-	signStr := headerPart + dataPart
+// SetRateLimiter replaces the client-side rate limiter (DefaultRateLimits by default).
+// Share one limiter between clients that use the same API key or IP. A nil limiter disables throttling.
+func (c *Client) SetRateLimiter(limiter *ratelimit.Limiter) {
+	c.limiter = limiter
+}
 
-	// signature = HMAC-SHA256(secretKey, sign)
-	mac := hmac.New(sha256.New, []byte(c.secretKey))
-	mac.Write([]byte(signStr))
-	signature := hex.EncodeToString(mac.Sum(nil))
+// SetSigner replaces the signer of private requests, e.g. to sign with a key
+// held by an external service. By default requests are signed with the secret
+// of the credentials; a nil signer restores that.
+func (c *Client) SetSigner(signer Signer) {
+	c.signer = signer
+}
 
-	// log.Printf("XT Sig Base X: %s", headerPart) // Debugging
-	// log.Printf("XT Sig Base Y: %s", dataPart) // Debugging
-	// log.Printf("XT Sig sign=XY: %s", signStr) // Debugging
-	// log.Printf("XT Signature: %s", signature) // Debugging
+// SetCredentials replaces the source of the API key pair, which is consulted
+// for every request so keys can be rotated without recreating the client. The
+// caller keeps ownership of provider and closes it; the provider created from
+// the keys passed to NewClient is closed by Close. A nil provider makes the
+// client public-only.
+func (c *Client) SetCredentials(provider credentials.Provider) {
+	if c.ownsCreds {
+		c.credentials.Close()
+	}
+	c.credentials, c.ownsCreds = provider, false
+}
+
+// Close zeroes the secret key passed to NewClient, including for the copies
+// made by WithUnderlying. Private requests fail afterwards.
+func (c *Client) Close() error {
+	if c.ownsCreds {
+		return c.credentials.Close()
+	}
+	return nil
+}
+
+// currentCredentials returns the key pair for a private request. The caller
+// zeroes it when done.
+func (c *Client) currentCredentials(ctx context.Context) (*credentials.Credentials, error) {
+	var creds *credentials.Credentials
+	if c.credentials != nil {
+		var err error
+		if creds, err = c.credentials.Credentials(ctx); err != nil {
+			return nil, retry.Permanent(fmt.Errorf("failed to get credentials: %w", err))
+		}
+	}
+	if !canSign(c.signer, creds) {
+		creds.Zero()
+		return nil, retry.Permanent(fmt.Errorf("API key and secret key must be provided for private endpoints"))
+	}
+	return creds, nil
+}
 
-	return signature
+// SetRetryPolicy replaces the retry policy (retry.DefaultPolicy by default).
+// Order creation is only retried when the order carries a ClientOrderID.
+// A nil policy disables retries.
+func (c *Client) SetRetryPolicy(policy retry.Policy) {
+	if policy == nil {
+		policy = retry.Never
+	}
+	c.retryPolicy = policy
 }
 
 // sortAndEncodeParams sorts map keys alphabetically and returns URL-encoded string "key=value&key=value..."
@@ -131,6 +205,7 @@ func sortAndEncodeParams(params map[string]string) string {
 }
 
 // sendRequest handles sending HTTP requests (both public and private).
+// Failed attempts are retried according to the client's retry policy.
 func (c *Client) sendRequest(ctx context.Context, method, baseURL, path string, queryParams map[string]string, bodyParams interface{}, isPrivate bool, target interface{}) error {
 
 	// --- Prepare URL and Query String ---
@@ -141,7 +216,6 @@ func (c *Client) sendRequest(ctx context.Context, method, baseURL, path string,
 	}
 
 	// --- Prepare Body ---
-	var bodyReader io.Reader
 	var bodyBytes []byte
 	var bodyStringForSig string // String representation of body for signature
 	var contentType string = "" // Default empty, set based on body type
@@ -151,7 +225,7 @@ func (c *Client) sendRequest(ctx context.Context, method, baseURL, path string,
 		// Check if it's form data (map[string]string)
 		if formData, ok := bodyParams.(map[string]string); ok {
 			bodyStringForSig = sortAndEncodeParams(formData) // Sort and encode form data for signature
-			bodyReader = strings.NewReader(bodyStringForSig) // Use encoded string as request body
+			bodyBytes = []byte(bodyStringForSig)             // Use encoded string as request body
 			contentType = "application/x-www-form-urlencoded"
 		} else {
 			// Assume JSON otherwise
@@ -160,15 +234,100 @@ func (c *Client) sendRequest(ctx context.Context, method, baseURL, path string,
 				return fmt.Errorf("failed to marshal request body to JSON: %w", err)
 			}
 			bodyStringForSig = string(bodyBytes)
-			bodyReader = bytes.NewReader(bodyBytes)
 			contentType = "application/json"
 		}
 	}
 
+	// Determine query string part for signature (only for GET/DELETE)
+	sigQueryPart := ""
+	if method == http.MethodGet || method == http.MethodDelete {
+		sigQueryPart = sortedQueryString
+	}
+
+	// --- Send with retries ---
+	attempt := retry.Attempt{
+		Method:     method,
+		Path:       path,
+		Idempotent: isIdempotent(method, path, bodyParams),
+	}
+	var responseBody []byte
+	for {
+		attempt.Number++
+		var statusCode int
+		var header http.Header
+		statusCode, header, responseBody, err = c.doRequest(ctx, method, path, fullURL, contentType, bodyBytes, sigQueryPart, bodyStringForSig, isPrivate)
+		// Throttling, credential and signing failures are local; resending cannot help.
+		if errors.Is(err, ratelimit.ErrRateLimited) || retry.IsPermanent(err) {
+			return err
+		}
+
+		attempt.StatusCode, attempt.Err, attempt.RetryAfter = statusCode, err, 0
+		if header != nil {
+			attempt.RetryAfter = retry.ParseRetryAfter(header.Get("Retry-After"), time.Now())
+		}
+		if delay, ok := c.retryPolicy.Next(&attempt); ok && (err != nil || statusCode >= 400) {
+			if sleepErr := retry.Sleep(ctx, delay); sleepErr == nil {
+				continue
+			}
+		}
+		if err != nil {
+			return err
+		}
+		break
+	}
+
+	// --- Handle Errors and Unmarshal ---
+	// Try unmarshalling into CommonResponse first to check returnCode
+	var commonResp CommonResponse
+	if err := json.Unmarshal(responseBody, &commonResp); err != nil {
+		// If basic unmarshal fails, return generic error
+		return fmt.Errorf("failed to unmarshal basic response structure: %w (body: %s)", err, string(responseBody))
+	}
+
+	if commonResp.ReturnCode != 0 {
+		// Return structured API error
+		return newAPIError(&commonResp)
+	}
+
+	// Unmarshal into the specific target struct if provided
+	if target != nil {
+		err = json.Unmarshal(responseBody, target)
+		if err != nil {
+			return fmt.Errorf("failed to unmarshal response body into target: %w (body: %s)", err, string(responseBody))
+		}
+	}
+
+	return nil
+}
+
+// doRequest performs a single (signed, if private) attempt and returns the raw response.
+// A non-nil error means no usable response was received.
+func (c *Client) doRequest(ctx context.Context, method, path, fullURL, contentType string, bodyBytes []byte, sigQueryPart, bodyStringForSig string, isPrivate bool) (int, http.Header, []byte, error) {
+
+	// --- Credentials (fetched per attempt to pick up rotated keys; fails before throttling when none are available) ---
+	var creds *credentials.Credentials
+	if isPrivate {
+		var err error
+		if creds, err = c.currentCredentials(ctx); err != nil {
+			return 0, nil, nil, err
+		}
+		defer creds.Zero()
+	}
+
+	// --- Throttle (fails fast if ctx's deadline cannot be met) ---
+	if err := c.limiter.Wait(ctx, endpointGroup(method, path)); err != nil {
+		return 0, nil, nil, err
+	}
+
+	var bodyReader io.Reader
+	if bodyBytes != nil {
+		bodyReader = bytes.NewReader(bodyBytes)
+	}
+
 	// --- Create Request ---
 	req, err := http.NewRequestWithContext(ctx, method, fullURL, bodyReader)
 	if err != nil {
-		return fmt.Errorf("failed to create request: %w", err)
+		return 0, nil, nil, fmt.Errorf("failed to create request: %w", err)
 	}
 
 	// --- Set Headers ---
@@ -177,76 +336,83 @@ func (c *Client) sendRequest(ctx context.Context, method, baseURL, path string,
 	}
 	req.Header.Set("Accept", "application/json") // Assume JSON response generally
 
-	// --- Add Authentication Headers (if private) ---
+	// --- Add Authentication Headers (if private, signed per attempt so the timestamp is fresh) ---
 	if isPrivate {
-		if c.apiKey == "" || c.secretKey == "" {
-			return fmt.Errorf("API key and secret key must be provided for private endpoints")
+		timestamp := strconv.FormatInt(c.clock.Now().UnixMilli(), 10)
+		recvWindow := ""
+		if c.sendWindow {
+			recvWindow = c.recvWindow
 		}
-		timestamp := strconv.FormatInt(time.Now().UnixMilli(), 10)
-
-		// Determine query string part for signature (only for GET/DELETE)
-		sigQueryPart := ""
-		if method == http.MethodGet || method == http.MethodDelete {
-			sigQueryPart = sortedQueryString
+		signature, err := sign(ctx, c.signer, creds, RequestPayload(creds.APIKey, recvWindow, timestamp, path, sigQueryPart, bodyStringForSig))
+		if err != nil {
+			return 0, nil, nil, retry.Permanent(fmt.Errorf("failed to sign request: %w", err))
 		}
 
-		signature := c.generateSignature(timestamp, path, sigQueryPart, bodyStringForSig)
-
-		req.Header.Set("validate-appkey", c.apiKey)
+		req.Header.Set("validate-appkey", creds.APIKey)
 		req.Header.Set("validate-timestamp", timestamp)
 		req.Header.Set("validate-signature", signature)
-		// Optional: Add recvWindow if needed
-		// req.Header.Set("validate-recvwindow", c.recvWindow)
+		if c.sendWindow {
+			req.Header.Set("validate-recvwindow", c.recvWindow)
+		}
 	}
 
-	// log.Printf("[XT:%s] %s", method, fullURL) // Debugging request URL
-	// log.Printf("XT Request Headers: %v", req.Header) // Debugging headers
-	// if bodyStringForSig != "" {
-	// 	log.Printf("XT Request Body String for Sig: %s", bodyStringForSig) // Debugging body
-	// }
-
 	// --- Send Request ---
+	entry := httplog.Entry{Method: method, Path: path, Query: req.URL.RawQuery, RequestBody: bodyBytes}
+	sent := time.Now()
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
-		return fmt.Errorf("failed to send request: %w", err)
+		entry.Latency, entry.Err = time.Since(sent), err
+		c.log.Request(ctx, entry)
+		return 0, nil, nil, fmt.Errorf("failed to send request: %w", err)
 	}
 	defer resp.Body.Close()
 
 	// --- Read Response Body ---
 	responseBody, err := io.ReadAll(resp.Body)
+	entry.Latency, entry.Status, entry.ResponseBody, entry.Err = time.Since(sent), resp.StatusCode, responseBody, err
+	if c.log.Enabled(ctx, slog.LevelWarn) {
+		entry.ErrorCode = errorCode(responseBody)
+	}
+	c.log.Request(ctx, entry)
 	if err != nil {
-		return fmt.Errorf("failed to read response body: %w", err)
+		return 0, nil, nil, fmt.Errorf("failed to read response body: %w", err)
 	}
+	return resp.StatusCode, resp.Header, responseBody, nil
+}
 
-	// log.Printf("XT Response Status: %s", resp.Status) // Debugging response status
-	// if len(responseBody) < 2000 { // Avoid logging huge responses
-	// 	log.Printf("XT Response Body: %s", string(responseBody))
-	// } else {
-	// 	log.Printf("XT Response Body: (omitted, length %d)", len(responseBody))
-	// }
+// clientOrderIDer is implemented by request bodies that carry an optional
+// client order ID, which makes re-sending them after an ambiguous failure safe.
+type clientOrderIDer interface {
+	clientOrderID() string
+}
 
-	// --- Handle Errors and Unmarshal ---
-	// Try unmarshalling into CommonResponse first to check returnCode
-	var commonResp CommonResponse
-	if err := json.Unmarshal(responseBody, &commonResp); err != nil {
-		// If basic unmarshal fails, return generic error
-		return fmt.Errorf("failed to unmarshal basic response structure: %w (body: %s)", err, string(responseBody))
+// isIdempotent reports whether a request may be repeated without side
+// effects: reads and cancellations always, creations only with a client order ID.
+func isIdempotent(method, path string, bodyParams interface{}) bool {
+	if method == http.MethodGet || method == http.MethodDelete {
+		return true
 	}
-
-	if commonResp.ReturnCode != 0 {
-		// Return structured API error
-		return fmt.Errorf("XT API error: code=%d, msg=%s, error=%s", commonResp.ReturnCode, commonResp.MsgInfo, string(commonResp.Error))
+	if strings.Contains(path, "/cancel") { // XT cancels are POSTs
+		return true
 	}
-
-	// Unmarshal into the specific target struct if provided
-	if target != nil {
-		err = json.Unmarshal(responseBody, target)
-		if err != nil {
-			return fmt.Errorf("failed to unmarshal response body into target: %w (body: %s)", err, string(responseBody))
-		}
+	switch b := bodyParams.(type) {
+	case clientOrderIDer:
+		return b.clientOrderID() != ""
+	case map[string]string:
+		return b["clientOrderId"] != ""
 	}
+	return false
+}
 
-	return nil
+func (r PlaceOrderRequest) clientOrderID() string { return deref(r.ClientOrderID) }
+
+func (r CreatePlanOrderRequest) clientOrderID() string { return deref(r.ClientOrderID) }
+
+func deref(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
 }
 
 // --- Public and Private Request Helpers ---
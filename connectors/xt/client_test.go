@@ -0,0 +1,343 @@
+package xt_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/neqin/futures/connectors/xt"
+	"github.com/neqin/futures/connectors/xt/xttest"
+	"github.com/neqin/futures/credentials"
+	"github.com/neqin/futures/decimal"
+	"github.com/neqin/futures/exchange"
+	"github.com/neqin/futures/paper"
+	"github.com/neqin/futures/retry"
+)
+
+const symbol = "btc_usdt"
+
+// newServer starts a mock with a btc_usdt contract quoted 100.0 / 100.5.
+func newServer(t *testing.T) (*xttest.Server, *xt.Client) {
+	t.Helper()
+	srv := xttest.NewServer(paper.Config{
+		Balance:  decimal.NewFromInt(1000),
+		MakerFee: decimal.MustParse("0.0002"),
+		TakerFee: decimal.MustParse("0.0005"),
+	})
+	t.Cleanup(srv.Close)
+	srv.AddContract(xt.Contract{
+		Symbol:         symbol,
+		ContractSize:   decimal.MustParse("0.01"),
+		PricePrecision: 1,
+		MinQty:         decimal.NewFromInt(1),
+	})
+	setBook(t, srv, 100, 100.5)
+	client := srv.Client()
+	client.SetRetryPolicy(&retry.Backoff{MaxAttempts: 3, BaseDelay: time.Millisecond, MaxDelay: time.Millisecond})
+	return srv, client
+}
+
+func setBook(t *testing.T, srv *xttest.Server, bid, ask float64) {
+	t.Helper()
+	err := srv.SetOrderBook(&exchange.OrderBook{
+		Symbol: symbol,
+		Bids:   []exchange.OrderBookLevel{{Price: bid, Size: 50}, {Price: bid - 1, Size: 50}},
+		Asks:   []exchange.OrderBookLevel{{Price: ask, Size: 50}, {Price: ask + 1, Size: 50}},
+	})
+	if err != nil {
+		t.Fatalf("SetOrderBook: %v", err)
+	}
+}
+
+func ptr[T any](v T) *T { return &v }
+
+func TestSignatureRejected(t *testing.T) {
+	srv, _ := newServer(t)
+	ctx := context.Background()
+
+	for name, client := range map[string]*xt.Client{
+		"wrong secret": xt.NewClient(xttest.APIKey, "not-the-secret", nil),
+		"wrong key":    xt.NewClient("not-the-key", xttest.SecretKey, nil),
+	} {
+		client.SetUsdtBaseURL(srv.URL)
+		_, err := client.GetBalance(ctx, "usdt")
+		if !errors.Is(err, exchange.ErrAuth) {
+			t.Errorf("%s: got %v, want ErrAuth", name, err)
+		}
+	}
+
+	skewed := srv.Client()
+	now := time.Now()
+	skewed.Clock().Observe(now, now, now.Add(-5*time.Minute))
+	_, err := skewed.GetBalance(ctx, "usdt")
+	var apiErr *xt.APIError
+	if !errors.As(err, &apiErr) || apiErr.Detail == nil || apiErr.Detail.Code != "AUTH_104" {
+		t.Errorf("skewed clock: got %v, want AUTH_104", err)
+	}
+
+	// The recv window, when sent, is part of the signed payload.
+	windowed := srv.Client()
+	windowed.SetSendRecvWindow(true)
+	if _, err := windowed.GetBalance(ctx, "usdt"); err != nil {
+		t.Errorf("with recv window: %v", err)
+	}
+}
+
+func TestMarketData(t *testing.T) {
+	_, client := newServer(t)
+	ctx := context.Background()
+
+	contracts, err := client.GetAllMarketConfigV3(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(contracts.Result.Symbols) != 1 || contracts.Result.Symbols[0].Symbol != symbol {
+		t.Fatalf("contracts = %+v", contracts.Result.Symbols)
+	}
+
+	book, err := xt.NewExchange(client).OrderBook(ctx, symbol, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(book.Bids) != 1 || book.Bids[0].Price != 100 || book.Asks[0].Price != 100.5 {
+		t.Errorf("book = %+v", book)
+	}
+}
+
+func TestOrderLifecycle(t *testing.T) {
+	srv, client := newServer(t)
+	ctx := context.Background()
+
+	placed, err := client.PlaceOrder(ctx, xt.PlaceOrderRequest{
+		ClientOrderID: ptr("t-entry"), Symbol: symbol, OrderSide: "BUY", OrderType: "LIMIT",
+		OrigQty: "10", Price: ptr("99"), PositionSide: "LONG",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	id, err := strconv.ParseInt(placed.Result, 10, 64)
+	if err != nil {
+		t.Fatalf("order id %q: %v", placed.Result, err)
+	}
+
+	open, err := client.GetOrderList(ctx, xt.GetOrderListRequest{Symbol: ptr(symbol), State: ptr("NEW")})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(open.Result.Items) != 1 || open.Result.Items[0].OrderID != id {
+		t.Fatalf("open orders = %+v", open.Result.Items)
+	}
+
+	if _, err := client.UpdateOrder(ctx, xt.UpdateOrderRequest{OrderID: id, Price: ptr("99.5")}); err != nil {
+		t.Fatal(err)
+	}
+
+	// The ask drops through the resting bid, which fills at its limit price as maker.
+	setBook(t, srv, 98.5, 99)
+	filled, err := client.GetOrder(ctx, id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if filled.Result.State != "FILLED" || filled.Result.AvgPrice.Cmp(decimal.MustParse("99.5")) != 0 {
+		t.Fatalf("filled order = %+v", filled.Result)
+	}
+
+	positions, err := client.GetPositions(ctx, ptr(symbol))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var long *xt.PositionDetail
+	for i, p := range positions.Result {
+		if p.PositionSide == "LONG" && p.PositionSize.Sign() != 0 {
+			long = &positions.Result[i]
+		}
+	}
+	if long == nil || long.PositionSize.Cmp(decimal.NewFromInt(10)) != 0 || long.EntryPrice.Cmp(decimal.MustParse("99.5")) != 0 {
+		t.Errorf("positions = %+v", positions.Result)
+	}
+
+	_, err = client.CancelOrder(ctx, id)
+	if !errors.Is(err, exchange.ErrOrderNotFound) {
+		t.Errorf("cancel of filled order: got %v, want ErrOrderNotFound", err)
+	}
+
+	// Close at market: sells 10 contracts into the 98.5 bid.
+	if _, err := client.PlaceOrder(ctx, xt.PlaceOrderRequest{
+		Symbol: symbol, OrderSide: "SELL", OrderType: "MARKET", OrigQty: "10", PositionSide: "LONG",
+	}); err != nil {
+		t.Fatal(err)
+	}
+	balance, err := client.GetBalance(ctx, "usdt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// PnL 10 * 0.01 * (98.5 - 99.5) = -0.1; fees 0.00199 maker + 0.004925 taker.
+	if want := decimal.MustParse("999.893085"); balance.Result.WalletBalance.Cmp(want) != 0 {
+		t.Errorf("wallet balance = %s, want %s", balance.Result.WalletBalance, want)
+	}
+}
+
+func TestOrderRejections(t *testing.T) {
+	_, client := newServer(t)
+	ctx := context.Background()
+
+	_, err := client.PlaceOrder(ctx, xt.PlaceOrderRequest{
+		Symbol: symbol, OrderSide: "BUY", OrderType: "LIMIT", OrigQty: "20000", Price: ptr("100.5"), PositionSide: "LONG",
+	})
+	if !errors.Is(err, exchange.ErrInsufficientBalance) {
+		t.Errorf("oversized order: got %v, want ErrInsufficientBalance", err)
+	}
+
+	_, err = client.GetOrder(ctx, 12345)
+	if !errors.Is(err, exchange.ErrOrderNotFound) {
+		t.Errorf("unknown order: got %v, want ErrOrderNotFound", err)
+	}
+
+	placed, err := client.PlaceOrder(ctx, xt.PlaceOrderRequest{
+		Symbol: symbol, OrderSide: "BUY", OrderType: "LIMIT", OrigQty: "1", Price: ptr("101"),
+		TimeInForce: ptr("GTX"), PositionSide: "LONG",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	id, _ := strconv.ParseInt(placed.Result, 10, 64)
+	order, err := client.GetOrder(ctx, id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if order.Result.State != "CANCELED" || order.Result.ExecutedQty.Sign() != 0 {
+		t.Errorf("crossing post-only order = %+v", order.Result)
+	}
+}
+
+func TestPlanOrder(t *testing.T) {
+	srv, client := newServer(t)
+	ctx := context.Background()
+
+	if _, err := client.PlaceOrder(ctx, xt.PlaceOrderRequest{
+		Symbol: symbol, OrderSide: "BUY", OrderType: "MARKET", OrigQty: "5", PositionSide: "LONG",
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.CreatePlanOrder(ctx, xt.CreatePlanOrderRequest{
+		Symbol: symbol, OrderSide: "SELL", EntrustType: "STOP_MARKET", OrigQty: "5", StopPrice: "95",
+		TimeInForce: "IOC", TriggerPriceType: "MARK_PRICE", PositionSide: "LONG",
+	}); err != nil {
+		t.Fatal(err)
+	}
+	pending, err := client.GetPlanOrderList(ctx, xt.GetPlanOrderListRequest{Symbol: symbol, State: "NOT_TRIGGERED"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(pending.Result.Items) != 1 {
+		t.Fatalf("pending plan orders = %+v", pending.Result.Items)
+	}
+
+	setBook(t, srv, 94, 94.5)
+	plan, err := client.GetPlanOrderDetail(ctx, pending.Result.Items[0].EntrustID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if plan.Result.State != "TRIGGERED" {
+		t.Fatalf("plan order = %+v", plan.Result)
+	}
+	positions, err := client.GetPositions(ctx, ptr(symbol))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, p := range positions.Result {
+		if p.PositionSize.Sign() != 0 {
+			t.Errorf("position after stop = %+v", p)
+		}
+	}
+}
+
+func TestFaultInjection(t *testing.T) {
+	srv, client := newServer(t)
+	ctx := context.Background()
+
+	srv.InjectFault(xttest.Fault{Method: http.MethodGet, Path: "/future/user/v1/balance/detail", Status: http.StatusServiceUnavailable, Times: 2})
+	before := len(srv.Requests())
+	if _, err := client.GetBalance(ctx, "usdt"); err != nil {
+		t.Fatalf("GetBalance after two 503s: %v", err)
+	}
+	if n := len(srv.Requests()) - before; n != 3 {
+		t.Errorf("requests = %d, want 3 (two retries)", n)
+	}
+
+	srv.InjectFault(xttest.Fault{Path: "/future/user/v1/position/list", Code: "TOO_MANY_REQUESTS", Message: "too many requests"})
+	client.SetRetryPolicy(nil)
+	_, err := client.GetPositions(ctx, ptr(symbol))
+	if !errors.Is(err, exchange.ErrRateLimit) {
+		t.Errorf("got %v, want ErrRateLimit", err)
+	}
+
+	// A 503 on order creation without a client order ID is not retried.
+	srv.ClearFaults()
+	client.SetRetryPolicy(retry.DefaultPolicy())
+	before = len(srv.Requests())
+	srv.InjectFault(xttest.Fault{Method: http.MethodPost, Path: "/future/trade/v1/order/create", Status: http.StatusServiceUnavailable, Times: 1})
+	if _, err := client.PlaceOrder(ctx, xt.PlaceOrderRequest{
+		Symbol: symbol, OrderSide: "BUY", OrderType: "LIMIT", OrigQty: "1", Price: ptr("99"), PositionSide: "LONG",
+	}); err == nil {
+		t.Error("order creation succeeded despite the 503")
+	}
+	if n := len(srv.Requests()) - before; n != 1 {
+		t.Errorf("order creation attempts = %d, want 1", n)
+	}
+}
+
+// countingProvider counts the key pair lookups of a client and fails them
+// with err if set.
+type countingProvider struct {
+	credentials.Provider
+	calls int
+	err   error
+}
+
+func (p *countingProvider) Credentials(ctx context.Context) (*credentials.Credentials, error) {
+	p.calls++
+	if p.err != nil {
+		return nil, p.err
+	}
+	return p.Provider.Credentials(ctx)
+}
+
+func TestLocalFailuresNotRetried(t *testing.T) {
+	srv, client := newServer(t)
+	ctx := context.Background()
+	provider := &countingProvider{Provider: credentials.NewStatic(xttest.APIKey, xttest.SecretKey)}
+	client.SetCredentials(provider)
+
+	if _, err := client.GetAccountInfo(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if provider.calls != 1 {
+		t.Errorf("successful request looked up the key pair %d times, want 1", provider.calls)
+	}
+
+	provider.calls, provider.err = 0, errors.New("vault unavailable")
+	_, err := client.GetAccountInfo(ctx)
+	if !errors.Is(err, provider.err) || !retry.IsPermanent(err) {
+		t.Errorf("provider failure: got %v, want it returned as permanent", err)
+	}
+	if provider.calls != 1 {
+		t.Errorf("provider failure retried: %d lookups, want 1", provider.calls)
+	}
+
+	provider.calls, provider.err = 0, nil
+	signErr := errors.New("signing service unavailable")
+	client.SetSigner(xt.SignerFunc(func(context.Context, string) (string, error) { return "", signErr }))
+	if _, err := client.GetAccountInfo(ctx); !errors.Is(err, signErr) {
+		t.Errorf("signer failure: got %v, want %v", err, signErr)
+	}
+	if provider.calls != 1 {
+		t.Errorf("signer failure retried: %d attempts, want 1", provider.calls)
+	}
+	if n := len(srv.Requests()); n != 1 {
+		t.Errorf("server saw %d requests, want only the successful one", n)
+	}
+}
@@ -0,0 +1,57 @@
+package xt
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+const defaultClockSyncInterval = time.Minute
+
+// SyncClock samples the server time once and updates the clock offset applied
+// to signed request timestamps.
+func (c *Client) SyncClock(ctx context.Context) error {
+	return c.clock.Sample(ctx, c.serverTime)
+}
+
+// RunClockSync samples the server time every interval (1 minute if
+// interval <= 0) until ctx is done, keeping the clock offset current.
+// Sampling errors are reported on Clock().Errors().
+func (c *Client) RunClockSync(ctx context.Context, interval time.Duration) error {
+	if interval <= 0 {
+		interval = defaultClockSyncInterval
+	}
+	return c.clock.Run(ctx, interval, c.serverTime)
+}
+
+// serverTime fetches the server time with a single direct request. Unlike
+// GetServerTime it bypasses the rate limiter and retry policy, whose waits
+// would otherwise be counted as network round trip.
+func (c *Client) serverTime(ctx context.Context) (time.Time, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.defaultBaseURL()+"/future/market/v1/public/time", nil)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to send request: %w", err)
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	var result ServerTimeResult
+	if err := json.Unmarshal(body, &result); err != nil {
+		return time.Time{}, fmt.Errorf("failed to unmarshal server time: %w (body: %s)", err, string(body))
+	}
+	if result.ReturnCode != 0 || result.Result == 0 {
+		return time.Time{}, fmt.Errorf("unexpected server time response: %s", string(body))
+	}
+	return time.UnixMilli(result.Result), nil
+}
@@ -0,0 +1,88 @@
+package xt
+
+import (
+	"bytes"
+	"encoding/json"
+	"strconv"
+	"strings"
+
+	"github.com/neqin/futures/exchange"
+)
+
+// newAPIError builds an APIError from a failed response, decoding the error
+// payload when it is an object.
+func newAPIError(resp *CommonResponse) *APIError {
+	apiErr := &APIError{
+		ReturnCode: resp.ReturnCode,
+		MsgInfo:    resp.MsgInfo,
+		Raw:        resp.Error,
+	}
+	if raw := bytes.TrimSpace(resp.Error); len(raw) > 0 && raw[0] == '{' {
+		var detail ErrorDetail
+		if err := json.Unmarshal(raw, &detail); err == nil {
+			apiErr.Detail = &detail
+		}
+	}
+	return apiErr
+}
+
+// errorCode returns the error code of a response body with a non-zero
+// returnCode (the returnCode itself if the payload carries no code), or "".
+func errorCode(body []byte) string {
+	var resp CommonResponse
+	if json.Unmarshal(body, &resp) != nil || resp.ReturnCode == 0 {
+		return ""
+	}
+	if apiErr := newAPIError(&resp); apiErr.Detail != nil && apiErr.Detail.Code != "" {
+		return apiErr.Detail.Code
+	}
+	return strconv.Itoa(resp.ReturnCode)
+}
+
+// errorClassRules map fragments of XT error codes (matched case-insensitively,
+// in order) to the shared error classes. XT reports codes such as "AUTH_105"
+// or "invalid_params"; matching on fragments also covers codes not listed in
+// the published table.
+var errorClassRules = []struct {
+	fragment string
+	class    error
+}{
+	{"auth_", exchange.ErrAuth},
+	{"signature", exchange.ErrAuth},
+	{"apikey", exchange.ErrAuth},
+	{"permission", exchange.ErrAuth},
+	{"too_many", exchange.ErrRateLimit},
+	{"rate_limit", exchange.ErrRateLimit},
+	{"frequent", exchange.ErrRateLimit},
+	{"insufficient", exchange.ErrInsufficientBalance},
+	{"balance_not_enough", exchange.ErrInsufficientBalance},
+	{"margin_not_enough", exchange.ErrInsufficientBalance},
+	{"order_not_exist", exchange.ErrOrderNotFound},
+	{"order_not_found", exchange.ErrOrderNotFound},
+	{"order_is_not_exist", exchange.ErrOrderNotFound},
+	{"invalid", exchange.ErrInvalidParameter},
+	{"param", exchange.ErrInvalidParameter},
+	{"symbol", exchange.ErrInvalidParameter},
+}
+
+// Class returns the shared error class of the error (e.g. exchange.ErrAuth),
+// or nil if the code is not recognised.
+func (e *APIError) Class() error {
+	if e.Detail == nil || e.Detail.Code == "" {
+		return nil
+	}
+	code := strings.ToLower(e.Detail.Code)
+	for _, rule := range errorClassRules {
+		if strings.Contains(code, rule.fragment) {
+			return rule.class
+		}
+	}
+	return nil
+}
+
+// Is reports whether the error belongs to the given shared error class, for
+// use with errors.Is.
+func (e *APIError) Is(target error) bool {
+	class := e.Class()
+	return class != nil && class == target
+}
@@ -0,0 +1,374 @@
+package xt
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/neqin/futures/exchange"
+)
+
+// Exchange adapts a Client to the venue-agnostic exchange.Exchange interface.
+type Exchange struct {
+	client *Client
+}
+
+var _ exchange.Exchange = (*Exchange)(nil)
+
+// NewExchange wraps client as an exchange.Exchange.
+func NewExchange(client *Client) *Exchange {
+	return &Exchange{client: client}
+}
+
+// Name returns the venue identifier.
+func (e *Exchange) Name() string {
+	return "xt"
+}
+
+// Client returns the underlying XT client.
+func (e *Exchange) Client() *Client {
+	return e.client
+}
+
+// --- exchange.MarketData ---
+
+// Ticker returns the latest ticker for a symbol, including best bid/ask, mark and index prices.
+func (e *Exchange) Ticker(ctx context.Context, symbol string) (*exchange.Ticker, error) {
+	res, err := e.client.GetAggTicker(ctx, xtSymbol(symbol))
+	if err != nil {
+		return nil, err
+	}
+	t := res.Result
+	return &exchange.Ticker{
+		Symbol:     symbol,
+		Last:       t.Close.Float64(),
+		Bid:        t.BidPrice.Float64(),
+		Ask:        t.AskPrice.Float64(),
+		MarkPrice:  t.MarkPrice.Float64(),
+		IndexPrice: t.IndexPrice.Float64(),
+		High24h:    t.High.Float64(),
+		Low24h:     t.Low.Float64(),
+		Volume24h:  t.Amount.Float64(),
+		Time:       time.UnixMilli(t.Timestamp),
+	}, nil
+}
+
+// OrderBook returns an order book snapshot with up to depth levels per side (XT allows 1-50).
+func (e *Exchange) OrderBook(ctx context.Context, symbol string, depth int) (*exchange.OrderBook, error) {
+	if depth <= 0 {
+		depth = 50
+	}
+	res, err := e.client.GetDepth(ctx, xtSymbol(symbol), depth)
+	if err != nil {
+		return nil, err
+	}
+	result := &exchange.OrderBook{
+		Symbol:   symbol,
+		Bids:     toLevels(res.Result.Bids),
+		Asks:     toLevels(res.Result.Asks),
+		UpdateID: res.Result.UpdateID,
+		Time:     time.UnixMilli(res.Result.Time),
+	}
+	return result, nil
+}
+
+// Trades returns up to limit recent public trades.
+func (e *Exchange) Trades(ctx context.Context, symbol string, limit int) ([]exchange.Trade, error) {
+	if limit <= 0 {
+		limit = 50
+	}
+	res, err := e.client.GetMarketDeal(ctx, xtSymbol(symbol), limit)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]exchange.Trade, 0, len(res.Result))
+	for _, t := range res.Result {
+		side := exchange.Buy
+		if m := strings.ToUpper(t.Maker); m == "ASK" || m == "SELL" {
+			side = exchange.Sell
+		}
+		result = append(result, exchange.Trade{
+			Symbol: symbol,
+			Price:  t.Price.Float64(),
+			Size:   t.Amount.Float64(),
+			Side:   side,
+			Time:   time.UnixMilli(t.Time),
+		})
+	}
+	return result, nil
+}
+
+// Candles returns up to limit k-lines for the given interval.
+func (e *Exchange) Candles(ctx context.Context, symbol, interval string, limit int) ([]exchange.Candle, error) {
+	var limitPtr *int
+	if limit > 0 {
+		limitPtr = &limit
+	}
+	res, err := e.client.GetKlines(ctx, xtSymbol(symbol), interval, nil, nil, limitPtr)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]exchange.Candle, 0, len(res.Result))
+	for _, k := range res.Result {
+		result = append(result, exchange.Candle{
+			Time:   time.UnixMilli(k.Time),
+			Open:   k.Open.Float64(),
+			High:   k.High.Float64(),
+			Low:    k.Low.Float64(),
+			Close:  k.Close.Float64(),
+			Volume: k.Amount.Float64(),
+		})
+	}
+	return result, nil
+}
+
+// --- exchange.Trading ---
+
+// PlaceOrder submits a new order. When req.PositionSide is empty it is derived
+// from the side and ReduceOnly flag: opening orders use the position on the same
+// side as the order, reduce-only orders close the opposite one.
+func (e *Exchange) PlaceOrder(ctx context.Context, req exchange.OrderRequest) (*exchange.Order, error) {
+	if req.Size <= 0 {
+		return nil, fmt.Errorf("order size must be positive, got %v", req.Size)
+	}
+	orderReq := PlaceOrderRequest{
+		Symbol:       xtSymbol(req.Symbol),
+		OrderSide:    strings.ToUpper(string(req.Side)),
+		OrderType:    strings.ToUpper(string(req.Type)),
+		OrigQty:      strconv.FormatFloat(req.Size, 'f', -1, 64),
+		PositionSide: xtPositionSide(req),
+	}
+	if req.Type != exchange.Market {
+		price := strconv.FormatFloat(req.Price, 'f', -1, 64)
+		orderReq.Price = &price
+		tif := toXTTif(req.TimeInForce)
+		orderReq.TimeInForce = &tif
+	}
+	if req.ClientOrderID != "" {
+		clientOrderID := req.ClientOrderID
+		orderReq.ClientOrderID = &clientOrderID
+	}
+
+	res, err := e.client.PlaceOrder(ctx, orderReq)
+	if err != nil {
+		return nil, err
+	}
+	return &exchange.Order{
+		ID:            res.Result,
+		ClientOrderID: req.ClientOrderID,
+		Symbol:        req.Symbol,
+		Side:          req.Side,
+		Type:          req.Type,
+		Price:         req.Price,
+		Size:          req.Size,
+		TimeInForce:   req.TimeInForce,
+		ReduceOnly:    req.ReduceOnly,
+		Status:        exchange.StatusOpen,
+		CreateTime:    time.Now(),
+	}, nil
+}
+
+// CancelOrder cancels a single order by ID.
+func (e *Exchange) CancelOrder(ctx context.Context, symbol, orderID string) error {
+	id, err := strconv.ParseInt(orderID, 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid XT order ID %q: %w", orderID, err)
+	}
+	_, err = e.client.CancelOrder(ctx, id)
+	return err
+}
+
+// CancelAllOrders cancels every open order on a symbol.
+func (e *Exchange) CancelAllOrders(ctx context.Context, symbol string) error {
+	s := xtSymbol(symbol)
+	_, err := e.client.CancelBatchOrder(ctx, &s)
+	return err
+}
+
+// GetOrder returns the current state of an order.
+func (e *Exchange) GetOrder(ctx context.Context, symbol, orderID string) (*exchange.Order, error) {
+	id, err := strconv.ParseInt(orderID, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid XT order ID %q: %w", orderID, err)
+	}
+	res, err := e.client.GetOrder(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return toExchangeOrder(symbol, &res.Result), nil
+}
+
+// OpenOrders lists unfinished orders on a symbol.
+func (e *Exchange) OpenOrders(ctx context.Context, symbol string) ([]exchange.Order, error) {
+	s := xtSymbol(symbol)
+	state := "UNFINISHED"
+	size := 100
+	res, err := e.client.GetOrderList(ctx, GetOrderListRequest{State: &state, Symbol: &s, Size: &size})
+	if err != nil {
+		return nil, err
+	}
+	result := make([]exchange.Order, 0, len(res.Result.Items))
+	for i := range res.Result.Items {
+		result = append(result, *toExchangeOrder(symbol, &res.Result.Items[i]))
+	}
+	return result, nil
+}
+
+// --- exchange.Account ---
+
+// Balances returns the balances of all futures wallet currencies.
+func (e *Exchange) Balances(ctx context.Context) ([]exchange.Balance, error) {
+	res, err := e.client.GetBalanceList(ctx)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]exchange.Balance, 0, len(res.Result))
+	for _, b := range res.Result {
+		result = append(result, exchange.Balance{
+			Currency:  strings.ToUpper(b.Coin),
+			Total:     b.WalletBalance.Float64(),
+			Available: b.AvailableBalance.Float64(),
+			Frozen:    b.IsolatedMargin.Float64() + b.CrossedMargin.Float64() + b.OpenOrderMarginFrozen.Float64(),
+		})
+	}
+	return result, nil
+}
+
+// Positions returns all non-empty positions.
+func (e *Exchange) Positions(ctx context.Context) ([]exchange.Position, error) {
+	res, err := e.client.GetPositions(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]exchange.Position, 0, len(res.Result))
+	for _, p := range res.Result {
+		size := p.PositionSize.Float64()
+		if size == 0 {
+			continue
+		}
+		side := exchange.Long
+		if strings.EqualFold(p.PositionSide, "SHORT") {
+			side = exchange.Short
+		}
+		result = append(result, exchange.Position{
+			Symbol:        strings.ToUpper(p.Symbol),
+			Side:          side,
+			Size:          size,
+			EntryPrice:    p.EntryPrice.Float64(),
+			MarkPrice:     p.CalMarkPrice.Float64(),
+			LiqPrice:      p.BreakPrice.Float64(),
+			UnrealizedPnL: p.FloatingPL.Float64(),
+			Margin:        p.IsolatedMargin.Float64(),
+			Leverage:      float64(p.Leverage),
+		})
+	}
+	return result, nil
+}
+
+// SetLeverage adjusts leverage on both the long and short position of a symbol.
+func (e *Exchange) SetLeverage(ctx context.Context, symbol string, leverage int) error {
+	s := xtSymbol(symbol)
+	for _, side := range []string{"LONG", "SHORT"} {
+		if _, err := e.client.AdjustLeverage(ctx, s, side, leverage); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// --- Conversion helpers ---
+
+// xtSymbol converts a canonical symbol to XT's lower-case form (e.g. "btc_usdt").
+func xtSymbol(symbol string) string {
+	return strings.ToLower(symbol)
+}
+
+func xtPositionSide(req exchange.OrderRequest) string {
+	if req.PositionSide != "" {
+		return strings.ToUpper(string(req.PositionSide))
+	}
+	opensLong := req.Side == exchange.Buy
+	if req.ReduceOnly {
+		opensLong = !opensLong
+	}
+	if opensLong {
+		return "LONG"
+	}
+	return "SHORT"
+}
+
+func toXTTif(tif exchange.TimeInForce) string {
+	switch tif {
+	case exchange.IOC:
+		return "IOC"
+	case exchange.FOK:
+		return "FOK"
+	case exchange.PostOnly:
+		return "GTX"
+	default:
+		return "GTC"
+	}
+}
+
+func fromXTTif(tif string) exchange.TimeInForce {
+	switch strings.ToUpper(tif) {
+	case "IOC":
+		return exchange.IOC
+	case "FOK":
+		return exchange.FOK
+	case "GTX":
+		return exchange.PostOnly
+	default:
+		return exchange.GTC
+	}
+}
+
+func fromXTState(state string) exchange.OrderStatus {
+	switch state {
+	case "PARTIALLY_FILLED":
+		return exchange.StatusPartiallyFilled
+	case "FILLED":
+		return exchange.StatusFilled
+	case "CANCELED", "PARTIALLY_CANCELED", "EXPIRED":
+		return exchange.StatusCanceled
+	case "REJECTED":
+		return exchange.StatusRejected
+	default:
+		return exchange.StatusOpen
+	}
+}
+
+func toExchangeOrder(symbol string, o *OrderDetail) *exchange.Order {
+	if symbol == "" {
+		symbol = strings.ToUpper(o.Symbol)
+	}
+	order := &exchange.Order{
+		ID:          strconv.FormatInt(o.OrderID, 10),
+		Symbol:      symbol,
+		Side:        exchange.Side(strings.ToLower(o.OrderSide)),
+		Type:        exchange.OrderType(strings.ToLower(o.OrderType)),
+		Price:       o.Price.Float64(),
+		Size:        o.OrigQty.Float64(),
+		FilledSize:  o.ExecutedQty.Float64(),
+		AvgPrice:    o.AvgPrice.Float64(),
+		TimeInForce: fromXTTif(o.TimeInForce),
+		Status:      fromXTState(o.State),
+		CreateTime:  time.UnixMilli(o.CreatedTime),
+	}
+	if o.ClientOrderID != nil {
+		order.ClientOrderID = *o.ClientOrderID
+	}
+	// A buy on the short leg (or sell on the long leg) reduces the position.
+	order.ReduceOnly = (o.OrderSide == "BUY") == strings.EqualFold(o.PositionSide, "SHORT")
+	return order
+}
+
+func toLevels(entries []DepthEntry) []exchange.OrderBookLevel {
+	levels := make([]exchange.OrderBookLevel, 0, len(entries))
+	for _, e := range entries {
+		levels = append(levels, exchange.OrderBookLevel{Price: e[0].Float64(), Size: e[1].Float64()})
+	}
+	return levels
+}
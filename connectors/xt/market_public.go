@@ -15,7 +15,7 @@ import (
 // Endpoint: GET /future/market/v1/public/time
 func (c *Client) GetServerTime(ctx context.Context) (*ServerTimeResult, error) {
 	path := "/future/market/v1/public/time"
-	baseURL := c.getBaseURL("USDT-M") // Assuming USDT-M for this generic endpoint
+	baseURL := c.defaultBaseURL()
 	var result ServerTimeResult
 	err := c.SendPublicRequest(ctx, http.MethodGet, baseURL, path, nil, &result) // Pass nil for params
 	if err != nil {
@@ -28,7 +28,7 @@ func (c *Client) GetServerTime(ctx context.Context) (*ServerTimeResult, error) {
 // Endpoint: GET /future/public/client
 func (c *Client) GetClientIP(ctx context.Context) (*ClientIPResult, error) {
 	path := "/future/public/client"
-	baseURL := c.getBaseURL("USDT-M") // Assuming USDT-M
+	baseURL := c.defaultBaseURL()
 	var result ClientIPResult
 	err := c.SendPublicRequest(ctx, http.MethodGet, baseURL, path, nil, &result) // Pass nil for params
 	if err != nil {
@@ -41,7 +41,7 @@ func (c *Client) GetClientIP(ctx context.Context) (*ClientIPResult, error) {
 // Endpoint: GET /future/market/v1/public/symbol/coins
 func (c *Client) GetCoinsInfo(ctx context.Context) (*CoinsInfoResult, error) {
 	path := "/future/market/v1/public/symbol/coins"
-	baseURL := c.getBaseURL("USDT-M") // Assuming USDT-M
+	baseURL := c.defaultBaseURL()
 	var result CoinsInfoResult
 	err := c.SendPublicRequest(ctx, http.MethodGet, baseURL, path, nil, &result) // Pass nil for params
 	if err != nil {
@@ -54,8 +54,8 @@ func (c *Client) GetCoinsInfo(ctx context.Context) (*CoinsInfoResult, error) {
 // Endpoint: GET /future/market/v1/public/symbol/detail
 func (c *Client) GetMarketConfig(ctx context.Context, symbol string) (*SingleContractResult, error) {
 	path := "/future/market/v1/public/symbol/detail"
-	baseURL := c.getBaseURL("USDT-M") // Assuming USDT-M
-	params := map[string]string{      // Changed to map[string]string
+	baseURL := c.symbolBaseURL(ctx, symbol)
+	params := map[string]string{ // Changed to map[string]string
 		"symbol": symbol,
 	}
 	var result SingleContractResult
@@ -66,16 +66,19 @@ func (c *Client) GetMarketConfig(ctx context.Context, symbol string) (*SingleCon
 	return &result, nil
 }
 
-// GetAllMarketConfigV3 fetches configuration details for all available USDT-M futures contracts (using v3 endpoint).
+// GetAllMarketConfigV3 fetches configuration details for all futures contracts of the client's
+// underlying type (USDT-M unless created with WithUnderlying), using the v3 endpoint.
+// The symbols are cached for request routing, see UnderlyingTypeOf.
 // Endpoint: GET /future/market/v3/public/symbol/list
 func (c *Client) GetAllMarketConfigV3(ctx context.Context) (*ContractsResult, error) {
 	path := "/future/market/v3/public/symbol/list" // Using v3 endpoint from docs
-	baseURL := c.getBaseURL("USDT-M")
+	baseURL := c.defaultBaseURL()
 	var result ContractsResult
 	err := c.SendPublicRequest(ctx, http.MethodGet, baseURL, path, nil, &result) // Pass nil for params
 	if err != nil {
 		return nil, fmt.Errorf("GetAllMarketConfigV3 failed: %w", err)
 	}
+	c.markets.store(result.Result.Symbols, c.underlying)
 	return &result, nil
 }
 
@@ -83,7 +86,7 @@ func (c *Client) GetAllMarketConfigV3(ctx context.Context) (*ContractsResult, er
 // Endpoint: GET /future/market/v1/public/leverage/bracket/detail
 func (c *Client) GetLeverageDetail(ctx context.Context, symbol string) (*LeverageDetailResult, error) {
 	path := "/future/market/v1/public/leverage/bracket/detail"
-	baseURL := c.getBaseURL("USDT-M")
+	baseURL := c.symbolBaseURL(ctx, symbol)
 	params := map[string]string{ // Changed to map[string]string
 		"symbol": symbol,
 	}
@@ -99,7 +102,7 @@ func (c *Client) GetLeverageDetail(ctx context.Context, symbol string) (*Leverag
 // Endpoint: GET /future/market/v1/public/leverage/bracket/list
 func (c *Client) GetLeverageDetailList(ctx context.Context) (*LeverageDetailListResult, error) {
 	path := "/future/market/v1/public/leverage/bracket/list"
-	baseURL := c.getBaseURL("USDT-M")
+	baseURL := c.defaultBaseURL()
 	var result LeverageDetailListResult
 	err := c.SendPublicRequest(ctx, http.MethodGet, baseURL, path, nil, &result) // Pass nil for params
 	if err != nil {
@@ -112,7 +115,7 @@ func (c *Client) GetLeverageDetailList(ctx context.Context) (*LeverageDetailList
 // Endpoint: GET /future/market/v1/public/q/ticker
 func (c *Client) GetMarketTicker(ctx context.Context, symbol string) (*SingleTickerResult, error) {
 	path := "/future/market/v1/public/q/ticker"
-	baseURL := c.getBaseURL("USDT-M")
+	baseURL := c.symbolBaseURL(ctx, symbol)
 	params := map[string]string{ // Changed to map[string]string
 		"symbol": symbol,
 	}
@@ -128,7 +131,7 @@ func (c *Client) GetMarketTicker(ctx context.Context, symbol string) (*SingleTic
 // Endpoint: GET /future/market/v1/public/q/tickers
 func (c *Client) GetMarketTickers(ctx context.Context) (*TickersResult, error) {
 	path := "/future/market/v1/public/q/tickers"
-	baseURL := c.getBaseURL("USDT-M")
+	baseURL := c.defaultBaseURL()
 	var result TickersResult
 	err := c.SendPublicRequest(ctx, http.MethodGet, baseURL, path, nil, &result) // Pass nil for params
 	if err != nil {
@@ -141,7 +144,7 @@ func (c *Client) GetMarketTickers(ctx context.Context) (*TickersResult, error) {
 // Endpoint: GET /future/market/v1/public/q/deal
 func (c *Client) GetMarketDeal(ctx context.Context, symbol string, num int) (*TradesResult, error) {
 	path := "/future/market/v1/public/q/deal"
-	baseURL := c.getBaseURL("USDT-M")
+	baseURL := c.symbolBaseURL(ctx, symbol)
 	params := map[string]string{ // Changed to map[string]string
 		"symbol": symbol,
 		"num":    strconv.Itoa(num),
@@ -158,7 +161,7 @@ func (c *Client) GetMarketDeal(ctx context.Context, symbol string, num int) (*Tr
 // Endpoint: GET /future/market/v1/public/q/depth
 func (c *Client) GetDepth(ctx context.Context, symbol string, level int) (*DepthResult, error) {
 	path := "/future/market/v1/public/q/depth"
-	baseURL := c.getBaseURL("USDT-M")
+	baseURL := c.symbolBaseURL(ctx, symbol)
 	params := map[string]string{ // Changed to map[string]string
 		"symbol": symbol,
 		"level":  strconv.Itoa(level),
@@ -175,7 +178,7 @@ func (c *Client) GetDepth(ctx context.Context, symbol string, level int) (*Depth
 // Endpoint: GET /future/market/v1/public/q/symbol-index-price
 func (c *Client) GetIndexPrice(ctx context.Context, symbol string) (*IndexPriceResult, error) {
 	path := "/future/market/v1/public/q/symbol-index-price"
-	baseURL := c.getBaseURL("USDT-M")
+	baseURL := c.symbolBaseURL(ctx, symbol)
 	params := map[string]string{ // Changed to map[string]string
 		"symbol": symbol,
 	}
@@ -191,7 +194,7 @@ func (c *Client) GetIndexPrice(ctx context.Context, symbol string) (*IndexPriceR
 // Endpoint: GET /future/market/v1/public/q/index-price
 func (c *Client) GetAllIndexPrice(ctx context.Context) (*AllIndexPriceResult, error) {
 	path := "/future/market/v1/public/q/index-price"
-	baseURL := c.getBaseURL("USDT-M")
+	baseURL := c.defaultBaseURL()
 	var result AllIndexPriceResult
 	err := c.SendPublicRequest(ctx, http.MethodGet, baseURL, path, nil, &result) // Pass nil for params
 	if err != nil {
@@ -204,7 +207,7 @@ func (c *Client) GetAllIndexPrice(ctx context.Context) (*AllIndexPriceResult, er
 // Endpoint: GET /future/market/v1/public/q/symbol-mark-price
 func (c *Client) GetMarketPrice(ctx context.Context, symbol string) (*SingleMarkPriceResult, error) {
 	path := "/future/market/v1/public/q/symbol-mark-price"
-	baseURL := c.getBaseURL("USDT-M")
+	baseURL := c.symbolBaseURL(ctx, symbol)
 	params := map[string]string{ // Changed to map[string]string
 		"symbol": symbol,
 	}
@@ -220,7 +223,7 @@ func (c *Client) GetMarketPrice(ctx context.Context, symbol string) (*SingleMark
 // Endpoint: GET /future/market/v1/public/q/mark-price
 func (c *Client) GetAllMarketPrice(ctx context.Context) (*MarkPriceResult, error) {
 	path := "/future/market/v1/public/q/mark-price"
-	baseURL := c.getBaseURL("USDT-M")
+	baseURL := c.defaultBaseURL()
 	var result MarkPriceResult
 	err := c.SendPublicRequest(ctx, http.MethodGet, baseURL, path, nil, &result) // Pass nil for params
 	if err != nil {
@@ -233,7 +236,7 @@ func (c *Client) GetAllMarketPrice(ctx context.Context) (*MarkPriceResult, error
 // Endpoint: GET /future/market/v1/public/q/kline
 func (c *Client) GetKlines(ctx context.Context, symbol, interval string, startTime, endTime *int64, limit *int) (*KlinesResult, error) {
 	path := "/future/market/v1/public/q/kline"
-	baseURL := c.getBaseURL("USDT-M")
+	baseURL := c.symbolBaseURL(ctx, symbol)
 	params := map[string]string{ // Changed to map[string]string
 		"symbol":   symbol,
 		"interval": interval,
@@ -259,7 +262,7 @@ func (c *Client) GetKlines(ctx context.Context, symbol, interval string, startTi
 // Endpoint: GET /future/market/v1/public/q/agg-ticker
 func (c *Client) GetAggTicker(ctx context.Context, symbol string) (*AggTickerResult, error) {
 	path := "/future/market/v1/public/q/agg-ticker"
-	baseURL := c.getBaseURL("USDT-M")
+	baseURL := c.symbolBaseURL(ctx, symbol)
 	params := map[string]string{ // Changed to map[string]string
 		"symbol": symbol,
 	}
@@ -275,7 +278,7 @@ func (c *Client) GetAggTicker(ctx context.Context, symbol string) (*AggTickerRes
 // Endpoint: GET /future/market/v1/public/q/agg-tickers
 func (c *Client) GetAllAggTicker(ctx context.Context) (*AllAggTickerResult, error) {
 	path := "/future/market/v1/public/q/agg-tickers"
-	baseURL := c.getBaseURL("USDT-M")
+	baseURL := c.defaultBaseURL()
 	var result AllAggTickerResult
 	err := c.SendPublicRequest(ctx, http.MethodGet, baseURL, path, nil, &result) // Pass nil for params
 	if err != nil {
@@ -288,7 +291,7 @@ func (c *Client) GetAllAggTicker(ctx context.Context) (*AllAggTickerResult, erro
 // Endpoint: GET /future/market/v1/public/q/funding-rate
 func (c *Client) GetFundRate(ctx context.Context, symbol string) (*FundingRateResult, error) {
 	path := "/future/market/v1/public/q/funding-rate"
-	baseURL := c.getBaseURL("USDT-M")
+	baseURL := c.symbolBaseURL(ctx, symbol)
 	params := map[string]string{ // Changed to map[string]string
 		"symbol": symbol,
 	}
@@ -304,7 +307,7 @@ func (c *Client) GetFundRate(ctx context.Context, symbol string) (*FundingRateRe
 // Endpoint: GET /future/market/v1/public/q/ticker/book
 func (c *Client) GetBookTicker(ctx context.Context, symbol string) (*BookTickerResult, error) {
 	path := "/future/market/v1/public/q/ticker/book"
-	baseURL := c.getBaseURL("USDT-M")
+	baseURL := c.symbolBaseURL(ctx, symbol)
 	params := map[string]string{ // Changed to map[string]string
 		"symbol": symbol,
 	}
@@ -320,7 +323,7 @@ func (c *Client) GetBookTicker(ctx context.Context, symbol string) (*BookTickerR
 // Endpoint: GET /future/market/v1/public/q/funding-rate-record
 func (c *Client) GetFundRateRecord(ctx context.Context, symbol string, direction *string, id *int64, limit *int) (*FundRateRecordResult, error) {
 	path := "/future/market/v1/public/q/funding-rate-record"
-	baseURL := c.getBaseURL("USDT-M")
+	baseURL := c.symbolBaseURL(ctx, symbol)
 	params := map[string]string{ // Changed to map[string]string
 		"symbol": symbol, // Required
 	}
@@ -345,7 +348,7 @@ func (c *Client) GetFundRateRecord(ctx context.Context, symbol string, direction
 // Endpoint: GET /future/market/v1/public/q/ticker/books
 func (c *Client) GetAllBookTickers(ctx context.Context) (*AllBookTickerResult, error) {
 	path := "/future/market/v1/public/q/ticker/books"
-	baseURL := c.getBaseURL("USDT-M")
+	baseURL := c.defaultBaseURL()
 	var result AllBookTickerResult
 	err := c.SendPublicRequest(ctx, http.MethodGet, baseURL, path, nil, &result) // Pass nil for params
 	if err != nil {
@@ -358,7 +361,7 @@ func (c *Client) GetAllBookTickers(ctx context.Context) (*AllBookTickerResult, e
 // Endpoint: GET /future/market/v1/public/contract/risk-balance
 func (c *Client) GetRiskBalance(ctx context.Context, symbol string, direction *string, id *int64, limit *int) (*RiskBalanceResult, error) {
 	path := "/future/market/v1/public/contract/risk-balance"
-	baseURL := c.getBaseURL("USDT-M")
+	baseURL := c.symbolBaseURL(ctx, symbol)
 	params := map[string]string{ // Changed to map[string]string
 		"symbol": symbol, // Required
 	}
@@ -383,7 +386,7 @@ func (c *Client) GetRiskBalance(ctx context.Context, symbol string, direction *s
 // Endpoint: GET /future/market/v1/public/contract/open-interest
 func (c *Client) GetOpenInterest(ctx context.Context, symbol string) (*OpenInterestResult, error) {
 	path := "/future/market/v1/public/contract/open-interest"
-	baseURL := c.getBaseURL("USDT-M")
+	baseURL := c.symbolBaseURL(ctx, symbol)
 	params := map[string]string{ // Changed to map[string]string
 		"symbol": symbol,
 	}
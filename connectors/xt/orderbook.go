@@ -0,0 +1,78 @@
+package xt
+
+import (
+	"context"
+	"time"
+
+	"github.com/neqin/futures/orderbook"
+)
+
+// OrderBookSource feeds an orderbook.Book for one symbol from the GetDepth
+// snapshot and the depth_update market topic.
+type OrderBookSource struct {
+	client   *Client
+	ws       *WSClient
+	symbol   string
+	interval string
+	level    int
+}
+
+// NewOrderBookSource creates an order book source for symbol (e.g., "btc_usdt").
+// ws must be a market client (NewMarketWSClient). The source snapshots 50
+// levels and subscribes to 100ms diffs; use SetDepth to change this.
+func NewOrderBookSource(client *Client, ws *WSClient, symbol string) *OrderBookSource {
+	return &OrderBookSource{
+		client:   client,
+		ws:       ws,
+		symbol:   symbol,
+		interval: "100ms",
+		level:    50,
+	}
+}
+
+// SetDepth sets the diff push interval ("100ms" or "1000ms") and the number
+// of snapshot levels per side (1-50).
+func (s *OrderBookSource) SetDepth(interval string, level int) {
+	s.interval = interval
+	s.level = level
+}
+
+// Snapshot fetches the order book with its update ID.
+func (s *OrderBookSource) Snapshot(ctx context.Context) (*orderbook.Snapshot, error) {
+	res, err := s.client.GetDepth(ctx, s.symbol, s.level)
+	if err != nil {
+		return nil, err
+	}
+	return &orderbook.Snapshot{
+		UpdateID: res.Result.UpdateID,
+		Bids:     toLevels(res.Result.Bids),
+		Asks:     toLevels(res.Result.Asks),
+	}, nil
+}
+
+// Diffs subscribes to depth_update for the symbol.
+func (s *OrderBookSource) Diffs(ctx context.Context) (<-chan orderbook.Diff, error) {
+	updates, err := s.ws.SubscribeDepthUpdate(ctx, s.symbol, s.interval)
+	if err != nil {
+		return nil, err
+	}
+	diffs := make(chan orderbook.Diff, wsSubscriptionBuffer)
+	go func() {
+		defer close(diffs)
+		for u := range updates {
+			d := orderbook.Diff{
+				FirstID: u.FirstUpdateID,
+				LastID:  u.UpdateID,
+				Bids:    toLevels(u.Bids),
+				Asks:    toLevels(u.Asks),
+				Time:    time.UnixMilli(u.Time),
+			}
+			select {
+			case diffs <- d:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return diffs, nil
+}
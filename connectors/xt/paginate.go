@@ -0,0 +1,94 @@
+package xt
+
+import (
+	"context"
+	"fmt"
+	"iter"
+	"strconv"
+
+	"github.com/neqin/futures/paginate"
+)
+
+// pageLimit is the page size requested by the iterators (the API maximum).
+const pageLimit = 100
+
+// directionNext pages from the anchor ID towards older records.
+const directionNext = "NEXT"
+
+// IterBalanceBills walks the balance bills of symbol, newest first, following
+// the ID of the last bill of each page until hasNext is false. Each page is a
+// rate-limited request; iteration stops at the first error (which is yielded)
+// or when ctx is done.
+func (c *Client) IterBalanceBills(ctx context.Context, symbol string, startTime, endTime *int64) iter.Seq2[BalanceBillDetail, error] {
+	return func(yield func(BalanceBillDetail, error) bool) {
+		var id *int64
+		direction := directionNext
+		limit := pageLimit
+		paginate.Walk(ctx, yield, func(b BalanceBillDetail) int64 { return b.ID }, func() ([]BalanceBillDetail, bool, error) {
+			res, err := c.GetBalanceBills(ctx, symbol, &direction, id, &limit, startTime, endTime)
+			if err != nil {
+				return nil, false, err
+			}
+			items := res.Result.Items
+			if len(items) == 0 {
+				return nil, true, nil
+			}
+			last := items[len(items)-1].ID
+			id = &last
+			return items, !res.Result.HasNext, nil
+		})
+	}
+}
+
+// IterFundRateRecord walks the funding rate history of symbol, newest first,
+// following the ID of the last record of each page until hasNext is false.
+func (c *Client) IterFundRateRecord(ctx context.Context, symbol string) iter.Seq2[FundingRateDetail, error] {
+	return func(yield func(FundingRateDetail, error) bool) {
+		var id *int64
+		direction := directionNext
+		limit := pageLimit
+		paginate.Walk(ctx, yield, func(r FundingRateDetail) string { return deref(r.ID) }, func() ([]FundingRateDetail, bool, error) {
+			res, err := c.GetFundRateRecord(ctx, symbol, &direction, id, &limit)
+			if err != nil {
+				return nil, false, err
+			}
+			items := res.Result.Items
+			if len(items) == 0 {
+				return nil, true, nil
+			}
+			last, err := strconv.ParseInt(deref(items[len(items)-1].ID), 10, 64)
+			if err != nil {
+				return nil, false, fmt.Errorf("IterFundRateRecord for %s: invalid record id: %w", symbol, err)
+			}
+			id = &last
+			return items, !res.Result.HasNext, nil
+		})
+	}
+}
+
+// IterHistoryList walks the order history described by queryReq, newest
+// first, following the ID of the last order of each page until hasNext is
+// false. queryReq.Direction, ID and Limit are managed by the iterator.
+func (c *Client) IterHistoryList(ctx context.Context, queryReq GetHistoryListRequest) iter.Seq2[OrderDetail, error] {
+	return func(yield func(OrderDetail, error) bool) {
+		req := queryReq
+		direction := directionNext
+		limit := pageLimit
+		req.Direction = &direction
+		req.Limit = &limit
+		req.ID = nil
+		paginate.Walk(ctx, yield, func(o OrderDetail) int64 { return o.OrderID }, func() ([]OrderDetail, bool, error) {
+			res, err := c.GetHistoryList(ctx, req)
+			if err != nil {
+				return nil, false, err
+			}
+			items := res.Result.Items
+			if len(items) == 0 {
+				return nil, true, nil
+			}
+			last := items[len(items)-1].OrderID
+			req.ID = &last
+			return items, !res.Result.HasNext, nil
+		})
+	}
+}
@@ -0,0 +1,147 @@
+package xt_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/neqin/futures/connectors/xt"
+	"github.com/neqin/futures/retry"
+)
+
+// newHistoryServer serves records with IDs total..1, newest first, on the
+// bills, funding rate record and order history endpoints. A page starts at
+// the anchor ID, which is included as XT does, and hasNext is reported until
+// the oldest record. Page failNumber (1-based) fails with a 400 if positive.
+func newHistoryServer(t *testing.T, total int64, failNumber int) (*xt.Client, *int) {
+	t.Helper()
+	pages := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		pages++
+		if pages == failNumber {
+			http.Error(w, `{"returnCode":1,"msgInfo":"failure","error":{"code":"invalid_params","msg":"bad page"}}`, http.StatusBadRequest)
+			return
+		}
+		q := r.URL.Query()
+		limit, _ := strconv.ParseInt(q.Get("limit"), 10, 64)
+		from := total
+		if q.Has("id") {
+			from, _ = strconv.ParseInt(q.Get("id"), 10, 64)
+		}
+		items := []map[string]any{}
+		for id := from; id > 0 && int64(len(items)) < limit; id-- {
+			switch r.URL.Path {
+			case "/future/user/v1/balance/bills":
+				items = append(items, map[string]any{"id": id})
+			case "/future/market/v1/public/q/funding-rate-record":
+				items = append(items, map[string]any{"id": strconv.FormatInt(id, 10)})
+			case "/future/trade/v1/order/list-history":
+				items = append(items, map[string]any{"orderId": id})
+			default:
+				t.Errorf("unexpected request %s", r.URL.Path)
+			}
+		}
+		hasNext := from-int64(len(items)) > 0
+		json.NewEncoder(w).Encode(map[string]any{"returnCode": 0, "result": map[string]any{"hasNext": hasNext, "items": items}})
+	}))
+	t.Cleanup(srv.Close)
+	client := xt.NewClient("key", "secret", nil)
+	client.SetUsdtBaseURL(srv.URL)
+	client.SetUnderlyingType(symbol, xt.USDTMargined)
+	client.SetRetryPolicy(retry.Never)
+	client.SetLogger(nil)
+	return client, &pages
+}
+
+func TestIterators(t *testing.T) {
+	iterators := []struct {
+		name string
+		ids  func(context.Context, *xt.Client) func(func(int64, error) bool)
+	}{
+		{"IterBalanceBills", func(ctx context.Context, c *xt.Client) func(func(int64, error) bool) {
+			return func(yield func(int64, error) bool) {
+				for b, err := range c.IterBalanceBills(ctx, symbol, nil, nil) {
+					if !yield(b.ID, err) {
+						return
+					}
+				}
+			}
+		}},
+		{"IterFundRateRecord", func(ctx context.Context, c *xt.Client) func(func(int64, error) bool) {
+			return func(yield func(int64, error) bool) {
+				for r, err := range c.IterFundRateRecord(ctx, symbol) {
+					var id int64
+					if r.ID != nil {
+						id, _ = strconv.ParseInt(*r.ID, 10, 64)
+					}
+					if !yield(id, err) {
+						return
+					}
+				}
+			}
+		}},
+		{"IterHistoryList", func(ctx context.Context, c *xt.Client) func(func(int64, error) bool) {
+			return func(yield func(int64, error) bool) {
+				for o, err := range c.IterHistoryList(ctx, xt.GetHistoryListRequest{Symbol: symbol}) {
+					if !yield(o.OrderID, err) {
+						return
+					}
+				}
+			}
+		}},
+	}
+	for _, it := range iterators {
+		// 250 records: pages of 100, 100 and 52 with the anchors repeated.
+		client, pages := newHistoryServer(t, 250, 0)
+		want := int64(250)
+		for id, err := range it.ids(context.Background(), client) {
+			if err != nil {
+				t.Fatalf("%s: %v", it.name, err)
+			}
+			if id != want {
+				t.Fatalf("%s: record %d, want %d", it.name, id, want)
+			}
+			want--
+		}
+		if want != 0 || *pages != 3 {
+			t.Errorf("%s: stopped before record %d after %d pages, want all records in 3 pages", it.name, want, *pages)
+		}
+
+		// A failed page is yielded and ends the iteration.
+		client, pages = newHistoryServer(t, 250, 2)
+		var records, failures int
+		for _, err := range it.ids(context.Background(), client) {
+			if err != nil {
+				failures++
+				continue
+			}
+			records++
+		}
+		if records != 100 || failures != 1 || *pages != 2 {
+			t.Errorf("%s with a failed second page: %d records, %d errors, %d pages", it.name, records, failures, *pages)
+		}
+
+		// Cancelling the context mid-walk ends it with the context error.
+		client, pages = newHistoryServer(t, 250, 0)
+		ctx, cancel := context.WithCancel(context.Background())
+		var last error
+		records = 0
+		for _, err := range it.ids(ctx, client) {
+			if err != nil {
+				last = err
+				continue
+			}
+			if records++; records == 150 {
+				cancel()
+			}
+		}
+		cancel()
+		if records != 199 || !errors.Is(last, context.Canceled) || *pages != 2 {
+			t.Errorf("%s cancelled: %d records, error %v after %d pages; want 199 records and context.Canceled after 2 pages", it.name, records, last, *pages)
+		}
+	}
+}
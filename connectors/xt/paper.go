@@ -0,0 +1,554 @@
+package xt
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/neqin/futures/decimal"
+	"github.com/neqin/futures/exchange"
+	"github.com/neqin/futures/paper"
+	"github.com/neqin/futures/symbols"
+)
+
+// FuturesTrader is the set of Client methods for orders, positions, leverage,
+// margin and trigger (plan) orders that PaperClient simulates. Strategies
+// written against it can be run on a simulated account without changes.
+type FuturesTrader interface {
+	PlaceOrder(ctx context.Context, orderReq PlaceOrderRequest) (*PlaceOrderResult, error)
+	CancelOrder(ctx context.Context, orderID int64) (*CancelOrderResult, error)
+	CancelBatchOrder(ctx context.Context, symbol *string) (*CancelBatchOrderResult, error)
+	GetOrder(ctx context.Context, orderID int64) (*GetOrderResult, error)
+	GetOrderList(ctx context.Context, queryReq GetOrderListRequest) (*GetOrderListResult, error)
+	UpdateOrder(ctx context.Context, updateReq UpdateOrderRequest) (*UpdateOrderResult, error)
+
+	GetBalance(ctx context.Context, coin string) (*GetBalanceResult, error)
+	GetPositions(ctx context.Context, symbol *string) (*GetPositionsResult, error)
+	AdjustLeverage(ctx context.Context, symbol, positionSide string, leverage int) (*AdjustLeverageResult, error)
+	UpdatePositionMargin(ctx context.Context, symbol, margin, marginType string, positionSide *string) (*UpdatePositionMarginResult, error)
+
+	CreatePlanOrder(ctx context.Context, orderReq CreatePlanOrderRequest) (*CreatePlanOrderResult, error)
+	CancelPlanOrder(ctx context.Context, entrustID int64) (*CancelPlanOrderResult, error)
+	CancelAllPlanOrder(ctx context.Context, symbol string) (*CancelAllPlanOrderResult, error)
+	GetPlanOrderList(ctx context.Context, queryReq GetPlanOrderListRequest) (*GetPlanOrderListResult, error)
+	GetPlanOrderDetail(ctx context.Context, entrustID int64) (*GetPlanOrderDetailResult, error)
+}
+
+var (
+	_ FuturesTrader = (*Client)(nil)
+	_ FuturesTrader = (*PaperClient)(nil)
+)
+
+// PaperClient implements FuturesTrader on a simulated paper.Engine account in
+// hedge mode: BUY LONG and SELL SHORT open a leg, SELL LONG and BUY SHORT
+// close it. TP/SL prices on PlaceOrder become triggers that close the leg at
+// market. Plan orders trigger on the book's mid price whatever the
+// triggerPriceType: STOP orders when the price moves against the order side
+// (BUY at or above, SELL at or below the stop price), TAKE_PROFIT orders when
+// it moves in its favour.
+type PaperClient struct {
+	engine *paper.Engine
+}
+
+// NewPaperClient creates a client trading on engine, e.g.
+//
+//	engine := paper.NewEngine(paper.Config{Currency: "USDT", Balance: decimal.NewFromInt(10000)},
+//		paper.PolledBooks(xt.NewExchange(client), 50))
+//	err := engine.LoadContracts(ctx, xt.NewSymbolLoader(client))
+//	trader := xt.NewPaperClient(engine)
+func NewPaperClient(engine *paper.Engine) *PaperClient {
+	return &PaperClient{engine: engine}
+}
+
+// Engine returns the simulated account.
+func (c *PaperClient) Engine() *paper.Engine {
+	return c.engine
+}
+
+var paperOK = CommonResponse{ReturnCode: 0, MsgInfo: "success"}
+
+// --- Orders ---
+
+// PlaceOrder places a simulated order.
+func (c *PaperClient) PlaceOrder(ctx context.Context, orderReq PlaceOrderRequest) (*PlaceOrderResult, error) {
+	req, err := paperOrderRequest(orderReq.Symbol, orderReq.OrderSide, orderReq.OrderType, orderReq.OrigQty, orderReq.Price, deref(orderReq.TimeInForce), orderReq.PositionSide)
+	if err != nil {
+		return nil, fmt.Errorf("PlaceOrder for %s failed: %w", orderReq.Symbol, err)
+	}
+	req.ClientOrderID = deref(orderReq.ClientOrderID)
+	o, err := c.engine.PlaceOrder(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("PlaceOrder for %s failed: %w", orderReq.Symbol, err)
+	}
+	if err := c.attachProfitStop(ctx, req, orderReq.TriggerProfitPrice, orderReq.TriggerStopPrice); err != nil {
+		return nil, fmt.Errorf("PlaceOrder for %s failed: %w", orderReq.Symbol, err)
+	}
+	return &PlaceOrderResult{CommonResponse: paperOK, Result: strconv.FormatInt(o.ID, 10)}, nil
+}
+
+// CancelOrder cancels a simulated order.
+func (c *PaperClient) CancelOrder(ctx context.Context, orderID int64) (*CancelOrderResult, error) {
+	o, err := c.engine.CancelOrder(ctx, orderID)
+	if err != nil {
+		return nil, fmt.Errorf("CancelOrder for ID %d failed: %w", orderID, err)
+	}
+	return &CancelOrderResult{CommonResponse: paperOK, Result: strconv.FormatInt(o.ID, 10)}, nil
+}
+
+// CancelBatchOrder cancels the open simulated orders of symbol, or of all
+// symbols if symbol is nil or empty.
+func (c *PaperClient) CancelBatchOrder(ctx context.Context, symbol *string) (*CancelBatchOrderResult, error) {
+	if _, err := c.engine.CancelOrders(ctx, paperSymbol(deref(symbol)), ""); err != nil {
+		return nil, fmt.Errorf("CancelBatchOrder failed: %w", err)
+	}
+	return &CancelBatchOrderResult{CommonResponse: paperOK, Result: true}, nil
+}
+
+// GetOrder returns a simulated order.
+func (c *PaperClient) GetOrder(ctx context.Context, orderID int64) (*GetOrderResult, error) {
+	o, err := c.engine.Order(orderID)
+	if err != nil {
+		return nil, fmt.Errorf("GetOrder for ID %d failed: %w", orderID, err)
+	}
+	return &GetOrderResult{CommonResponse: paperOK, Result: orderDetail(o)}, nil
+}
+
+// GetOrderList lists simulated orders, newest first. State UNFINISHED
+// selects open orders and HISTORY finished ones; other states match exactly.
+func (c *PaperClient) GetOrderList(ctx context.Context, queryReq GetOrderListRequest) (*GetOrderListResult, error) {
+	state := deref(queryReq.State)
+	var orders []paper.Order
+	symbol := paperSymbol(deref(queryReq.Symbol))
+	if state != "HISTORY" {
+		orders = append(orders, c.engine.Orders(symbol, true)...)
+	}
+	if state != "UNFINISHED" && state != "NEW" && state != "PARTIALLY_FILLED" {
+		orders = append(orders, c.engine.Orders(symbol, false)...)
+	}
+	var items []OrderDetail
+	for i := range orders {
+		d := orderDetail(&orders[i])
+		switch {
+		case state != "" && state != "UNFINISHED" && state != "HISTORY" && d.State != state,
+			queryReq.ClientOrderID != nil && deref(d.ClientOrderID) != *queryReq.ClientOrderID,
+			queryReq.StartTime != nil && d.CreatedTime < *queryReq.StartTime,
+			queryReq.EndTime != nil && d.CreatedTime > *queryReq.EndTime:
+			continue
+		}
+		items = append(items, d)
+	}
+	result := &GetOrderListResult{CommonResponse: paperOK}
+	result.Result.Page, result.Result.Ps, result.Result.Total = 1, 10, len(items)
+	if queryReq.Page != nil && *queryReq.Page > 0 {
+		result.Result.Page = *queryReq.Page
+	}
+	if queryReq.Size != nil && *queryReq.Size > 0 {
+		result.Result.Ps = *queryReq.Size
+	}
+	result.Result.Items = pageItems(items, result.Result.Page, result.Result.Ps)
+	return result, nil
+}
+
+// UpdateOrder changes the price and/or quantity of an open simulated order.
+// Changing TP/SL settings is not simulated.
+func (c *PaperClient) UpdateOrder(ctx context.Context, updateReq UpdateOrderRequest) (*UpdateOrderResult, error) {
+	if updateReq.TriggerProfitPrice != nil || updateReq.TriggerStopPrice != nil {
+		return nil, fmt.Errorf("UpdateOrder for ID %d failed: TP/SL updates are not simulated: %w", updateReq.OrderID, exchange.ErrInvalidParameter)
+	}
+	var size, price *decimal.Decimal
+	if updateReq.OrigQty != nil {
+		s, err := decimal.Parse(*updateReq.OrigQty)
+		if err != nil {
+			return nil, fmt.Errorf("UpdateOrder for ID %d failed: invalid origQty %q: %w", updateReq.OrderID, *updateReq.OrigQty, exchange.ErrInvalidParameter)
+		}
+		size = &s
+	}
+	if updateReq.Price != nil {
+		p, err := decimal.Parse(*updateReq.Price)
+		if err != nil {
+			return nil, fmt.Errorf("UpdateOrder for ID %d failed: invalid price %q: %w", updateReq.OrderID, *updateReq.Price, exchange.ErrInvalidParameter)
+		}
+		price = &p
+	}
+	if _, err := c.engine.AmendOrder(ctx, updateReq.OrderID, size, price); err != nil {
+		return nil, fmt.Errorf("UpdateOrder for ID %d failed: %w", updateReq.OrderID, err)
+	}
+	return &UpdateOrderResult{CommonResponse: paperOK, Result: map[string]interface{}{}}, nil
+}
+
+// --- Account and positions ---
+
+// GetBalance returns the simulated wallet; coin must match the engine's currency.
+func (c *PaperClient) GetBalance(ctx context.Context, coin string) (*GetBalanceResult, error) {
+	a := c.engine.Account()
+	if !strings.EqualFold(coin, a.Currency) {
+		return nil, fmt.Errorf("GetBalance for %s failed: not the simulated %s account: %w", coin, a.Currency, exchange.ErrInvalidParameter)
+	}
+	return &GetBalanceResult{CommonResponse: paperOK, Result: BalanceDetail{
+		Coin:                  strings.ToLower(a.Currency),
+		AvailableBalance:      a.Available,
+		IsolatedMargin:        a.PositionMargin,
+		OpenOrderMarginFrozen: a.OrderMargin,
+		WalletBalance:         a.Balance,
+	}}, nil
+}
+
+// GetPositions returns the open simulated position legs of symbol, or of all
+// symbols if symbol is nil or empty.
+func (c *PaperClient) GetPositions(ctx context.Context, symbol *string) (*GetPositionsResult, error) {
+	want := paperSymbol(deref(symbol))
+	result := &GetPositionsResult{CommonResponse: paperOK, Result: []PositionDetail{}}
+	for _, p := range c.engine.Positions() {
+		if p.Side != "" && (want == "" || p.Symbol == want) {
+			result.Result = append(result.Result, c.positionDetail(p))
+		}
+	}
+	return result, nil
+}
+
+// AdjustLeverage sets the leverage of a simulated position leg.
+func (c *PaperClient) AdjustLeverage(ctx context.Context, symbol, positionSide string, leverage int) (*AdjustLeverageResult, error) {
+	side, err := paperPositionSide(positionSide)
+	if err == nil {
+		_, err = c.engine.SetLeverage(paperSymbol(symbol), side, leverage)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("AdjustLeverage for %s (%s) failed: %w", symbol, positionSide, err)
+	}
+	return &AdjustLeverageResult{CommonResponse: paperOK, Result: map[string]interface{}{}}, nil
+}
+
+// UpdatePositionMargin adds (ADD) or removes (SUB) isolated margin of a
+// simulated position leg.
+func (c *PaperClient) UpdatePositionMargin(ctx context.Context, symbol, margin, marginType string, positionSide *string) (*UpdatePositionMarginResult, error) {
+	amount, err := decimal.Parse(margin)
+	switch {
+	case err != nil:
+		err = fmt.Errorf("invalid margin %q: %w", margin, exchange.ErrInvalidParameter)
+	case marginType == "SUB":
+		amount = amount.Neg()
+	case marginType != "ADD":
+		err = fmt.Errorf("invalid marginType: must be ADD or SUB: %w", exchange.ErrInvalidParameter)
+	}
+	var side exchange.PositionSide
+	if err == nil {
+		side, err = paperPositionSide(deref(positionSide))
+	}
+	if err == nil {
+		_, err = c.engine.AddMargin(paperSymbol(symbol), side, amount)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("UpdatePositionMargin for %s failed: %w", symbol, err)
+	}
+	return &UpdatePositionMarginResult{CommonResponse: paperOK, Result: map[string]interface{}{}}, nil
+}
+
+// --- Plan orders ---
+
+// CreatePlanOrder registers a simulated trigger order.
+func (c *PaperClient) CreatePlanOrder(ctx context.Context, orderReq CreatePlanOrderRequest) (*CreatePlanOrderResult, error) {
+	orderType := "LIMIT"
+	if strings.HasSuffix(orderReq.EntrustType, "_MARKET") {
+		orderType = "MARKET"
+	}
+	req, err := paperOrderRequest(orderReq.Symbol, orderReq.OrderSide, orderType, orderReq.OrigQty, orderReq.Price, orderReq.TimeInForce, orderReq.PositionSide)
+	if err != nil {
+		return nil, fmt.Errorf("CreatePlanOrder for %s failed: %w", orderReq.Symbol, err)
+	}
+	req.ClientOrderID = deref(orderReq.ClientOrderID)
+	stop, err := decimal.Parse(orderReq.StopPrice)
+	if err != nil {
+		return nil, fmt.Errorf("CreatePlanOrder for %s failed: invalid stopPrice %q: %w", orderReq.Symbol, orderReq.StopPrice, exchange.ErrInvalidParameter)
+	}
+	// STOP fires when the price moves against the order side, TAKE_PROFIT when it moves in its favour.
+	rule := paper.PriceBelow
+	if (req.Side == exchange.Buy) == strings.HasPrefix(orderReq.EntrustType, "STOP") {
+		rule = paper.PriceAbove
+	}
+	if _, err := c.engine.PlaceTrigger(ctx, paper.TriggerRequest{Order: req, Price: stop, Rule: rule}); err != nil {
+		return nil, fmt.Errorf("CreatePlanOrder for %s failed: %w", orderReq.Symbol, err)
+	}
+	return &CreatePlanOrderResult{CommonResponse: paperOK, Result: map[string]interface{}{}}, nil
+}
+
+// CancelPlanOrder cancels a simulated trigger order.
+func (c *PaperClient) CancelPlanOrder(ctx context.Context, entrustID int64) (*CancelPlanOrderResult, error) {
+	if _, err := c.engine.CancelTrigger(ctx, entrustID); err != nil {
+		return nil, fmt.Errorf("CancelPlanOrder for ID %d failed: %w", entrustID, err)
+	}
+	return &CancelPlanOrderResult{CommonResponse: paperOK, Result: map[string]interface{}{}}, nil
+}
+
+// CancelAllPlanOrder cancels the open simulated trigger orders of symbol.
+func (c *PaperClient) CancelAllPlanOrder(ctx context.Context, symbol string) (*CancelAllPlanOrderResult, error) {
+	if _, err := c.engine.CancelTriggers(ctx, paperSymbol(symbol)); err != nil {
+		return nil, fmt.Errorf("CancelAllPlanOrder for %s failed: %w", symbol, err)
+	}
+	return &CancelAllPlanOrderResult{CommonResponse: paperOK, Result: true}, nil
+}
+
+// GetPlanOrderList lists simulated trigger orders of a symbol, newest first.
+// State UNFINISHED selects open triggers and HISTORY finished ones; other
+// states match exactly.
+func (c *PaperClient) GetPlanOrderList(ctx context.Context, queryReq GetPlanOrderListRequest) (*GetPlanOrderListResult, error) {
+	symbol := paperSymbol(queryReq.Symbol)
+	triggers := c.engine.Triggers(symbol, true)
+	triggers = append(triggers, c.engine.Triggers(symbol, false)...)
+	var items []PlanOrderDetail
+	for i := range triggers {
+		d := planOrderDetail(&triggers[i])
+		open := triggers[i].Status == paper.TriggerOpen
+		switch {
+		case queryReq.State == "UNFINISHED" && !open,
+			queryReq.State == "HISTORY" && open,
+			queryReq.State != "UNFINISHED" && queryReq.State != "HISTORY" && queryReq.State != "" && d.State != queryReq.State,
+			queryReq.StartTime != nil && d.CreatedTime < *queryReq.StartTime,
+			queryReq.EndTime != nil && d.CreatedTime > *queryReq.EndTime:
+			continue
+		}
+		items = append(items, d)
+	}
+	result := &GetPlanOrderListResult{CommonResponse: paperOK}
+	result.Result.Page, result.Result.Ps, result.Result.Total = 1, 10, len(items)
+	if queryReq.Page != nil && *queryReq.Page > 0 {
+		result.Result.Page = *queryReq.Page
+	}
+	if queryReq.Size != nil && *queryReq.Size > 0 {
+		result.Result.Ps = *queryReq.Size
+	}
+	result.Result.Items = pageItems(items, result.Result.Page, result.Result.Ps)
+	return result, nil
+}
+
+// GetPlanOrderDetail returns a simulated trigger order.
+func (c *PaperClient) GetPlanOrderDetail(ctx context.Context, entrustID int64) (*GetPlanOrderDetailResult, error) {
+	t, err := c.engine.Trigger(entrustID)
+	if err != nil {
+		return nil, fmt.Errorf("GetPlanOrderDetail for ID %d failed: %w", entrustID, err)
+	}
+	return &GetPlanOrderDetailResult{CommonResponse: paperOK, Result: planOrderDetail(t)}, nil
+}
+
+// --- Conversion helpers ---
+
+// attachProfitStop registers the TP/SL of an order that opens a leg as
+// triggers that close the leg at market.
+func (c *PaperClient) attachProfitStop(ctx context.Context, req paper.OrderRequest, profit, stop *string) error {
+	closing := paper.OrderRequest{Symbol: req.Symbol, Side: exchange.Sell, Type: exchange.Market, Close: true, PositionSide: req.PositionSide}
+	if req.PositionSide == exchange.Short {
+		closing.Side = exchange.Buy
+	}
+	for _, tp := range []struct {
+		price *string
+		rule  paper.TriggerRule
+	}{
+		{profit, paper.PriceAbove},
+		{stop, paper.PriceBelow},
+	} {
+		if deref(tp.price) == "" {
+			continue
+		}
+		price, err := decimal.Parse(*tp.price)
+		if err != nil {
+			return fmt.Errorf("invalid TP/SL price %q: %w", *tp.price, exchange.ErrInvalidParameter)
+		}
+		rule := tp.rule
+		if req.PositionSide == exchange.Short {
+			rule = paper.PriceAbove + paper.PriceBelow - rule // Reversed for shorts
+		}
+		if _, err := c.engine.PlaceTrigger(ctx, paper.TriggerRequest{Order: closing, Price: price, Rule: rule}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *PaperClient) positionDetail(p paper.Position) PositionDetail {
+	var open, frozen decimal.Decimal
+	for _, o := range c.engine.Orders(p.Symbol, true) {
+		if o.PositionSide != p.Side {
+			continue
+		}
+		if o.Side == exchange.Buy == (p.Side == exchange.Long) {
+			open = open.Add(o.Left())
+		} else {
+			frozen = frozen.Add(o.Left())
+		}
+	}
+	return PositionDetail{
+		AvailableCloseSize: p.Size.Abs().Sub(frozen),
+		CalMarkPrice:       p.MarkPrice,
+		CloseOrderSize:     frozen,
+		ContractType:       "PERPETUAL",
+		EntryPrice:         p.EntryPrice,
+		FloatingPL:         p.UnrealizedPnL,
+		IsolatedMargin:     p.Margin,
+		Leverage:           p.Leverage,
+		OpenOrderSize:      open,
+		PositionSide:       strings.ToUpper(string(p.Side)),
+		PositionSize:       p.Size.Abs(),
+		PositionType:       "ISOLATED",
+		RealizedProfit:     p.RealizedPnL,
+		Symbol:             strings.ToLower(p.Symbol),
+	}
+}
+
+// paperOrderRequest converts the fields shared by XT orders and plan orders.
+func paperOrderRequest(symbol, side, orderType, qty string, price *string, tif, positionSide string) (paper.OrderRequest, error) {
+	req := paper.OrderRequest{Symbol: paperSymbol(symbol)}
+	switch side {
+	case "BUY":
+		req.Side = exchange.Buy
+	case "SELL":
+		req.Side = exchange.Sell
+	default:
+		return req, fmt.Errorf("invalid orderSide %q: %w", side, exchange.ErrInvalidParameter)
+	}
+	switch orderType {
+	case "LIMIT":
+		req.Type = exchange.Limit
+	case "MARKET":
+		req.Type = exchange.Market
+	default:
+		return req, fmt.Errorf("invalid orderType %q: %w", orderType, exchange.ErrInvalidParameter)
+	}
+	var err error
+	if req.Size, err = decimal.Parse(qty); err != nil {
+		return req, fmt.Errorf("invalid origQty %q: %w", qty, exchange.ErrInvalidParameter)
+	}
+	if req.Type == exchange.Limit && deref(price) != "" {
+		if req.Price, err = decimal.Parse(*price); err != nil {
+			return req, fmt.Errorf("invalid price %q: %w", *price, exchange.ErrInvalidParameter)
+		}
+	}
+	switch tif {
+	case "", "GTC":
+		req.TimeInForce = exchange.GTC
+	case "IOC":
+		req.TimeInForce = exchange.IOC
+	case "FOK":
+		req.TimeInForce = exchange.FOK
+	case "GTX":
+		req.TimeInForce = exchange.PostOnly
+	default:
+		return req, fmt.Errorf("invalid timeInForce %q: %w", tif, exchange.ErrInvalidParameter)
+	}
+	req.PositionSide, err = paperPositionSide(positionSide)
+	if err == nil && req.PositionSide == "" {
+		err = fmt.Errorf("positionSide is required: %w", exchange.ErrInvalidParameter)
+	}
+	return req, err
+}
+
+func paperPositionSide(positionSide string) (exchange.PositionSide, error) {
+	switch positionSide {
+	case "":
+		return "", nil
+	case "LONG":
+		return exchange.Long, nil
+	case "SHORT":
+		return exchange.Short, nil
+	}
+	return "", fmt.Errorf("invalid positionSide %q: %w", positionSide, exchange.ErrInvalidParameter)
+}
+
+// paperSymbol converts an XT symbol to the engine's canonical symbol.
+func paperSymbol(symbol string) string {
+	if symbol == "" {
+		return ""
+	}
+	return symbols.Canonical(symbol)
+}
+
+func orderDetail(o *paper.Order) OrderDetail {
+	d := OrderDetail{
+		AvgPrice:     o.AvgPrice,
+		CreatedTime:  o.CreateTime.UnixMilli(),
+		ExecutedQty:  o.Filled,
+		OrderID:      o.ID,
+		OrderSide:    strings.ToUpper(string(o.Side)),
+		OrderType:    strings.ToUpper(string(o.Type)),
+		OrigQty:      o.Size,
+		PositionSide: strings.ToUpper(string(o.PositionSide)),
+		Price:        o.Price,
+		Symbol:       strings.ToLower(o.Symbol),
+		TimeInForce:  xtTimeInForce(o.TimeInForce),
+	}
+	if o.ClientOrderID != "" {
+		id := o.ClientOrderID
+		d.ClientOrderID = &id
+	}
+	switch {
+	case o.Status == exchange.StatusOpen:
+		d.State = "NEW"
+	case o.Status == exchange.StatusPartiallyFilled:
+		d.State = "PARTIALLY_FILLED"
+	case o.Status == exchange.StatusFilled:
+		d.State = "FILLED"
+	case o.Filled.Sign() > 0:
+		d.State = "PARTIALLY_CANCELED"
+	default:
+		d.State = "CANCELED"
+	}
+	return d
+}
+
+func planOrderDetail(t *paper.Trigger) PlanOrderDetail {
+	entrustType := "TAKE_PROFIT"
+	if (t.Order.Side == exchange.Buy) == (t.Rule == paper.PriceAbove) {
+		entrustType = "STOP"
+	}
+	if t.Order.Type == exchange.Market {
+		entrustType += "_MARKET"
+	}
+	d := PlanOrderDetail{
+		CreatedTime:      t.CreateTime.UnixMilli(),
+		EntrustID:        t.ID,
+		EntrustType:      entrustType,
+		OrderSide:        strings.ToUpper(string(t.Order.Side)),
+		OrigQty:          t.Order.Size,
+		PositionSide:     strings.ToUpper(string(t.Order.PositionSide)),
+		Price:            t.Order.Price,
+		StopPrice:        t.Price,
+		Symbol:           strings.ToLower(t.Order.Symbol),
+		TimeInForce:      xtTimeInForce(t.Order.TimeInForce),
+		TriggerPriceType: "LATEST_PRICE",
+	}
+	if t.Order.ClientOrderID != "" {
+		id := t.Order.ClientOrderID
+		d.ClientOrderID = &id
+	}
+	switch t.Status {
+	case paper.TriggerOpen:
+		d.State = "NOT_TRIGGERED"
+	case paper.TriggerFinished:
+		d.State = "TRIGGERED"
+	case paper.TriggerCancelled:
+		d.State = "USER_REVOCATION"
+	case paper.TriggerFailed:
+		d.State = "PLATFORM_REVOCATION"
+	case paper.TriggerExpired:
+		d.State = "EXPIRED"
+	}
+	return d
+}
+
+func xtTimeInForce(tif exchange.TimeInForce) string {
+	switch tif {
+	case "":
+		return "GTC"
+	case exchange.PostOnly:
+		return "GTX"
+	}
+	return strings.ToUpper(string(tif))
+}
+
+// pageItems returns page (1-based) of size items.
+func pageItems[T any](items []T, page, size int) []T {
+	start := (page - 1) * size
+	if start >= len(items) {
+		return []T{}
+	}
+	return items[start:min(start+size, len(items))]
+}
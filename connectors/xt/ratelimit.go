@@ -0,0 +1,36 @@
+package xt
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/neqin/futures/ratelimit"
+)
+
+// DefaultRateLimits returns XT's published futures REST limits: 1000 requests
+// per minute per IP for market data and for account endpoints, and 100
+// requests per second for order endpoints. The per-minute buckets allow bursts
+// of 50 requests.
+func DefaultRateLimits() map[ratelimit.Group]ratelimit.Rate {
+	return map[ratelimit.Group]ratelimit.Rate{
+		ratelimit.PublicMarket:   {Requests: 1000, Per: time.Minute, Burst: 50},
+		ratelimit.PrivateTrading: ratelimit.Every(100, time.Second),
+		ratelimit.PrivateAccount: {Requests: 1000, Per: time.Minute, Burst: 50},
+	}
+}
+
+// endpointGroup classifies a request such as POST "/future/trade/v1/order/create".
+// Order and trade queries under /future/trade/ are GETs and count against the
+// account limit; only the writing methods use the trading bucket.
+func endpointGroup(method, path string) ratelimit.Group {
+	trade := strings.HasPrefix(path, "/future/trade/")
+	switch {
+	case trade && (method == http.MethodPost || method == http.MethodPut || method == http.MethodDelete):
+		return ratelimit.PrivateTrading
+	case trade, strings.HasPrefix(path, "/future/user/"):
+		return ratelimit.PrivateAccount
+	default:
+		return ratelimit.PublicMarket
+	}
+}
@@ -0,0 +1,30 @@
+package xt
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/neqin/futures/ratelimit"
+)
+
+func TestEndpointGroup(t *testing.T) {
+	tests := []struct {
+		method, path string
+		want         ratelimit.Group
+	}{
+		{http.MethodGet, "/future/market/v1/public/q/depth", ratelimit.PublicMarket},
+		{http.MethodGet, "/future/market/v3/public/symbol/list", ratelimit.PublicMarket},
+		{http.MethodPost, "/future/trade/v1/order/create", ratelimit.PrivateTrading},
+		{http.MethodPost, "/future/trade/v1/entrust/cancel-plan", ratelimit.PrivateTrading},
+		{http.MethodDelete, "/future/trade/v1/order/cancel-all", ratelimit.PrivateTrading},
+		{http.MethodGet, "/future/trade/v1/order/list", ratelimit.PrivateAccount},
+		{http.MethodGet, "/future/trade/v1/order/detail", ratelimit.PrivateAccount},
+		{http.MethodGet, "/future/user/v1/balance/list", ratelimit.PrivateAccount},
+		{http.MethodPost, "/future/user/v1/position/adjust-leverage", ratelimit.PrivateAccount},
+	}
+	for _, tt := range tests {
+		if got := endpointGroup(tt.method, tt.path); got != tt.want {
+			t.Errorf("%s %s: got %s, want %s", tt.method, tt.path, got, tt.want)
+		}
+	}
+}
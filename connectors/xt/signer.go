@@ -0,0 +1,86 @@
+package xt
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+
+	"github.com/neqin/futures/credentials"
+)
+
+// Signer signs the payloads of private requests (RequestPayload) with the
+// secret key. By default the client signs with the secret of its credentials
+// provider; other implementations can keep the secret outside the process,
+// e.g. in an external signing service.
+type Signer interface {
+	// Sign returns the hex-encoded HMAC-SHA256 of payload.
+	Sign(ctx context.Context, payload string) (string, error)
+}
+
+// SignerFunc adapts a function to the Signer interface.
+type SignerFunc func(ctx context.Context, payload string) (string, error)
+
+// Sign calls f(ctx, payload).
+func (f SignerFunc) Sign(ctx context.Context, payload string) (string, error) {
+	return f(ctx, payload)
+}
+
+// HMACSigner signs payloads with a secret key held in memory.
+type HMACSigner struct {
+	secret []byte
+}
+
+// NewHMACSigner creates a signer for secretKey.
+func NewHMACSigner(secretKey string) *HMACSigner {
+	return &HMACSigner{secret: []byte(secretKey)}
+}
+
+// Sign returns the hex-encoded HMAC-SHA256 of payload.
+func (s *HMACSigner) Sign(_ context.Context, payload string) (string, error) {
+	return hmacSHA256(s.secret, payload), nil
+}
+
+func hmacSHA256(secret []byte, payload string) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(payload))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// canSign reports whether requests can be signed for creds: an API key is
+// required, and a secret unless a custom signer is set.
+func canSign(signer Signer, creds *credentials.Credentials) bool {
+	return !creds.Empty() && (signer != nil || len(creds.Secret) > 0)
+}
+
+// sign signs payload with signer, or with the secret of creds if signer is nil.
+func sign(ctx context.Context, signer Signer, creds *credentials.Credentials, payload string) (string, error) {
+	if signer != nil {
+		return signer.Sign(ctx, payload)
+	}
+	return hmacSHA256(creds.Secret, payload), nil
+}
+
+// RequestPayload builds the string signed for a private request, X followed
+// by Y:
+//
+//	X = validate-appkey=<appKey>[&validate-recvwindow=<recvWindow>]&validate-timestamp=<timestamp>
+//	Y = #<path>[#<query>][#<body>]
+//
+// recvWindow is omitted when empty (no validate-recvwindow header is sent),
+// timestamp is in Unix milliseconds, query is the sorted, URL-encoded query
+// string (signed for GET and DELETE only) and body the raw request body.
+func RequestPayload(appKey, recvWindow, timestamp, path, query, body string) string {
+	payload := "validate-appkey=" + appKey
+	if recvWindow != "" {
+		payload += "&validate-recvwindow=" + recvWindow
+	}
+	payload += "&validate-timestamp=" + timestamp + "#" + path
+	if query != "" {
+		payload += "#" + query
+	}
+	if body != "" {
+		payload += "#" + body
+	}
+	return payload
+}
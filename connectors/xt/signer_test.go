@@ -0,0 +1,72 @@
+package xt_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/neqin/futures/connectors/xt"
+	"github.com/neqin/futures/connectors/xt/xttest"
+)
+
+// Known-answer vectors for the key "xt-appkey", secret "xt-secret" and
+// timestamp 1700000000000.
+var signatureVectors = []struct {
+	name                      string
+	window, path, query, body string
+	payload, sign             string
+}{
+	{
+		name: "GET with query", path: "/future/user/v1/balance/detail", query: "coin=usdt",
+		payload: "validate-appkey=xt-appkey&validate-timestamp=1700000000000#/future/user/v1/balance/detail#coin=usdt",
+		sign:    "5685820ad67f0a5b33c3c9964dd6a0c6e06d611840832c6f10f516def006aef6",
+	},
+	{
+		name: "GET with recv window", window: "5000", path: "/future/user/v1/position/list", query: "symbol=btc_usdt",
+		payload: "validate-appkey=xt-appkey&validate-recvwindow=5000&validate-timestamp=1700000000000#/future/user/v1/position/list#symbol=btc_usdt",
+		sign:    "b482fdf3497f2cdbdaffe64e452d1228634f8d8d5e6318bcab0a94b863e95ad1",
+	},
+	{
+		name: "POST JSON body", path: "/future/trade/v1/order/create",
+		body:    `{"symbol":"btc_usdt","orderSide":"BUY","orderType":"LIMIT","origQty":"1","price":"30000","positionSide":"LONG"}`,
+		payload: `validate-appkey=xt-appkey&validate-timestamp=1700000000000#/future/trade/v1/order/create#{"symbol":"btc_usdt","orderSide":"BUY","orderType":"LIMIT","origQty":"1","price":"30000","positionSide":"LONG"}`,
+		sign:    "d94d818bd17ee548e06060d488a82394a1cca0f06c66462d6fba751319c85f87",
+	},
+	{
+		name: "POST form body", path: "/future/trade/v1/order/cancel", body: "orderId=123456",
+		payload: "validate-appkey=xt-appkey&validate-timestamp=1700000000000#/future/trade/v1/order/cancel#orderId=123456",
+		sign:    "44804a4815a1bcaf5ee44e5b7260fae8ac04905ac6a58d8fc9322f416cd87b63",
+	},
+}
+
+func TestSignatureVectors(t *testing.T) {
+	signer := xt.NewHMACSigner("xt-secret")
+	for _, v := range signatureVectors {
+		payload := xt.RequestPayload("xt-appkey", v.window, "1700000000000", v.path, v.query, v.body)
+		if payload != v.payload {
+			t.Errorf("%s: payload = %q, want %q", v.name, payload, v.payload)
+		}
+		sign, err := signer.Sign(context.Background(), payload)
+		if err != nil || sign != v.sign {
+			t.Errorf("%s: sign = %s, %v, want %s", v.name, sign, err, v.sign)
+		}
+	}
+}
+
+func TestExternalSigner(t *testing.T) {
+	srv, _ := newServer(t)
+	remote := xt.NewHMACSigner(xttest.SecretKey) // Stands in for a signing service
+	calls := 0
+
+	client := xt.NewClient(xttest.APIKey, "", nil)
+	client.SetUsdtBaseURL(srv.URL)
+	client.SetSigner(xt.SignerFunc(func(ctx context.Context, payload string) (string, error) {
+		calls++
+		return remote.Sign(ctx, payload)
+	}))
+	if _, err := client.GetBalance(context.Background(), "usdt"); err != nil {
+		t.Fatal(err)
+	}
+	if calls != 1 {
+		t.Errorf("signer calls = %d, want 1", calls)
+	}
+}
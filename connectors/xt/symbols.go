@@ -0,0 +1,74 @@
+package xt
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/neqin/futures/symbols"
+)
+
+// SymbolLoader implements symbols.Loader with GetAllMarketConfigV3, listing
+// the contracts of both the USDT-M and the COIN-M host.
+type SymbolLoader struct {
+	client *Client
+}
+
+// NewSymbolLoader creates a loader for the contracts of both hosts.
+func NewSymbolLoader(client *Client) *SymbolLoader {
+	return &SymbolLoader{client: client}
+}
+
+// Venue returns "xt".
+func (l *SymbolLoader) Venue() string {
+	return "xt"
+}
+
+// LoadContracts lists the contracts of both hosts. Contracts of the host that
+// loaded are returned together with the error of the one that failed. As a
+// side effect the client's symbol routing cache is refreshed.
+func (l *SymbolLoader) LoadContracts(ctx context.Context) ([]symbols.Contract, error) {
+	var result []symbols.Contract
+	var errs []error
+	for _, underlying := range []UnderlyingType{USDTMargined, CoinMargined} {
+		res, err := l.client.WithUnderlying(underlying).GetAllMarketConfigV3(ctx)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", underlying, err))
+			continue
+		}
+		for i := range res.Result.Symbols {
+			result = append(result, symbolContract(&res.Result.Symbols[i]))
+		}
+	}
+	return result, errors.Join(errs...)
+}
+
+// symbolContract converts an XT contract to symbols.Contract. Quantities are
+// in contracts with QuantityPrecision decimals.
+func symbolContract(c *Contract) symbols.Contract {
+	tick := c.MinStepPrice
+	if tick.IsZero() {
+		tick = symbols.Step(int32(c.PricePrecision))
+	}
+	settle := strings.ToUpper(c.QuoteCoin)
+	if c.IsCoinMargined() {
+		settle = strings.ToUpper(c.BaseCoin)
+	}
+	return symbols.Contract{
+		Symbol:            symbols.Canonical(c.Symbol),
+		VenueSymbol:       c.Symbol,
+		Base:              strings.ToUpper(c.BaseCoin),
+		Quote:             strings.ToUpper(c.QuoteCoin),
+		Settle:            settle,
+		Inverse:           c.IsCoinMargined(),
+		TickSize:          tick,
+		LotSize:           symbols.Step(int32(c.QuantityPrecision)),
+		MinSize:           c.MinQty,
+		Multiplier:        c.ContractSize,
+		MinNotional:       c.MinNotional,
+		MaxNotional:       c.MaxNotional,
+		PricePrecision:    symbols.Precision(tick),
+		QuantityPrecision: int32(c.QuantityPrecision),
+	}
+}
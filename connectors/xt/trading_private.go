@@ -29,7 +29,7 @@ type PlaceOrderRequest struct {
 // Endpoint: POST /future/trade/v1/order/create
 func (c *Client) PlaceOrder(ctx context.Context, orderReq PlaceOrderRequest) (*PlaceOrderResult, error) {
 	path := "/future/trade/v1/order/create"
-	baseURL := c.getBaseURL("USDT-M") // Assuming USDT-M
+	baseURL := c.symbolBaseURL(ctx, orderReq.Symbol)
 
 	// Basic validation
 	if orderReq.Symbol == "" || orderReq.OrderSide == "" || orderReq.OrderType == "" || orderReq.OrigQty == "" || orderReq.PositionSide == "" {
@@ -38,6 +38,11 @@ func (c *Client) PlaceOrder(ctx context.Context, orderReq PlaceOrderRequest) (*P
 	if orderReq.OrderType == "LIMIT" && (orderReq.Price == nil || *orderReq.Price == "") {
 		return nil, fmt.Errorf("price is required for LIMIT orders")
 	}
+	if c.validation != nil {
+		if err := c.validateOrder(ctx, &orderReq); err != nil {
+			return nil, err
+		}
+	}
 
 	var result PlaceOrderResult
 	// API accepts application/json or application/x-www-form-urlencoded
@@ -60,11 +65,20 @@ type PlaceBatchOrderRequest struct {
 // Note: API expects the list of orders as a JSON *string* within the 'list' form parameter.
 func (c *Client) PlaceBatchOrder(ctx context.Context, batchReq PlaceBatchOrderRequest) (*PlaceBatchOrderResult, error) {
 	path := "/future/trade/v2/order/create-batch" // Using v2 endpoint from docs
-	baseURL := c.getBaseURL("USDT-M")
 
 	if len(batchReq.List) == 0 {
 		return nil, fmt.Errorf("order list cannot be empty for batch order")
 	}
+	baseURL := c.symbolBaseURL(ctx, batchReq.List[0].Symbol) // A batch is sent to one host
+	if c.validation != nil {
+		list := append([]PlaceOrderRequest(nil), batchReq.List...) // Normalize without touching the caller's slice
+		for i := range list {
+			if err := c.validateOrder(ctx, &list[i]); err != nil {
+				return nil, fmt.Errorf("order %d: %w", i, err)
+			}
+		}
+		batchReq.List = list
+	}
 
 	// Marshal the list of orders into a JSON string
 	listJSON, err := json.Marshal(batchReq.List)
@@ -89,7 +103,7 @@ func (c *Client) PlaceBatchOrder(ctx context.Context, batchReq PlaceBatchOrderRe
 // Endpoint: POST /future/trade/v1/order/cancel
 func (c *Client) CancelOrder(ctx context.Context, orderID int64) (*CancelOrderResult, error) {
 	path := "/future/trade/v1/order/cancel"
-	baseURL := c.getBaseURL("USDT-M")
+	baseURL := c.defaultBaseURL()
 	bodyParams := map[string]string{ // Docs indicate x-www-form-urlencoded or JSON
 		"orderId": strconv.FormatInt(orderID, 10),
 	}
@@ -105,7 +119,7 @@ func (c *Client) CancelOrder(ctx context.Context, orderID int64) (*CancelOrderRe
 // Endpoint: POST /future/trade/v1/order/cancel-all
 func (c *Client) CancelBatchOrder(ctx context.Context, symbol *string) (*CancelBatchOrderResult, error) {
 	path := "/future/trade/v1/order/cancel-all"
-	baseURL := c.getBaseURL("USDT-M")
+	baseURL := c.symbolBaseURL(ctx, deref(symbol))
 	bodyParams := map[string]string{} // Use map for optional param
 	if symbol != nil {
 		bodyParams["symbol"] = *symbol // API expects empty string to cancel all
@@ -129,7 +143,7 @@ func (c *Client) CancelBatchOrder(ctx context.Context, symbol *string) (*CancelB
 // Endpoint: GET /future/trade/v1/order/detail
 func (c *Client) GetOrder(ctx context.Context, orderID int64) (*GetOrderResult, error) {
 	path := "/future/trade/v1/order/detail"
-	baseURL := c.getBaseURL("USDT-M")
+	baseURL := c.defaultBaseURL()
 	params := map[string]string{
 		"orderId": strconv.FormatInt(orderID, 10),
 	}
@@ -156,7 +170,7 @@ type GetOrderListRequest struct {
 // Endpoint: GET /future/trade/v1/order/list
 func (c *Client) GetOrderList(ctx context.Context, queryReq GetOrderListRequest) (*GetOrderListResult, error) {
 	path := "/future/trade/v1/order/list"
-	baseURL := c.getBaseURL("USDT-M")
+	baseURL := c.symbolBaseURL(ctx, deref(queryReq.Symbol))
 	params := make(map[string]string)
 	if queryReq.State != nil {
 		params["state"] = *queryReq.State
@@ -202,7 +216,7 @@ type GetHistoryListRequest struct {
 // Endpoint: GET /future/trade/v1/order/list-history
 func (c *Client) GetHistoryList(ctx context.Context, queryReq GetHistoryListRequest) (*GetHistoryListResult, error) {
 	path := "/future/trade/v1/order/list-history"
-	baseURL := c.getBaseURL("USDT-M")
+	baseURL := c.symbolBaseURL(ctx, queryReq.Symbol)
 	params := map[string]string{
 		"symbol": queryReq.Symbol, // Required
 	}
@@ -244,7 +258,7 @@ type GetTradeListRequest struct {
 // Endpoint: GET /future/trade/v1/order/trade-list
 func (c *Client) GetTradeList(ctx context.Context, queryReq GetTradeListRequest) (*GetTradeListResult, error) {
 	path := "/future/trade/v1/order/trade-list"
-	baseURL := c.getBaseURL("USDT-M")
+	baseURL := c.symbolBaseURL(ctx, deref(queryReq.Symbol))
 	params := make(map[string]string)
 	if queryReq.OrderID != nil {
 		params["orderId"] = strconv.FormatInt(*queryReq.OrderID, 10)
@@ -294,7 +308,7 @@ type UpdateOrderRequest struct {
 // Endpoint: POST /future/trade/v1/order/update
 func (c *Client) UpdateOrder(ctx context.Context, updateReq UpdateOrderRequest) (*UpdateOrderResult, error) {
 	path := "/future/trade/v1/order/update"
-	baseURL := c.getBaseURL("USDT-M")
+	baseURL := c.defaultBaseURL()
 
 	// API accepts application/json or application/x-www-form-urlencoded
 	// Using JSON for simplicity with optional fields.
@@ -326,7 +340,7 @@ type CreatePlanOrderRequest struct {
 // Endpoint: POST /future/trade/v1/entrust/create-plan
 func (c *Client) CreatePlanOrder(ctx context.Context, orderReq CreatePlanOrderRequest) (*CreatePlanOrderResult, error) {
 	path := "/future/trade/v1/entrust/create-plan"
-	baseURL := c.getBaseURL("USDT-M")
+	baseURL := c.symbolBaseURL(ctx, orderReq.Symbol)
 
 	// Basic Validation
 	if orderReq.EntrustType == "TAKE_PROFIT" || orderReq.EntrustType == "STOP" {
@@ -352,7 +366,7 @@ func (c *Client) CreatePlanOrder(ctx context.Context, orderReq CreatePlanOrderRe
 // Endpoint: POST /future/trade/v1/entrust/cancel-plan
 func (c *Client) CancelPlanOrder(ctx context.Context, entrustID int64) (*CancelPlanOrderResult, error) {
 	path := "/future/trade/v1/entrust/cancel-plan"
-	baseURL := c.getBaseURL("USDT-M")
+	baseURL := c.defaultBaseURL()
 	bodyParams := map[string]string{
 		"entrustId": strconv.FormatInt(entrustID, 10),
 	}
@@ -368,7 +382,7 @@ func (c *Client) CancelPlanOrder(ctx context.Context, entrustID int64) (*CancelP
 // Endpoint: POST /future/trade/v1/entrust/cancel-all-plan
 func (c *Client) CancelAllPlanOrder(ctx context.Context, symbol string) (*CancelAllPlanOrderResult, error) {
 	path := "/future/trade/v1/entrust/cancel-all-plan"
-	baseURL := c.getBaseURL("USDT-M")
+	baseURL := c.symbolBaseURL(ctx, symbol)
 	bodyParams := map[string]string{
 		"symbol": symbol, // Required
 	}
@@ -394,7 +408,7 @@ type GetPlanOrderListRequest struct {
 // Endpoint: GET /future/trade/v1/entrust/plan-list
 func (c *Client) GetPlanOrderList(ctx context.Context, queryReq GetPlanOrderListRequest) (*GetPlanOrderListResult, error) {
 	path := "/future/trade/v1/entrust/plan-list"
-	baseURL := c.getBaseURL("USDT-M")
+	baseURL := c.symbolBaseURL(ctx, queryReq.Symbol)
 	params := map[string]string{
 		"symbol": queryReq.Symbol,
 		"state":  queryReq.State,
@@ -424,7 +438,7 @@ func (c *Client) GetPlanOrderList(ctx context.Context, queryReq GetPlanOrderList
 // Endpoint: GET /future/trade/v1/entrust/plan-detail
 func (c *Client) GetPlanOrderDetail(ctx context.Context, entrustID int64) (*GetPlanOrderDetailResult, error) {
 	path := "/future/trade/v1/entrust/plan-detail"
-	baseURL := c.getBaseURL("USDT-M")
+	baseURL := c.defaultBaseURL()
 	params := map[string]string{
 		"entrustId": strconv.FormatInt(entrustID, 10),
 	}
@@ -450,7 +464,7 @@ type GetPlanHistoryListRequest struct {
 // Endpoint: GET /future/trade/v1/entrust/plan-list-history
 func (c *Client) GetPlanHistoryList(ctx context.Context, queryReq GetPlanHistoryListRequest) (*GetPlanHistoryListResult, error) {
 	path := "/future/trade/v1/entrust/plan-list-history"
-	baseURL := c.getBaseURL("USDT-M")
+	baseURL := c.symbolBaseURL(ctx, queryReq.Symbol)
 	params := map[string]string{
 		"symbol": queryReq.Symbol,
 	}
@@ -494,7 +508,7 @@ type CreateProfitStopRequest struct {
 // Endpoint: POST /future/trade/v1/entrust/create-profit
 func (c *Client) CreateProfitStop(ctx context.Context, orderReq CreateProfitStopRequest) (*CreateProfitStopResult, error) {
 	path := "/future/trade/v1/entrust/create-profit"
-	baseURL := c.getBaseURL("USDT-M")
+	baseURL := c.symbolBaseURL(ctx, orderReq.Symbol)
 	var result CreateProfitStopResult
 	err := c.SendPrivateRequest(ctx, http.MethodPost, baseURL, path, nil, orderReq, &result)
 	if err != nil {
@@ -507,7 +521,7 @@ func (c *Client) CreateProfitStop(ctx context.Context, orderReq CreateProfitStop
 // Endpoint: POST /future/trade/v1/entrust/cancel-profit-stop
 func (c *Client) CancelProfitStop(ctx context.Context, profitID int64) (*CancelProfitStopResult, error) {
 	path := "/future/trade/v1/entrust/cancel-profit-stop"
-	baseURL := c.getBaseURL("USDT-M")
+	baseURL := c.defaultBaseURL()
 	bodyParams := map[string]string{
 		"profitId": strconv.FormatInt(profitID, 10),
 	}
@@ -523,7 +537,7 @@ func (c *Client) CancelProfitStop(ctx context.Context, profitID int64) (*CancelP
 // Endpoint: POST /future/trade/v1/entrust/cancel-all-profit-stop
 func (c *Client) CancelAllProfitStop(ctx context.Context, symbol string) (*CancelAllProfitStopResult, error) {
 	path := "/future/trade/v1/entrust/cancel-all-profit-stop"
-	baseURL := c.getBaseURL("USDT-M")
+	baseURL := c.symbolBaseURL(ctx, symbol)
 	bodyParams := map[string]string{
 		"symbol": symbol, // Required
 	}
@@ -549,7 +563,7 @@ type GetProfitStopListRequest struct {
 // Endpoint: GET /future/trade/v1/entrust/profit-list
 func (c *Client) GetProfitStopList(ctx context.Context, queryReq GetProfitStopListRequest) (*GetProfitStopListResult, error) {
 	path := "/future/trade/v1/entrust/profit-list"
-	baseURL := c.getBaseURL("USDT-M")
+	baseURL := c.symbolBaseURL(ctx, queryReq.Symbol)
 	params := map[string]string{
 		"symbol": queryReq.Symbol,
 		"state":  queryReq.State,
@@ -579,7 +593,7 @@ func (c *Client) GetProfitStopList(ctx context.Context, queryReq GetProfitStopLi
 // Endpoint: GET /future/trade/v1/entrust/profit-detail
 func (c *Client) GetProfitStopDetail(ctx context.Context, profitID int64) (*GetProfitStopDetailResult, error) {
 	path := "/future/trade/v1/entrust/profit-detail"
-	baseURL := c.getBaseURL("USDT-M")
+	baseURL := c.defaultBaseURL()
 	params := map[string]string{
 		"profitId": strconv.FormatInt(profitID, 10),
 	}
@@ -602,7 +616,7 @@ type UpdateProfitStopRequest struct {
 // Endpoint: POST /future/trade/v1/entrust/update-profit-stop
 func (c *Client) UpdateProfitStop(ctx context.Context, updateReq UpdateProfitStopRequest) (*UpdateProfitStopResult, error) {
 	path := "/future/trade/v1/entrust/update-profit-stop"
-	baseURL := c.getBaseURL("USDT-M")
+	baseURL := c.defaultBaseURL()
 	var result UpdateProfitStopResult
 	err := c.SendPrivateRequest(ctx, http.MethodPost, baseURL, path, nil, updateReq, &result)
 	if err != nil {
@@ -634,7 +648,7 @@ type CreateTrackOrderRequest struct {
 // Endpoint: POST /future/trade/v1/entrust/create-track
 func (c *Client) CreateTrackOrder(ctx context.Context, orderReq CreateTrackOrderRequest) (*CreateTrackOrderResult, error) {
 	path := "/future/trade/v1/entrust/create-track"
-	baseURL := c.getBaseURL("USDT-M")
+	baseURL := c.symbolBaseURL(ctx, orderReq.Symbol)
 	// API expects application/x-www-form-urlencoded
 	bodyParams := map[string]string{
 		"callback":         orderReq.Callback,
@@ -674,7 +688,7 @@ func (c *Client) CreateTrackOrder(ctx context.Context, orderReq CreateTrackOrder
 // Endpoint: POST /future/trade/v1/entrust/cancel-track
 func (c *Client) CancelTrackOrder(ctx context.Context, trackID int64) (*CancelTrackOrderResult, error) {
 	path := "/future/trade/v1/entrust/cancel-track"
-	baseURL := c.getBaseURL("USDT-M")
+	baseURL := c.defaultBaseURL()
 	bodyParams := map[string]string{
 		"trackId": strconv.FormatInt(trackID, 10),
 	}
@@ -690,7 +704,7 @@ func (c *Client) CancelTrackOrder(ctx context.Context, trackID int64) (*CancelTr
 // Endpoint: GET /future/trade/v1/entrust/track-detail
 func (c *Client) GetTrackOrderDetail(ctx context.Context, trackID int64) (*GetTrackOrderDetailResult, error) {
 	path := "/future/trade/v1/entrust/track-detail"
-	baseURL := c.getBaseURL("USDT-M")
+	baseURL := c.defaultBaseURL()
 	params := map[string]string{
 		"trackId": strconv.FormatInt(trackID, 10),
 	}
@@ -715,7 +729,7 @@ type GetTrackOrderListRequest struct {
 // Endpoint: GET /future/trade/v1/entrust/track-list
 func (c *Client) GetTrackOrderList(ctx context.Context, queryReq GetTrackOrderListRequest) (*GetTrackOrderListResult, error) {
 	path := "/future/trade/v1/entrust/track-list"
-	baseURL := c.getBaseURL("USDT-M")
+	baseURL := c.symbolBaseURL(ctx, deref(queryReq.Symbol))
 	params := make(map[string]string)
 	if queryReq.Page != nil {
 		params["page"] = strconv.Itoa(*queryReq.Page)
@@ -745,7 +759,7 @@ func (c *Client) GetTrackOrderList(ctx context.Context, queryReq GetTrackOrderLi
 // Endpoint: POST /future/trade/v1/entrust/cancel-all-track
 func (c *Client) CancelAllTrackOrder(ctx context.Context) (*CancelAllTrackOrderResult, error) {
 	path := "/future/trade/v1/entrust/cancel-all-track"
-	baseURL := c.getBaseURL("USDT-M")
+	baseURL := c.defaultBaseURL()
 	// No body parameters needed
 	var result CancelAllTrackOrderResult
 	err := c.SendPrivateRequest(ctx, http.MethodPost, baseURL, path, nil, nil, &result)
@@ -769,7 +783,7 @@ type GetTrackHistoryListRequest struct {
 // Endpoint: GET /future/trade/v1/entrust/track-list-history
 func (c *Client) GetTrackHistoryList(ctx context.Context, queryReq GetTrackHistoryListRequest) (*GetTrackHistoryListResult, error) {
 	path := "/future/trade/v1/entrust/track-list-history"
-	baseURL := c.getBaseURL("USDT-M")
+	baseURL := c.symbolBaseURL(ctx, deref(queryReq.Symbol))
 	params := make(map[string]string)
 	if queryReq.Direction != nil {
 		params["direction"] = *queryReq.Direction
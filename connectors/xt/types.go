@@ -1,6 +1,11 @@
 package xt
 
-import "encoding/json"
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/neqin/futures/decimal"
+)
 
 // CommonResponse structure for basic API responses
 type CommonResponse struct {
@@ -9,6 +14,26 @@ type CommonResponse struct {
 	Error      json.RawMessage `json:"error"` // Use RawMessage to handle null or object
 }
 
+// APIError is returned when XT answers with a non-zero returnCode.
+// It matches the shared error classes in the exchange package with errors.Is.
+type APIError struct {
+	ReturnCode int             // Non-zero return code
+	MsgInfo    string          // Summary message, usually "failure"
+	Detail     *ErrorDetail    // Decoded "error" payload, nil if absent or not an object
+	Raw        json.RawMessage // Undecoded "error" payload
+}
+
+// ErrorDetail defines the structure of the "error" payload of a failed response.
+type ErrorDetail struct {
+	Code string `json:"code"` // Error code, e.g. "AUTH_105" or "invalid_params"
+	Msg  string `json:"msg"`  // Error description
+}
+
+// Error returns the error message string.
+func (e *APIError) Error() string {
+	return fmt.Sprintf("XT API error: code=%d, msg=%s, error=%s", e.ReturnCode, e.MsgInfo, string(e.Raw))
+}
+
 // --- Public Market Data Structs ---
 
 // ServerTimeResult defines the structure for the server time response
@@ -33,70 +58,70 @@ type CoinsInfoResult struct {
 
 // Contract defines the structure for a single contract's details
 type Contract struct {
-	ID                        int64    `json:"id"`
-	Symbol                    string   `json:"symbol"`
-	SymbolGroupId             int      `json:"symbolGroupId"`
-	Pair                      string   `json:"pair"`
-	ContractType              string   `json:"contractType"` // perpetual, delivery
-	ProductType               string   `json:"productType"`  // perpetual, futures
-	PredictEventType          *string  `json:"predictEventType"`
-	PredictEventParam         *string  `json:"predictEventParam"`
-	PredictEventSort          *int     `json:"predictEventSort"`
-	UnderlyingType            string   `json:"underlyingType"` // Coin-M, USDT-M
-	ContractSize              string   `json:"contractSize"`
-	TradeSwitch               bool     `json:"tradeSwitch"`
-	OpenSwitch                bool     `json:"openSwitch"`
-	IsDisplay                 bool     `json:"isDisplay"`
-	IsOpenApi                 bool     `json:"isOpenApi"`
-	State                     int      `json:"state"`
-	InitLeverage              int      `json:"initLeverage"`
-	InitPositionType          string   `json:"initPositionType"`
-	BaseCoin                  string   `json:"baseCoin"`
-	SpotCoin                  string   `json:"spotCoin"` // Not in v3 list response?
-	QuoteCoin                 string   `json:"quoteCoin"`
-	SettleCoin                string   `json:"settleCoin"` // Not in v3 list response?
-	BaseCoinPrecision         int      `json:"baseCoinPrecision"`
-	BaseCoinDisplayPrecision  int      `json:"baseCoinDisplayPrecision"`
-	QuoteCoinPrecision        int      `json:"quoteCoinPrecision"`
-	QuoteCoinDisplayPrecision int      `json:"quoteCoinDisplayPrecision"`
-	QuantityPrecision         int      `json:"quantityPrecision"` // Deprecated in v3 list?
-	PricePrecision            int      `json:"pricePrecision"`
-	SupportOrderType          string   `json:"supportOrderType"`
-	SupportTimeInForce        string   `json:"supportTimeInForce"`
-	SupportEntrustType        string   `json:"supportEntrustType"`
-	SupportPositionType       string   `json:"supportPositionType"`
-	MinQty                    string   `json:"minQty"`
-	MinNotional               string   `json:"minNotional"`
-	MaxNotional               string   `json:"maxNotional"`
-	MultiplierDown            string   `json:"multiplierDown"`
-	MultiplierUp              string   `json:"multiplierUp"`
-	MaxOpenOrders             int      `json:"maxOpenOrders"`
-	MaxEntrusts               int      `json:"maxEntrusts"`
-	MakerFee                  string   `json:"makerFee"`
-	TakerFee                  string   `json:"takerFee"`
-	LiquidationFee            string   `json:"liquidationFee"`
-	MarketTakeBound           string   `json:"marketTakeBound"`
-	DepthPrecisionMerge       int      `json:"depthPrecisionMerge"`
-	Labels                    []string `json:"labels"`
-	OnboardDate               int64    `json:"onboardDate"`
-	EnName                    string   `json:"enName"`
-	CnName                    string   `json:"cnName"`
-	MinStepPrice              string   `json:"minStepPrice"`
-	MinPrice                  *string  `json:"minPrice"`
-	MaxPrice                  *string  `json:"maxPrice"`
-	DeliveryDate              *int64   `json:"deliveryDate"`
-	DeliveryPrice             *string  `json:"deliveryPrice"`
-	DeliveryCompletion        bool     `json:"deliveryCompletion"`
-	CnDesc                    *string  `json:"cnDesc"`
-	EnDesc                    *string  `json:"enDesc"`
-	CnRemark                  *string  `json:"cnRemark"`
-	EnRemark                  *string  `json:"enRemark"`
-	Plates                    []int    `json:"plates"`
-	FastTrackCallbackRate1    *string  `json:"fastTrackCallbackRate1"` // Nullable based on xt.txt
-	FastTrackCallbackRate2    *string  `json:"fastTrackCallbackRate2"` // Nullable based on xt.txt
-	MinTrackCallbackRate      *string  `json:"minTrackCallbackRate"`   // Nullable based on xt.txt
-	MaxTrackCallbackRate      *string  `json:"maxTrackCallbackRate"`   // Nullable based on xt.txt
-	LatestPriceDeviation      *float64 `json:"latestPriceDeviation"`   // Nullable based on xt.txt
+	ID                        int64            `json:"id"`
+	Symbol                    string           `json:"symbol"`
+	SymbolGroupId             int              `json:"symbolGroupId"`
+	Pair                      string           `json:"pair"`
+	ContractType              string           `json:"contractType"` // perpetual, delivery
+	ProductType               string           `json:"productType"`  // perpetual, futures
+	PredictEventType          *string          `json:"predictEventType"`
+	PredictEventParam         *string          `json:"predictEventParam"`
+	PredictEventSort          *int             `json:"predictEventSort"`
+	UnderlyingType            string           `json:"underlyingType"` // Coin-M, USDT-M
+	ContractSize              decimal.Decimal  `json:"contractSize"`
+	TradeSwitch               bool             `json:"tradeSwitch"`
+	OpenSwitch                bool             `json:"openSwitch"`
+	IsDisplay                 bool             `json:"isDisplay"`
+	IsOpenApi                 bool             `json:"isOpenApi"`
+	State                     int              `json:"state"`
+	InitLeverage              int              `json:"initLeverage"`
+	InitPositionType          string           `json:"initPositionType"`
+	BaseCoin                  string           `json:"baseCoin"`
+	SpotCoin                  string           `json:"spotCoin"` // Not in v3 list response?
+	QuoteCoin                 string           `json:"quoteCoin"`
+	SettleCoin                string           `json:"settleCoin"` // Not in v3 list response?
+	BaseCoinPrecision         int              `json:"baseCoinPrecision"`
+	BaseCoinDisplayPrecision  int              `json:"baseCoinDisplayPrecision"`
+	QuoteCoinPrecision        int              `json:"quoteCoinPrecision"`
+	QuoteCoinDisplayPrecision int              `json:"quoteCoinDisplayPrecision"`
+	QuantityPrecision         int              `json:"quantityPrecision"` // Deprecated in v3 list?
+	PricePrecision            int              `json:"pricePrecision"`
+	SupportOrderType          string           `json:"supportOrderType"`
+	SupportTimeInForce        string           `json:"supportTimeInForce"`
+	SupportEntrustType        string           `json:"supportEntrustType"`
+	SupportPositionType       string           `json:"supportPositionType"`
+	MinQty                    decimal.Decimal  `json:"minQty"`
+	MinNotional               decimal.Decimal  `json:"minNotional"`
+	MaxNotional               decimal.Decimal  `json:"maxNotional"`
+	MultiplierDown            decimal.Decimal  `json:"multiplierDown"`
+	MultiplierUp              decimal.Decimal  `json:"multiplierUp"`
+	MaxOpenOrders             int              `json:"maxOpenOrders"`
+	MaxEntrusts               int              `json:"maxEntrusts"`
+	MakerFee                  decimal.Decimal  `json:"makerFee"`
+	TakerFee                  decimal.Decimal  `json:"takerFee"`
+	LiquidationFee            decimal.Decimal  `json:"liquidationFee"`
+	MarketTakeBound           decimal.Decimal  `json:"marketTakeBound"`
+	DepthPrecisionMerge       int              `json:"depthPrecisionMerge"`
+	Labels                    []string         `json:"labels"`
+	OnboardDate               int64            `json:"onboardDate"`
+	EnName                    string           `json:"enName"`
+	CnName                    string           `json:"cnName"`
+	MinStepPrice              decimal.Decimal  `json:"minStepPrice"`
+	MinPrice                  *decimal.Decimal `json:"minPrice"`
+	MaxPrice                  *decimal.Decimal `json:"maxPrice"`
+	DeliveryDate              *int64           `json:"deliveryDate"`
+	DeliveryPrice             *decimal.Decimal `json:"deliveryPrice"`
+	DeliveryCompletion        bool             `json:"deliveryCompletion"`
+	CnDesc                    *string          `json:"cnDesc"`
+	EnDesc                    *string          `json:"enDesc"`
+	CnRemark                  *string          `json:"cnRemark"`
+	EnRemark                  *string          `json:"enRemark"`
+	Plates                    []int            `json:"plates"`
+	FastTrackCallbackRate1    *decimal.Decimal `json:"fastTrackCallbackRate1"` // Nullable based on xt.txt
+	FastTrackCallbackRate2    *decimal.Decimal `json:"fastTrackCallbackRate2"` // Nullable based on xt.txt
+	MinTrackCallbackRate      *decimal.Decimal `json:"minTrackCallbackRate"`   // Nullable based on xt.txt
+	MaxTrackCallbackRate      *decimal.Decimal `json:"maxTrackCallbackRate"`   // Nullable based on xt.txt
+	LatestPriceDeviation      *decimal.Decimal `json:"latestPriceDeviation"`   // Nullable based on xt.txt
 }
 
 // ContractsResult defines the structure for the list of contracts response (v3 endpoint)
@@ -117,14 +142,14 @@ type SingleContractResult struct {
 
 // LeverageBracket defines the structure for leverage stratification details
 type LeverageBracket struct {
-	Bracket            int    `json:"bracket"`
-	MaintMarginRate    string `json:"maintMarginRate"` // Use string for precision
-	MaxLeverage        string `json:"maxLeverage"`     // Use string
-	MaxNominalValue    string `json:"maxNominalValue"` // Use string for precision
-	MaxStartMarginRate string `json:"maxStartMarginRate"`
-	MinLeverage        string `json:"minLeverage"`     // Use string
-	StartMarginRate    string `json:"startMarginRate"` // Use string for precision
-	Symbol             string `json:"symbol"`
+	Bracket            int             `json:"bracket"`
+	MaintMarginRate    decimal.Decimal `json:"maintMarginRate"`
+	MaxLeverage        decimal.Decimal `json:"maxLeverage"`
+	MaxNominalValue    decimal.Decimal `json:"maxNominalValue"`
+	MaxStartMarginRate decimal.Decimal `json:"maxStartMarginRate"`
+	MinLeverage        decimal.Decimal `json:"minLeverage"`
+	StartMarginRate    decimal.Decimal `json:"startMarginRate"`
+	Symbol             string          `json:"symbol"`
 }
 
 // LeverageDetail defines the structure for leverage details for a symbol
@@ -147,15 +172,15 @@ type LeverageDetailListResult struct {
 
 // TickerDetail defines the structure for a single ticker.
 type TickerDetail struct {
-	Amount      string `json:"a"` // 24h volume (Quote currency?)
-	Close       string `json:"c"` // Latest price
-	High        string `json:"h"` // 24h High
-	Low         string `json:"l"` // 24h Low
-	Open        string `json:"o"` // 24h Open
-	ChangeRatio string `json:"r"` // 24h Change Ratio
-	Symbol      string `json:"s"` // Trading pair
-	Timestamp   int64  `json:"t"` // Timestamp (ms)
-	Volume      string `json:"v"` // 24h Turnover (Base currency?)
+	Amount      decimal.Decimal `json:"a"` // 24h volume (Quote currency?)
+	Close       decimal.Decimal `json:"c"` // Latest price
+	High        decimal.Decimal `json:"h"` // 24h High
+	Low         decimal.Decimal `json:"l"` // 24h Low
+	Open        decimal.Decimal `json:"o"` // 24h Open
+	ChangeRatio decimal.Decimal `json:"r"` // 24h Change Ratio
+	Symbol      string          `json:"s"` // Trading pair
+	Timestamp   int64           `json:"t"` // Timestamp (ms)
+	Volume      decimal.Decimal `json:"v"` // 24h Turnover (Base currency?)
 }
 
 // TickersResult defines the structure for the all tickers response.
@@ -172,11 +197,11 @@ type SingleTickerResult struct {
 
 // Trade defines the structure for a single public trade (deal)
 type Trade struct {
-	Amount string `json:"a"` // Volume
-	Maker  string `json:"m"` // Order side (BUY/SELL?)
-	Price  string `json:"p"` // Price
-	Symbol string `json:"s"` // Trading pair
-	Time   int64  `json:"t"` // Time (ms)
+	Amount decimal.Decimal `json:"a"` // Volume
+	Maker  string          `json:"m"` // Order side (BUY/SELL?)
+	Price  decimal.Decimal `json:"p"` // Price
+	Symbol string          `json:"s"` // Trading pair
+	Time   int64           `json:"t"` // Time (ms)
 }
 
 // TradesResult defines the structure for the recent trades response
@@ -186,7 +211,7 @@ type TradesResult struct {
 }
 
 // DepthEntry represents a single price level in the order book [price, quantity]
-type DepthEntry [2]string
+type DepthEntry [2]decimal.Decimal
 
 // DepthResult defines the structure for the order book depth response
 type DepthResult struct {
@@ -202,9 +227,9 @@ type DepthResult struct {
 
 // IndexPriceDetail defines the structure for index price info.
 type IndexPriceDetail struct {
-	Price  string `json:"p"` // Price
-	Symbol string `json:"s"` // Trading pair
-	Time   int64  `json:"t"` // Time (ms)
+	Price  decimal.Decimal `json:"p"` // Price
+	Symbol string          `json:"s"` // Trading pair
+	Time   int64           `json:"t"` // Time (ms)
 }
 
 // IndexPriceResult defines the structure for the single index price response.
@@ -221,9 +246,9 @@ type AllIndexPriceResult struct {
 
 // MarkPriceDetail defines the structure for mark price info.
 type MarkPriceDetail struct {
-	Price  string `json:"p"` // Price
-	Symbol string `json:"s"` // Trading pair
-	Time   int64  `json:"t"` // Time (ms)
+	Price  decimal.Decimal `json:"p"` // Price
+	Symbol string          `json:"s"` // Trading pair
+	Time   int64           `json:"t"` // Time (ms)
 }
 
 // SingleMarkPriceResult defines the structure for the single mark price response.
@@ -240,14 +265,14 @@ type MarkPriceResult struct {
 
 // Kline defines the structure for a single candlestick
 type Kline struct {
-	Amount string `json:"a"` // Volume (Turnover in quote currency?)
-	Close  string `json:"c"` // Close price
-	High   string `json:"h"` // Highest price
-	Low    string `json:"l"` // Lowest price
-	Open   string `json:"o"` // Open price
-	Symbol string `json:"s"` // Trading pair
-	Time   int64  `json:"t"` // Time (ms)
-	Volume string `json:"v"` // Turnover (Volume in base currency?)
+	Amount decimal.Decimal `json:"a"` // Volume (Turnover in quote currency?)
+	Close  decimal.Decimal `json:"c"` // Close price
+	High   decimal.Decimal `json:"h"` // Highest price
+	Low    decimal.Decimal `json:"l"` // Lowest price
+	Open   decimal.Decimal `json:"o"` // Open price
+	Symbol string          `json:"s"` // Trading pair
+	Time   int64           `json:"t"` // Time (ms)
+	Volume decimal.Decimal `json:"v"` // Turnover (Volume in base currency?)
 }
 
 // KlinesResult defines the structure for the klines/candlestick response
@@ -258,19 +283,19 @@ type KlinesResult struct {
 
 // AggTickerDetail defines the structure for aggregated ticker information.
 type AggTickerDetail struct {
-	Timestamp   int64  `json:"t"`  // Timestamp (ms)
-	Symbol      string `json:"s"`  // Trading pair
-	Close       string `json:"c"`  // Last price
-	High        string `json:"h"`  // 24h High
-	Low         string `json:"l"`  // 24h Low
-	Amount      string `json:"a"`  // 24h Volume (Quote currency?)
-	Volume      string `json:"v"`  // 24h Volume (Base currency?)
-	Open        string `json:"o"`  // 24h Open
-	ChangeRatio string `json:"r"`  // 24h Change Ratio
-	IndexPrice  string `json:"i"`  // Index Price
-	MarkPrice   string `json:"m"`  // Mark Price
-	BidPrice    string `json:"bp"` // Best Bid price
-	AskPrice    string `json:"ap"` // Best Ask price
+	Timestamp   int64           `json:"t"`  // Timestamp (ms)
+	Symbol      string          `json:"s"`  // Trading pair
+	Close       decimal.Decimal `json:"c"`  // Last price
+	High        decimal.Decimal `json:"h"`  // 24h High
+	Low         decimal.Decimal `json:"l"`  // 24h Low
+	Amount      decimal.Decimal `json:"a"`  // 24h Volume (Quote currency?)
+	Volume      decimal.Decimal `json:"v"`  // 24h Volume (Base currency?)
+	Open        decimal.Decimal `json:"o"`  // 24h Open
+	ChangeRatio decimal.Decimal `json:"r"`  // 24h Change Ratio
+	IndexPrice  decimal.Decimal `json:"i"`  // Index Price
+	MarkPrice   decimal.Decimal `json:"m"`  // Mark Price
+	BidPrice    decimal.Decimal `json:"bp"` // Best Bid price
+	AskPrice    decimal.Decimal `json:"ap"` // Best Ask price
 }
 
 // AggTickerResult defines the structure for the single aggregated ticker response.
@@ -287,12 +312,12 @@ type AllAggTickerResult struct {
 
 // FundingRateDetail defines the structure for a single funding rate record.
 type FundingRateDetail struct {
-	Symbol             string  `json:"symbol"`
-	FundingRate        string  `json:"fundingRate"` // Use string for precision
-	NextCollectionTime *int64  `json:"nextCollectionTime,omitempty"`
-	CollectionInternal *int    `json:"collectionInternal,omitempty"`
-	ID                 *string `json:"id,omitempty"`          // Only in record list
-	CreatedTime        *int64  `json:"createdTime,omitempty"` // Only in record list
+	Symbol             string          `json:"symbol"`
+	FundingRate        decimal.Decimal `json:"fundingRate"`
+	NextCollectionTime *int64          `json:"nextCollectionTime,omitempty"`
+	CollectionInternal *int            `json:"collectionInternal,omitempty"`
+	ID                 *string         `json:"id,omitempty"`          // Only in record list
+	CreatedTime        *int64          `json:"createdTime,omitempty"` // Only in record list
 }
 
 // FundingRateResult defines the structure for the GetFundRate response.
@@ -313,12 +338,12 @@ type FundRateRecordResult struct {
 
 // BookTickerDetail defines the structure for ask/bid ticker info.
 type BookTickerDetail struct {
-	AskPrice  string `json:"ap"` // ask price
-	AskQty    string `json:"aq"` // ask amount
-	BidPrice  string `json:"bp"` // bid price
-	BidQty    string `json:"bq"` // bid amount
-	Symbol    string `json:"s"`  // Trading pair
-	Timestamp int64  `json:"t"`  // Time (ms)
+	AskPrice  decimal.Decimal `json:"ap"` // ask price
+	AskQty    decimal.Decimal `json:"aq"` // ask amount
+	BidPrice  decimal.Decimal `json:"bp"` // bid price
+	BidQty    decimal.Decimal `json:"bq"` // bid amount
+	Symbol    string          `json:"s"`  // Trading pair
+	Timestamp int64           `json:"t"`  // Time (ms)
 }
 
 // BookTickerResult defines the structure for the single book ticker response.
@@ -335,10 +360,10 @@ type AllBookTickerResult struct {
 
 // RiskBalanceDetail defines the structure for risk balance information.
 type RiskBalanceDetail struct {
-	ID          string `json:"id"`          // ID is string in response
-	Coin        string `json:"coin"`        // Coin
-	Amount      string `json:"amount"`      // Amount (use string for precision)
-	CreatedTime int64  `json:"createdTime"` // Time (ms)
+	ID          string          `json:"id"`          // ID is string in response
+	Coin        string          `json:"coin"`        // Coin
+	Amount      decimal.Decimal `json:"amount"`      // Amount
+	CreatedTime int64           `json:"createdTime"` // Time (ms)
 }
 
 // RiskBalanceResult defines the structure for the risk balance response.
@@ -353,10 +378,10 @@ type RiskBalanceResult struct {
 
 // OpenInterestDetail defines the structure for open interest information.
 type OpenInterestDetail struct {
-	Symbol          string `json:"symbol"`          // Trading pair
-	OpenInterest    string `json:"openInterest"`    // open position
-	OpenInterestUsd string `json:"openInterestUsd"` // open value (use string)
-	Time            int64  `json:"time"`            // time (ms)
+	Symbol          string          `json:"symbol"`          // Trading pair
+	OpenInterest    decimal.Decimal `json:"openInterest"`    // open position
+	OpenInterestUsd decimal.Decimal `json:"openInterestUsd"` // open value
+	Time            int64           `json:"time"`            // time (ms)
 }
 
 // OpenInterestResult defines the structure for the open interest response.
@@ -369,14 +394,14 @@ type OpenInterestResult struct {
 
 // BalanceDetail defines the structure for a single asset's balance.
 type BalanceDetail struct {
-	Coin                  string `json:"coin"`                  // e.g., "usdt"
-	AvailableBalance      string `json:"availableBalance"`      // Available balance
-	IsolatedMargin        string `json:"isolatedMargin"`        // Frozen isolated margin
-	OpenOrderMarginFrozen string `json:"openOrderMarginFrozen"` // Frozen order margin
-	CrossedMargin         string `json:"crossedMargin"`         // Crossed Margin
-	Bonus                 string `json:"bonus"`                 // Bonus
-	Coupon                string `json:"coupon"`                // Coupon
-	WalletBalance         string `json:"walletBalance"`         // Balance
+	Coin                  string          `json:"coin"`                  // e.g., "usdt"
+	AvailableBalance      decimal.Decimal `json:"availableBalance"`      // Available balance
+	IsolatedMargin        decimal.Decimal `json:"isolatedMargin"`        // Frozen isolated margin
+	OpenOrderMarginFrozen decimal.Decimal `json:"openOrderMarginFrozen"` // Frozen order margin
+	CrossedMargin         decimal.Decimal `json:"crossedMargin"`         // Crossed Margin
+	Bonus                 decimal.Decimal `json:"bonus"`                 // Bonus
+	Coupon                decimal.Decimal `json:"coupon"`                // Coupon
+	WalletBalance         decimal.Decimal `json:"walletBalance"`         // Balance
 }
 
 // BalanceListResult defines the structure for the list of all asset balances.
@@ -387,22 +412,22 @@ type BalanceListResult struct {
 
 // CompatBalanceDetail defines the structure from the compat balance endpoint.
 type CompatBalanceDetail struct {
-	AccountID             int64  `json:"accountId"`
-	UserID                int64  `json:"userId"`
-	Coin                  string `json:"coin"`
-	UnderlyingType        int    `json:"underlyingType"` // 1: Coin-M, 2: USDT-M
-	WalletBalance         string `json:"walletBalance"`
-	OpenOrderMarginFrozen string `json:"openOrderMarginFrozen"`
-	IsolatedMargin        string `json:"isolatedMargin"`
-	CrossedMargin         string `json:"crossedMargin"`
-	Amount                string `json:"amount"`      // Net asset balance
-	TotalAmount           string `json:"totalAmount"` // Margin balance
-	ConvertBtcAmount      string `json:"convertBtcAmount"`
-	ConvertUsdtAmount     string `json:"convertUsdtAmount"`
-	Profit                string `json:"profit"`    // Realized PNL?
-	NotProfit             string `json:"notProfit"` // Unrealized PNL?
-	Bonus                 string `json:"bonus"`
-	Coupon                string `json:"coupon"`
+	AccountID             int64           `json:"accountId"`
+	UserID                int64           `json:"userId"`
+	Coin                  string          `json:"coin"`
+	UnderlyingType        int             `json:"underlyingType"` // 1: Coin-M, 2: USDT-M
+	WalletBalance         decimal.Decimal `json:"walletBalance"`
+	OpenOrderMarginFrozen decimal.Decimal `json:"openOrderMarginFrozen"`
+	IsolatedMargin        decimal.Decimal `json:"isolatedMargin"`
+	CrossedMargin         decimal.Decimal `json:"crossedMargin"`
+	Amount                decimal.Decimal `json:"amount"`      // Net asset balance
+	TotalAmount           decimal.Decimal `json:"totalAmount"` // Margin balance
+	ConvertBtcAmount      decimal.Decimal `json:"convertBtcAmount"`
+	ConvertUsdtAmount     decimal.Decimal `json:"convertUsdtAmount"`
+	Profit                decimal.Decimal `json:"profit"`    // Realized PNL?
+	NotProfit             decimal.Decimal `json:"notProfit"` // Unrealized PNL?
+	Bonus                 decimal.Decimal `json:"bonus"`
+	Coupon                decimal.Decimal `json:"coupon"`
 }
 
 // CompatBalanceListResult defines the structure for the compat balance list response.
@@ -447,14 +472,14 @@ type GetBalanceResult struct {
 
 // BalanceBillDetail defines the structure for a single balance bill entry.
 type BalanceBillDetail struct {
-	AfterAmount string `json:"afterAmount"` // Balance after change
-	Amount      string `json:"amount"`      // Quantity
-	Coin        string `json:"coin"`        // Currency
-	CreatedTime int64  `json:"createdTime"` // Time (ms)
-	ID          int64  `json:"id"`          // id
-	Side        string `json:"side"`        // ADD:transfer in;SUB:transfer out
-	Symbol      string `json:"symbol"`      // Trading pair
-	Type        string `json:"type"`        // EXCHANGE:transfer;CLOSE_POSITION:Offset profit and loss;TAKE_OVER:position takeover;QIANG_PING_MANAGER:Liquidation management fee (fee);FUND:Fund Fee;FEE:Fee(Open position, liquidation, Forced liquidation);ADL:Adl;TAKE_OVER:position takeover;MERGE:Position Merge
+	AfterAmount decimal.Decimal `json:"afterAmount"` // Balance after change
+	Amount      decimal.Decimal `json:"amount"`      // Quantity
+	Coin        string          `json:"coin"`        // Currency
+	CreatedTime int64           `json:"createdTime"` // Time (ms)
+	ID          int64           `json:"id"`          // id
+	Side        string          `json:"side"`        // ADD:transfer in;SUB:transfer out
+	Symbol      string          `json:"symbol"`      // Trading pair
+	Type        string          `json:"type"`        // EXCHANGE:transfer;CLOSE_POSITION:Offset profit and loss;TAKE_OVER:position takeover;QIANG_PING_MANAGER:Liquidation management fee (fee);FUND:Fund Fee;FEE:Fee(Open position, liquidation, Forced liquidation);ADL:Adl;TAKE_OVER:position takeover;MERGE:Position Merge
 }
 
 // GetBalanceBillsResult defines the structure for the balance bills response.
@@ -469,12 +494,12 @@ type GetBalanceBillsResult struct {
 
 // UserFundingRateDetail defines the structure for user funding rate entries.
 type UserFundingRateDetail struct {
-	Cast         string `json:"cast"`         // Fund fee
-	Coin         string `json:"coin"`         // Currency
-	CreatedTime  int64  `json:"createdTime"`  // Time (ms)
-	ID           int64  `json:"id"`           // id
-	PositionSide string `json:"positionSide"` // Direction
-	Symbol       string `json:"symbol"`       // Trading pair
+	Cast         decimal.Decimal `json:"cast"`         // Fund fee
+	Coin         string          `json:"coin"`         // Currency
+	CreatedTime  int64           `json:"createdTime"`  // Time (ms)
+	ID           int64           `json:"id"`           // id
+	PositionSide string          `json:"positionSide"` // Direction
+	Symbol       string          `json:"symbol"`       // Trading pair
 }
 
 // GetUserFundingRateListResult defines the structure for the user funding fees response.
@@ -489,28 +514,28 @@ type GetUserFundingRateListResult struct {
 
 // PositionDetail defines the structure for a single open position.
 type PositionDetail struct {
-	AutoMargin            bool    `json:"autoMargin"`            // Whether to automatically call margin
-	AvailableCloseSize    string  `json:"availableCloseSize"`    // Available quantity (Cont)
-	BreakPrice            string  `json:"breakPrice"`            // Blowout price (Liquidation price?)
-	CalMarkPrice          string  `json:"calMarkPrice"`          // Calculated mark price
-	CloseOrderSize        string  `json:"closeOrderSize"`        // Quantity of open order (Cont)
-	ContractType          string  `json:"contractType"`          // Contract Types: PERPETUAL (Perpetual Contract), PREDICT (Predict Contract)
-	EntryPrice            string  `json:"entryPrice"`            // Average opening price
-	FloatingPL            string  `json:"floatingPL"`            // Unrealized profit or loss
-	IsolatedMargin        string  `json:"isolatedMargin"`        // Warehouse-by-warehouse margin
-	Leverage              int     `json:"leverage"`              // Leverage ratio (use int based on response example)
-	OpenOrderMarginFrozen string  `json:"openOrderMarginFrozen"` // Occupation of deposit for opening order
-	OpenOrderSize         string  `json:"openOrderSize"`         // Opening warehouse orders occupied (Not in /list response?)
-	PositionSide          string  `json:"positionSide"`          // Position direction
-	PositionSize          string  `json:"positionSize"`          // Position quantity (Cont)
-	PositionType          string  `json:"positionType"`          // Position type: CROSSED (full position); ISOLATED (warehouse by warehouse)
-	ProfitID              *int64  `json:"profitId"`              // Take profit and stop loss id (nullable)
-	RealizedProfit        string  `json:"realizedProfit"`        // Realized profit and loss
-	Symbol                string  `json:"symbol"`                // trading pair
-	TriggerPriceType      *string `json:"triggerPriceType"`      // Trigger price type (nullable)
-	TriggerProfitPrice    *string `json:"triggerProfitPrice"`    // Take profit trigger price (nullable)
-	TriggerStopPrice      *string `json:"triggerStopPrice"`      // Stop loss trigger price (nullable)
-	WelfareAccount        *bool   `json:"welfareAccount"`        // Nullable?
+	AutoMargin            bool             `json:"autoMargin"`            // Whether to automatically call margin
+	AvailableCloseSize    decimal.Decimal  `json:"availableCloseSize"`    // Available quantity (Cont)
+	BreakPrice            decimal.Decimal  `json:"breakPrice"`            // Blowout price (Liquidation price?)
+	CalMarkPrice          decimal.Decimal  `json:"calMarkPrice"`          // Calculated mark price
+	CloseOrderSize        decimal.Decimal  `json:"closeOrderSize"`        // Quantity of open order (Cont)
+	ContractType          string           `json:"contractType"`          // Contract Types: PERPETUAL (Perpetual Contract), PREDICT (Predict Contract)
+	EntryPrice            decimal.Decimal  `json:"entryPrice"`            // Average opening price
+	FloatingPL            decimal.Decimal  `json:"floatingPL"`            // Unrealized profit or loss
+	IsolatedMargin        decimal.Decimal  `json:"isolatedMargin"`        // Warehouse-by-warehouse margin
+	Leverage              int              `json:"leverage"`              // Leverage ratio (use int based on response example)
+	OpenOrderMarginFrozen decimal.Decimal  `json:"openOrderMarginFrozen"` // Occupation of deposit for opening order
+	OpenOrderSize         decimal.Decimal  `json:"openOrderSize"`         // Opening warehouse orders occupied (Not in /list response?)
+	PositionSide          string           `json:"positionSide"`          // Position direction
+	PositionSize          decimal.Decimal  `json:"positionSize"`          // Position quantity (Cont)
+	PositionType          string           `json:"positionType"`          // Position type: CROSSED (full position); ISOLATED (warehouse by warehouse)
+	ProfitID              *int64           `json:"profitId"`              // Take profit and stop loss id (nullable)
+	RealizedProfit        decimal.Decimal  `json:"realizedProfit"`        // Realized profit and loss
+	Symbol                string           `json:"symbol"`                // trading pair
+	TriggerPriceType      *string          `json:"triggerPriceType"`      // Trigger price type (nullable)
+	TriggerProfitPrice    *decimal.Decimal `json:"triggerProfitPrice"`    // Take profit trigger price (nullable)
+	TriggerStopPrice      *decimal.Decimal `json:"triggerStopPrice"`      // Stop loss trigger price (nullable)
+	WelfareAccount        *bool            `json:"welfareAccount"`        // Nullable?
 }
 
 // GetPositionsResult defines the structure for the get positions response.
@@ -523,8 +548,8 @@ type GetPositionsResult struct {
 type StepRateResult struct {
 	CommonResponse
 	Result struct {
-		MakerFee string `json:"makerFee"`
-		TakerFee string `json:"takerFee"`
+		MakerFee decimal.Decimal `json:"makerFee"`
+		TakerFee decimal.Decimal `json:"takerFee"`
 	} `json:"result"`
 }
 
@@ -585,16 +610,16 @@ type ChangePositionTypeResult struct {
 
 // BreakPositionDetail defines the structure for margin call info.
 type BreakPositionDetail struct {
-	BreakPrice     string `json:"breakPrice"`     // Margin call price. 0 means no margin call
-	CalMarkPrice   string `json:"calMarkPrice"`   // Mark price
-	ContractType   string `json:"contractType"`   // Futures type: PERPETUAL;PREDICT
-	EntryPrice     string `json:"entryPrice"`     // Open position average price
-	IsolatedMargin string `json:"isolatedMargin"` // Isolated Margin
-	Leverage       int    `json:"leverage"`       // Leverage
-	PositionSide   string `json:"positionSide"`   // Position side:LONG;SHORT
-	PositionSize   string `json:"positionSize"`   // Position quantity (Cont)
-	PositionType   string `json:"positionType"`   // Position type:CROSSED;ISOLATED
-	Symbol         string `json:"symbol"`         // Symbol
+	BreakPrice     decimal.Decimal `json:"breakPrice"`     // Margin call price. 0 means no margin call
+	CalMarkPrice   decimal.Decimal `json:"calMarkPrice"`   // Mark price
+	ContractType   string          `json:"contractType"`   // Futures type: PERPETUAL;PREDICT
+	EntryPrice     decimal.Decimal `json:"entryPrice"`     // Open position average price
+	IsolatedMargin decimal.Decimal `json:"isolatedMargin"` // Isolated Margin
+	Leverage       int             `json:"leverage"`       // Leverage
+	PositionSide   string          `json:"positionSide"`   // Position side:LONG;SHORT
+	PositionSize   decimal.Decimal `json:"positionSize"`   // Position quantity (Cont)
+	PositionType   string          `json:"positionType"`   // Position type:CROSSED;ISOLATED
+	Symbol         string          `json:"symbol"`         // Symbol
 }
 
 // BreakListResult defines the structure for the margin call list response.
@@ -608,31 +633,31 @@ type BreakListResult struct {
 // PlaceOrderResult defines the structure for the place order response.
 type PlaceOrderResult struct {
 	CommonResponse
-	Result map[string]interface{} `json:"result"` // Empty object {} on success
+	Result string `json:"result"` // Order ID as string
 }
 
 // OrderDetail defines the structure for detailed order information.
 type OrderDetail struct {
-	ClientOrderID      *string `json:"clientOrderId"`      // Client order ID (nullable)
-	AvgPrice           string  `json:"avgPrice"`           // Average price
-	ClosePosition      *bool   `json:"closePosition"`      // Whether to close all when order condition is triggered (nullable)
-	CloseProfit        string  `json:"closeProfit"`        // Offset profit and loss
-	CreatedTime        int64   `json:"createdTime"`        // Create time (ms)
-	ExecutedQty        string  `json:"executedQty"`        // Volume (Cont)
-	ForceClose         *bool   `json:"forceClose"`         // Is it a liquidation order (nullable)
-	MarginFrozen       string  `json:"marginFrozen"`       // Occupied margin
-	OrderID            int64   `json:"orderId"`            // Order ID
-	OrderSide          string  `json:"orderSide"`          // Order side
-	OrderType          string  `json:"orderType"`          // Order type
-	OrigQty            string  `json:"origQty"`            // Quantity (Cont)
-	PositionSide       string  `json:"positionSide"`       // Position side
-	Price              string  `json:"price"`              // Order price
-	SourceID           *int64  `json:"sourceId"`           // Triggering conditions ID (nullable)
-	State              string  `json:"state"`              // Order state:NEW,PARTIALLY_FILLED,PARTIALLY_CANCELED,FILLED,CANCELED,REJECTED,EXPIRED
-	Symbol             string  `json:"symbol"`             // Trading pair
-	TimeInForce        string  `json:"timeInForce"`        // Valid type
-	TriggerProfitPrice *string `json:"triggerProfitPrice"` // TP trigger price (nullable)
-	TriggerStopPrice   *string `json:"triggerStopPrice"`   // SL trigger price (nullable)
+	ClientOrderID      *string          `json:"clientOrderId"`      // Client order ID (nullable)
+	AvgPrice           decimal.Decimal  `json:"avgPrice"`           // Average price
+	ClosePosition      *bool            `json:"closePosition"`      // Whether to close all when order condition is triggered (nullable)
+	CloseProfit        decimal.Decimal  `json:"closeProfit"`        // Offset profit and loss
+	CreatedTime        int64            `json:"createdTime"`        // Create time (ms)
+	ExecutedQty        decimal.Decimal  `json:"executedQty"`        // Volume (Cont)
+	ForceClose         *bool            `json:"forceClose"`         // Is it a liquidation order (nullable)
+	MarginFrozen       decimal.Decimal  `json:"marginFrozen"`       // Occupied margin
+	OrderID            int64            `json:"orderId"`            // Order ID
+	OrderSide          string           `json:"orderSide"`          // Order side
+	OrderType          string           `json:"orderType"`          // Order type
+	OrigQty            decimal.Decimal  `json:"origQty"`            // Quantity (Cont)
+	PositionSide       string           `json:"positionSide"`       // Position side
+	Price              decimal.Decimal  `json:"price"`              // Order price
+	SourceID           *int64           `json:"sourceId"`           // Triggering conditions ID (nullable)
+	State              string           `json:"state"`              // Order state:NEW,PARTIALLY_FILLED,PARTIALLY_CANCELED,FILLED,CANCELED,REJECTED,EXPIRED
+	Symbol             string           `json:"symbol"`             // Trading pair
+	TimeInForce        string           `json:"timeInForce"`        // Valid type
+	TriggerProfitPrice *decimal.Decimal `json:"triggerProfitPrice"` // TP trigger price (nullable)
+	TriggerStopPrice   *decimal.Decimal `json:"triggerStopPrice"`   // SL trigger price (nullable)
 }
 
 // GetOrderResult defines the structure for the get order response.
@@ -664,15 +689,15 @@ type GetHistoryListResult struct {
 
 // TradeDetail defines the structure for a single trade detail.
 type TradeDetail struct {
-	Fee        string `json:"fee"`        // Fee
-	FeeCoin    string `json:"feeCoin"`    // Currency of fee
-	OrderID    int64  `json:"orderId"`    // Order ID
-	ExecID     string `json:"execId"`     // Trade ID (string in response)
-	Price      string `json:"price"`      // Price
-	Quantity   string `json:"quantity"`   // Volume
-	Symbol     string `json:"symbol"`     // Trading pair
-	Timestamp  int64  `json:"timestamp"`  // Time (ms)
-	TakerMaker string `json:"takerMaker"` // TAKER or MAKER
+	Fee        decimal.Decimal `json:"fee"`        // Fee
+	FeeCoin    string          `json:"feeCoin"`    // Currency of fee
+	OrderID    int64           `json:"orderId"`    // Order ID
+	ExecID     string          `json:"execId"`     // Trade ID (string in response)
+	Price      decimal.Decimal `json:"price"`      // Price
+	Quantity   decimal.Decimal `json:"quantity"`   // Volume
+	Symbol     string          `json:"symbol"`     // Trading pair
+	Timestamp  int64           `json:"timestamp"`  // Time (ms)
+	TakerMaker string          `json:"takerMaker"` // TAKER or MAKER
 }
 
 // GetTradeListResult defines the structure for the trade list response.
@@ -712,22 +737,22 @@ type CancelBatchOrderResult struct {
 
 // PlanOrderDetail defines the structure for trigger orders.
 type PlanOrderDetail struct {
-	ClientOrderID    *string `json:"clientOrderId"`    // Client order ID (nullable)
-	ClosePosition    *bool   `json:"closePosition"`    // Whether triggered to close all (nullable)
-	CreatedTime      int64   `json:"createdTime"`      // Create time (ms)
-	EntrustID        int64   `json:"entrustId"`        // Order ID
-	EntrustType      string  `json:"entrustType"`      // Order type
-	MarketOrderLevel *int    `json:"marketOrderLevel"` // Best market price (nullable?)
-	OrderSide        string  `json:"orderSide"`        // Order side
-	Ordinary         *bool   `json:"ordinary"`         // Nullable?
-	OrigQty          string  `json:"origQty"`          // Quantity (Cont)
-	PositionSide     string  `json:"positionSide"`     // Position side
-	Price            string  `json:"price"`            // Order price
-	State            string  `json:"state"`            // Order state: NOT_TRIGGERED,TRIGGERING,TRIGGERED,USER_REVOCATION,PLATFORM_REVOCATION,EXPIRED
-	StopPrice        string  `json:"stopPrice"`        // Trigger price
-	Symbol           string  `json:"symbol"`           // Trading pair
-	TimeInForce      string  `json:"timeInForce"`      // Valid way
-	TriggerPriceType string  `json:"triggerPriceType"` // Trigger price type
+	ClientOrderID    *string         `json:"clientOrderId"`    // Client order ID (nullable)
+	ClosePosition    *bool           `json:"closePosition"`    // Whether triggered to close all (nullable)
+	CreatedTime      int64           `json:"createdTime"`      // Create time (ms)
+	EntrustID        int64           `json:"entrustId"`        // Order ID
+	EntrustType      string          `json:"entrustType"`      // Order type
+	MarketOrderLevel *int            `json:"marketOrderLevel"` // Best market price (nullable?)
+	OrderSide        string          `json:"orderSide"`        // Order side
+	Ordinary         *bool           `json:"ordinary"`         // Nullable?
+	OrigQty          decimal.Decimal `json:"origQty"`          // Quantity (Cont)
+	PositionSide     string          `json:"positionSide"`     // Position side
+	Price            decimal.Decimal `json:"price"`            // Order price
+	State            string          `json:"state"`            // Order state: NOT_TRIGGERED,TRIGGERING,TRIGGERED,USER_REVOCATION,PLATFORM_REVOCATION,EXPIRED
+	StopPrice        decimal.Decimal `json:"stopPrice"`        // Trigger price
+	Symbol           string          `json:"symbol"`           // Trading pair
+	TimeInForce      string          `json:"timeInForce"`      // Valid way
+	TriggerPriceType string          `json:"triggerPriceType"` // Trigger price type
 }
 
 // CreatePlanOrderResult defines the structure for creating trigger orders.
@@ -777,18 +802,18 @@ type GetPlanHistoryListResult struct {
 
 // ProfitStopDetail defines the structure for stop limit orders.
 type ProfitStopDetail struct {
-	CreatedTime        int64  `json:"createdTime"`        // Time (ms)
-	EntryPrice         string `json:"entryPrice"`         // Open position average price
-	ExecutedQty        string `json:"executedQty"`        // Actual transaction
-	IsolatedMargin     string `json:"isolatedMargin"`     // Isolated Margin
-	OrigQty            string `json:"origQty"`            // Quantity (Cont)
-	PositionSide       string `json:"positionSide"`       // Position side
-	PositionSize       string `json:"positionSize"`       // Position quantity (Cont)
-	ProfitID           int64  `json:"profitId"`           // Order ID
-	State              string `json:"state"`              // Order state: NOT_TRIGGERED,TRIGGERING,TRIGGERED,USER_REVOCATION,PLATFORM_REVOCATION,EXPIRED
-	Symbol             string `json:"symbol"`             // Trading pair
-	TriggerProfitPrice string `json:"triggerProfitPrice"` // Stop profit price
-	TriggerStopPrice   string `json:"triggerStopPrice"`   // Stop loss price
+	CreatedTime        int64           `json:"createdTime"`        // Time (ms)
+	EntryPrice         decimal.Decimal `json:"entryPrice"`         // Open position average price
+	ExecutedQty        decimal.Decimal `json:"executedQty"`        // Actual transaction
+	IsolatedMargin     decimal.Decimal `json:"isolatedMargin"`     // Isolated Margin
+	OrigQty            decimal.Decimal `json:"origQty"`            // Quantity (Cont)
+	PositionSide       string          `json:"positionSide"`       // Position side
+	PositionSize       decimal.Decimal `json:"positionSize"`       // Position quantity (Cont)
+	ProfitID           int64           `json:"profitId"`           // Order ID
+	State              string          `json:"state"`              // Order state: NOT_TRIGGERED,TRIGGERING,TRIGGERED,USER_REVOCATION,PLATFORM_REVOCATION,EXPIRED
+	Symbol             string          `json:"symbol"`             // Trading pair
+	TriggerProfitPrice decimal.Decimal `json:"triggerProfitPrice"` // Stop profit price
+	TriggerStopPrice   decimal.Decimal `json:"triggerStopPrice"`   // Stop loss price
 }
 
 // CreateProfitStopResult defines the structure for creating stop limit orders.
@@ -834,26 +859,26 @@ type UpdateProfitStopResult struct {
 
 // TrackOrderDetail defines the structure for track orders.
 type TrackOrderDetail struct {
-	ActivationPrice  string `json:"activationPrice"`  // Activation price
-	AvgPrice         string `json:"avgPrice"`         // Average price
-	Callback         string `json:"callback"`         // Callback range configuration 1:PROPORTION 2:FIXED
-	CallbackVal      string `json:"callbackVal"`      // Callback value (use string)
-	ConfigActivation bool   `json:"configActivation"` // Whether to configure activation price
-	CreatedTime      int64  `json:"createdTime"`      // Create time (ms)
-	CurrentPrice     string `json:"currentPrice"`     // Real-time price
-	Desc             string `json:"desc"`             // Describe
-	ExecutedQty      string `json:"executedQty"`      // Actual transaction quantity
-	OrderSide        string `json:"orderSide"`        // Order side
-	Ordinary         bool   `json:"ordinary"`
-	OrigQty          string `json:"origQty"`          // Quantity (Cont)
-	PositionSide     string `json:"positionSide"`     // Position side
-	Price            string `json:"price"`            // Order price
-	State            string `json:"state"`            // Order state: NOT_ACTIVATION,NOT_TRIGGERED,TRIGGERING,TRIGGERED,USER_REVOCATION,PLATFORM_REVOCATION,EXPIRED,DELEGATION_FAILED
-	StopPrice        string `json:"stopPrice"`        // Trigger price
-	Symbol           string `json:"symbol"`           // Symbol
-	TrackID          int64  `json:"trackId"`          // Track id
-	TriggerPriceType string `json:"triggerPriceType"` // Trigger price type
-	UpdatedTime      int64  `json:"updatedTime"`      // Update time (ms)
+	ActivationPrice  decimal.Decimal `json:"activationPrice"`  // Activation price
+	AvgPrice         decimal.Decimal `json:"avgPrice"`         // Average price
+	Callback         string          `json:"callback"`         // Callback range configuration 1:PROPORTION 2:FIXED
+	CallbackVal      decimal.Decimal `json:"callbackVal"`      // Callback value
+	ConfigActivation bool            `json:"configActivation"` // Whether to configure activation price
+	CreatedTime      int64           `json:"createdTime"`      // Create time (ms)
+	CurrentPrice     decimal.Decimal `json:"currentPrice"`     // Real-time price
+	Desc             string          `json:"desc"`             // Describe
+	ExecutedQty      decimal.Decimal `json:"executedQty"`      // Actual transaction quantity
+	OrderSide        string          `json:"orderSide"`        // Order side
+	Ordinary         bool            `json:"ordinary"`
+	OrigQty          decimal.Decimal `json:"origQty"`          // Quantity (Cont)
+	PositionSide     string          `json:"positionSide"`     // Position side
+	Price            decimal.Decimal `json:"price"`            // Order price
+	State            string          `json:"state"`            // Order state: NOT_ACTIVATION,NOT_TRIGGERED,TRIGGERING,TRIGGERED,USER_REVOCATION,PLATFORM_REVOCATION,EXPIRED,DELEGATION_FAILED
+	StopPrice        decimal.Decimal `json:"stopPrice"`        // Trigger price
+	Symbol           string          `json:"symbol"`           // Symbol
+	TrackID          int64           `json:"trackId"`          // Track id
+	TriggerPriceType string          `json:"triggerPriceType"` // Trigger price type
+	UpdatedTime      int64           `json:"updatedTime"`      // Update time (ms)
 }
 
 // CreateTrackOrderResult defines the structure for creating track orders.
@@ -900,3 +925,16 @@ type GetTrackHistoryListResult struct {
 		Items   []TrackOrderDetail `json:"items"`
 	} `json:"result"`
 }
+
+// --- WebSocket Structs ---
+
+// DepthUpdate defines an incremental order book update pushed on depth_update@{symbol}.
+type DepthUpdate struct {
+	Symbol        string       // Trading pair
+	FirstUpdateID int64        // First update ID in this event
+	UpdateID      int64        // Last update ID in this event
+	PrevUpdateID  int64        // Last update ID of the previous event (0 if not provided)
+	Asks          []DepthEntry // Changed ask levels [price, quantity], a zero quantity removes the level
+	Bids          []DepthEntry // Changed bid levels [price, quantity], a zero quantity removes the level
+	Time          int64        // Timestamp (ms)
+}
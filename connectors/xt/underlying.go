@@ -0,0 +1,250 @@
+package xt
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/neqin/futures/decimal"
+)
+
+// UnderlyingType identifies the margin type of an XT contract. USDT-M and
+// COIN-M contracts are served by different hosts (see SetUsdtBaseURL and
+// SetCoinBaseURL), and order, position and balance IDs are only valid on the
+// host that issued them.
+type UnderlyingType string
+
+const (
+	USDTMargined UnderlyingType = "U_BASED"    // Linear contracts margined and settled in USDT (fapi.xt.com)
+	CoinMargined UnderlyingType = "COIN_BASED" // Inverse contracts margined and settled in the base coin (dapi.xt.com)
+)
+
+// marketsReloadInterval limits how often a missing symbol triggers a reload of the symbol lists.
+const marketsReloadInterval = time.Minute
+
+// ParseUnderlyingType maps an underlyingType value reported by XT ("U_BASED",
+// "COIN_BASED", "USDT-M", "Coin-M", ...) onto USDTMargined or CoinMargined.
+func ParseUnderlyingType(s string) UnderlyingType {
+	if strings.Contains(strings.ToUpper(s), "COIN") {
+		return CoinMargined
+	}
+	return USDTMargined
+}
+
+// IsCoinMargined reports whether the contract is coin-margined (inverse).
+func (c *Contract) IsCoinMargined() bool {
+	return ParseUnderlyingType(c.UnderlyingType) == CoinMargined
+}
+
+// BaseQuantity returns the amount of base coin represented by qty contracts at
+// price. A USDT-M contract is ContractSize units of the base coin; a COIN-M
+// contract is ContractSize USD of face value, so its base amount depends on
+// the price and is rounded to BaseCoinPrecision (8 if unset).
+func (c *Contract) BaseQuantity(qty, price decimal.Decimal) decimal.Decimal {
+	if !c.IsCoinMargined() {
+		return qty.Mul(c.ContractSize)
+	}
+	if price.IsZero() {
+		return decimal.Zero
+	}
+	return qty.Mul(c.ContractSize).Div(price, c.basePrecision())
+}
+
+// QuoteValue returns the value of qty contracts at price in the quote
+// currency (USDT or USD).
+func (c *Contract) QuoteValue(qty, price decimal.Decimal) decimal.Decimal {
+	if c.IsCoinMargined() {
+		return qty.Mul(c.ContractSize)
+	}
+	return qty.Mul(c.ContractSize).Mul(price)
+}
+
+// SettleValue returns the value of qty contracts at price in the settlement
+// currency, which is the currency margin, fees and PnL are booked in: USDT for
+// USDT-M contracts and the base coin for COIN-M contracts.
+func (c *Contract) SettleValue(qty, price decimal.Decimal) decimal.Decimal {
+	if c.IsCoinMargined() {
+		return c.BaseQuantity(qty, price)
+	}
+	return c.QuoteValue(qty, price)
+}
+
+// Contracts returns the number of whole contracts closest to, but not more
+// than, baseQty units of the base coin at price.
+func (c *Contract) Contracts(baseQty, price decimal.Decimal) decimal.Decimal {
+	if c.ContractSize.Sign() <= 0 {
+		return decimal.Zero
+	}
+	value := baseQty
+	if c.IsCoinMargined() {
+		value = baseQty.Mul(price)
+	}
+	return value.Div(c.ContractSize, 18).Truncate(0)
+}
+
+func (c *Contract) basePrecision() int32 {
+	if c.BaseCoinPrecision > 0 {
+		return int32(c.BaseCoinPrecision)
+	}
+	return 8
+}
+
+// marketCache maps symbols to their underlying type and contract specs.
+// Entries come from the symbol lists of both hosts and from explicit
+// SetUnderlyingType overrides.
+type marketCache struct {
+	mu        sync.Mutex
+	types     map[string]UnderlyingType
+	specs     map[string]Contract
+	specTimes map[string]time.Time // When each spec was fetched
+	overrides map[string]UnderlyingType
+	loaded    map[UnderlyingType]bool // Hosts whose symbol list has been cached
+	lastLoad  time.Time               // Last lazy load attempt
+}
+
+func newMarketCache() *marketCache {
+	return &marketCache{
+		types:     make(map[string]UnderlyingType),
+		specs:     make(map[string]Contract),
+		specTimes: make(map[string]time.Time),
+		overrides: make(map[string]UnderlyingType),
+		loaded:    make(map[UnderlyingType]bool),
+	}
+}
+
+func (m *marketCache) store(contracts []Contract, host UnderlyingType) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	now := time.Now()
+	for _, c := range contracts {
+		symbol := strings.ToLower(c.Symbol)
+		m.types[symbol] = host
+		m.specs[symbol] = c
+		m.specTimes[symbol] = now
+	}
+	m.loaded[host] = true
+}
+
+// spec returns the cached contract spec of symbol and when it was fetched.
+func (m *marketCache) spec(symbol string) (Contract, time.Time, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	symbol = strings.ToLower(symbol)
+	c, ok := m.specs[symbol]
+	return c, m.specTimes[symbol], ok
+}
+
+func (m *marketCache) storeSpec(c Contract) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	symbol := strings.ToLower(c.Symbol)
+	m.specs[symbol] = c
+	m.specTimes[symbol] = time.Now()
+}
+
+func (m *marketCache) lookup(symbol string) (UnderlyingType, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if t, ok := m.overrides[symbol]; ok {
+		return t, true
+	}
+	t, ok := m.types[symbol]
+	return t, ok
+}
+
+// shouldLoad reports whether a lazy load should be attempted now and records the attempt.
+func (m *marketCache) shouldLoad() bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.loaded[USDTMargined] && m.loaded[CoinMargined] {
+		return false
+	}
+	if time.Since(m.lastLoad) < marketsReloadInterval {
+		return false
+	}
+	m.lastLoad = time.Now()
+	return true
+}
+
+// SetUnderlyingType pins symbol to an underlying type, taking precedence over
+// the cached symbol lists. Use it for symbols that are not listed yet or to
+// avoid loading the symbol lists altogether.
+func (c *Client) SetUnderlyingType(symbol string, underlying UnderlyingType) {
+	c.markets.mu.Lock()
+	defer c.markets.mu.Unlock()
+	c.markets.overrides[strings.ToLower(symbol)] = underlying
+}
+
+// WithUnderlying returns a client that sends requests without a symbol
+// (balances, account info, order lookups by ID, listen keys, ...) to the host
+// of the given underlying type. Requests for a symbol are still routed by the
+// symbol's own type. The returned client shares the HTTP client, credentials,
+// clock, rate limiter, retry policy and symbol cache with c; setters called on
+// it do not affect c.
+func (c *Client) WithUnderlying(underlying UnderlyingType) *Client {
+	clone := *c
+	clone.underlying = underlying
+	return &clone
+}
+
+// Underlying returns the underlying type used for requests without a symbol (USDTMargined by default).
+func (c *Client) Underlying() UnderlyingType {
+	return c.underlying
+}
+
+// UnderlyingTypeOf returns the underlying type of symbol: an explicit
+// SetUnderlyingType override, else the type cached from the symbol lists. The
+// lists of both hosts are loaded on the first lookup of an unknown symbol (at
+// most once a minute); if the symbol is still unknown the client's own
+// underlying type is returned.
+func (c *Client) UnderlyingTypeOf(ctx context.Context, symbol string) UnderlyingType {
+	symbol = strings.ToLower(symbol)
+	if t, ok := c.markets.lookup(symbol); ok {
+		return t
+	}
+	if c.markets.shouldLoad() {
+		c.LoadMarkets(ctx) // Errors leave the symbol unknown; fall back below
+		if t, ok := c.markets.lookup(symbol); ok {
+			return t
+		}
+	}
+	return c.underlying
+}
+
+// LoadMarkets fetches the symbol lists of the USDT-M and COIN-M hosts and
+// caches the underlying type of every symbol. It returns the joined errors of
+// the hosts that failed; the lists of the other hosts are cached regardless.
+func (c *Client) LoadMarkets(ctx context.Context) error {
+	var errs []error
+	for _, underlying := range []UnderlyingType{USDTMargined, CoinMargined} {
+		if _, err := c.WithUnderlying(underlying).GetAllMarketConfigV3(ctx); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", underlying, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// getBaseURL returns the base URL of the host serving the given underlying type.
+func (c *Client) getBaseURL(underlying UnderlyingType) string {
+	if underlying == CoinMargined {
+		return c.coinBaseURL
+	}
+	return c.usdtBaseURL
+}
+
+// defaultBaseURL returns the base URL for requests without a symbol.
+func (c *Client) defaultBaseURL() string {
+	return c.getBaseURL(c.underlying)
+}
+
+// symbolBaseURL returns the base URL of the host serving symbol. An empty
+// symbol uses the client's own underlying type.
+func (c *Client) symbolBaseURL(ctx context.Context, symbol string) string {
+	if symbol == "" {
+		return c.defaultBaseURL()
+	}
+	return c.getBaseURL(c.UnderlyingTypeOf(ctx, symbol))
+}
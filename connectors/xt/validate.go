@@ -0,0 +1,201 @@
+package xt
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/neqin/futures/decimal"
+	"github.com/neqin/futures/exchange"
+)
+
+// specsTTL is how long a cached contract spec is used for pre-trade checks
+// before it is fetched again.
+const specsTTL = 5 * time.Minute
+
+// OrderValidation configures the local checks PlaceOrder and PlaceBatchOrder
+// run against the contract specs before sending an order. Violations are
+// returned as *exchange.ValidationError.
+//
+// The checks cover the quantity step (quantityPrecision), the minimum quantity
+// (minQty), the price tick (minStepPrice, else pricePrecision), the price
+// limits (minPrice, maxPrice) and the order value (minNotional, maxNotional).
+// Specs come from the symbol lists the client already caches for host routing,
+// or from GetMarketConfig, and are refetched after a few minutes.
+type OrderValidation struct {
+	// Normalize rounds prices and quantities instead of rejecting them:
+	// quantities down to the step, buy prices down and sell prices up to the
+	// tick so the order is never more aggressive than requested, and trigger
+	// prices to the nearest tick.
+	Normalize bool
+	// PriceBands rejects limit prices outside the contract's band around the
+	// mark price (multiplierUp for buys, multiplierDown for sells). It costs
+	// one GetMarketPrice request per order.
+	PriceBands bool
+}
+
+// SetOrderValidation enables pre-trade validation of orders, or disables it
+// if v is nil (the default).
+func (c *Client) SetOrderValidation(v *OrderValidation) {
+	c.validation = v
+}
+
+// contractSpec returns the spec of symbol from the market cache, fetching it
+// with GetMarketConfig if it is missing or stale.
+func (c *Client) contractSpec(ctx context.Context, symbol string) (Contract, error) {
+	spec, fetched, ok := c.markets.spec(symbol)
+	if ok && time.Since(fetched) < specsTTL {
+		return spec, nil
+	}
+	res, err := c.GetMarketConfig(ctx, symbol)
+	if err != nil {
+		return Contract{}, fmt.Errorf("load contract spec: %w", err)
+	}
+	if res.Result.Symbol == "" {
+		return Contract{}, &exchange.ValidationError{Symbol: symbol, Field: "symbol", Reason: "is not listed"}
+	}
+	c.markets.storeSpec(res.Result)
+	return res.Result, nil
+}
+
+// validateOrder checks order against the contract spec and, with Normalize,
+// rounds its quantity and prices in place.
+func (c *Client) validateOrder(ctx context.Context, order *PlaceOrderRequest) error {
+	v := c.validation
+	spec, err := c.contractSpec(ctx, order.Symbol)
+	if err != nil {
+		return err
+	}
+	rules := symbolContract(&spec)
+	buy := order.OrderSide == "BUY"
+	invalid := func(field, format string, args ...interface{}) error {
+		return &exchange.ValidationError{Symbol: order.Symbol, Field: field, Reason: fmt.Sprintf(format, args...)}
+	}
+
+	qty, err := decimal.Parse(order.OrigQty)
+	if err != nil {
+		return invalid("origQty", "%q is not a number", order.OrigQty)
+	}
+	if qty.Sign() <= 0 {
+		return invalid("origQty", "%s must be positive", qty)
+	}
+	if !qty.IsMultipleOf(rules.LotSize) {
+		if !v.Normalize {
+			return invalid("origQty", "%s is not a multiple of the quantity step %s", qty, rules.LotSize)
+		}
+		qty = qty.FloorToTick(rules.LotSize)
+		order.OrigQty = qty.String()
+	}
+	if qty.IsZero() || qty.LessThan(rules.MinSize) {
+		return invalid("origQty", "%s is below the minimum of %s contracts", qty, rules.MinSize)
+	}
+
+	var price decimal.Decimal
+	if order.OrderType == "LIMIT" && order.Price != nil {
+		if price, err = decimal.Parse(*order.Price); err != nil {
+			return invalid("price", "%q is not a number", *order.Price)
+		}
+		if price.Sign() <= 0 {
+			return invalid("price", "%s must be positive", price)
+		}
+		if !price.IsMultipleOf(rules.TickSize) {
+			if !v.Normalize {
+				return invalid("price", "%s is not a multiple of the tick size %s", price, rules.TickSize)
+			}
+			if buy {
+				price = price.FloorToTick(rules.TickSize)
+			} else {
+				price = price.CeilToTick(rules.TickSize)
+			}
+			if price.IsZero() {
+				return invalid("price", "%s rounds to 0 with tick size %s", *order.Price, rules.TickSize)
+			}
+			s := price.String()
+			order.Price = &s
+		}
+		if spec.MinPrice != nil && spec.MinPrice.Sign() > 0 && price.LessThan(*spec.MinPrice) {
+			return invalid("price", "%s is below the minimum price %s", price, *spec.MinPrice)
+		}
+		if spec.MaxPrice != nil && spec.MaxPrice.Sign() > 0 && price.GreaterThan(*spec.MaxPrice) {
+			return invalid("price", "%s exceeds the maximum price %s", price, *spec.MaxPrice)
+		}
+	}
+	for _, trigger := range []struct {
+		field string
+		price **string
+	}{
+		{"triggerProfitPrice", &order.TriggerProfitPrice},
+		{"triggerStopPrice", &order.TriggerStopPrice},
+	} {
+		if err := normalizeTrigger(trigger.price, rules.TickSize, v.Normalize, func(format string, args ...interface{}) error {
+			return invalid(trigger.field, format, args...)
+		}); err != nil {
+			return err
+		}
+	}
+
+	// The mark price is only fetched when a check needs it.
+	limitedValue := rules.MinNotional.Sign() > 0 || rules.MaxNotional.Sign() > 0
+	needMark := (v.PriceBands && price.Sign() > 0) || (limitedValue && price.IsZero())
+	var mark decimal.Decimal
+	if needMark {
+		res, err := c.GetMarketPrice(ctx, order.Symbol)
+		if err != nil {
+			return fmt.Errorf("load mark price: %w", err)
+		}
+		mark = res.Result.Price
+	}
+
+	if v.PriceBands && price.Sign() > 0 && mark.Sign() > 0 {
+		one := decimal.NewFromInt(1)
+		if up := spec.MultiplierUp; buy && up.Sign() > 0 {
+			if limit := mark.Mul(one.Add(up)); price.GreaterThan(limit) {
+				return invalid("price", "%s is above the band limit %s (mark price %s + %s)", price, limit, mark, up)
+			}
+		}
+		if down := spec.MultiplierDown; !buy && down.Sign() > 0 {
+			if limit := mark.Mul(one.Sub(down)); price.LessThan(limit) {
+				return invalid("price", "%s is below the band limit %s (mark price %s - %s)", price, limit, mark, down)
+			}
+		}
+	}
+
+	at := price
+	if at.IsZero() {
+		at = mark
+	}
+	if limitedValue && at.Sign() > 0 {
+		value := rules.Notional(qty, at)
+		if rules.MinNotional.Sign() > 0 && value.LessThan(rules.MinNotional) {
+			return invalid("origQty", "order value %s is below the minimum of %s", value, rules.MinNotional)
+		}
+		if rules.MaxNotional.Sign() > 0 && value.GreaterThan(rules.MaxNotional) {
+			return invalid("origQty", "order value %s exceeds the maximum of %s", value, rules.MaxNotional)
+		}
+	}
+	return nil
+}
+
+// normalizeTrigger checks that the trigger price *p, if set, is a positive
+// multiple of tick, rounding it to the nearest tick if normalize is set.
+func normalizeTrigger(p **string, tick decimal.Decimal, normalize bool, invalid func(string, ...interface{}) error) error {
+	if *p == nil || **p == "" {
+		return nil
+	}
+	price, err := decimal.Parse(**p)
+	if err != nil {
+		return invalid("%q is not a number", **p)
+	}
+	if price.Sign() <= 0 {
+		return invalid("%s must be positive", price)
+	}
+	if price.IsMultipleOf(tick) {
+		return nil
+	}
+	if !normalize {
+		return invalid("%s is not a multiple of the tick size %s", price, tick)
+	}
+	s := price.RoundToTick(tick).String()
+	*p = &s
+	return nil
+}
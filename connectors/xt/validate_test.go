@@ -0,0 +1,136 @@
+package xt_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/neqin/futures/connectors/xt"
+	"github.com/neqin/futures/decimal"
+	"github.com/neqin/futures/exchange"
+)
+
+// newValidatingServer adds an eth_usdt contract with a 0.05 tick, a 0.1
+// quantity step, a minimum of 0.5 contracts, prices of 50 to 200, order
+// values of 5 to 100 USDT and a 10% band around the 100.25 mark price.
+func newValidatingServer(t *testing.T) (*xt.Client, func() xt.PlaceOrderRequest) {
+	t.Helper()
+	srv, client := newServer(t)
+	minPrice, maxPrice := decimal.NewFromInt(50), decimal.NewFromInt(200)
+	srv.AddContract(xt.Contract{
+		Symbol:            "eth_usdt",
+		ContractSize:      decimal.MustParse("0.01"),
+		PricePrecision:    2,
+		MinStepPrice:      decimal.MustParse("0.05"),
+		QuantityPrecision: 1,
+		MinQty:            decimal.MustParse("0.5"),
+		MinNotional:       decimal.NewFromInt(5),
+		MaxNotional:       decimal.NewFromInt(100),
+		MinPrice:          &minPrice,
+		MaxPrice:          &maxPrice,
+		MultiplierUp:      decimal.MustParse("0.1"),
+		MultiplierDown:    decimal.MustParse("0.1"),
+	})
+	err := srv.SetOrderBook(&exchange.OrderBook{
+		Symbol: "eth_usdt",
+		Bids:   []exchange.OrderBookLevel{{Price: 100, Size: 500}},
+		Asks:   []exchange.OrderBookLevel{{Price: 100.5, Size: 500}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// sent returns the last order the server received.
+	sent := func() xt.PlaceOrderRequest {
+		t.Helper()
+		var order xt.PlaceOrderRequest
+		reqs := srv.Requests()
+		for i := len(reqs) - 1; i >= 0; i-- {
+			if reqs[i].Method == http.MethodPost && reqs[i].Path == "/future/trade/v1/order/create" {
+				if err := json.Unmarshal(reqs[i].Body, &order); err != nil {
+					t.Fatal(err)
+				}
+				return order
+			}
+		}
+		t.Fatal("no order was sent")
+		return order
+	}
+	return client, sent
+}
+
+func TestValidateOrder(t *testing.T) {
+	normalize := xt.OrderValidation{Normalize: true}
+	bands := xt.OrderValidation{PriceBands: true}
+	tests := []struct {
+		name       string
+		validation xt.OrderValidation
+		side       string
+		qty        string
+		price      string // Market order if empty
+		stop       string // Trigger stop price, none if empty
+		field      string // Field of the expected ValidationError, "" if accepted
+		sentQty    string
+		sentPrice  string
+	}{
+		{"on tick", xt.OrderValidation{}, "BUY", "10", "100.05", "", "", "10", "100.05"},
+		{"off tick", xt.OrderValidation{}, "BUY", "10", "100.03", "", "price", "", ""},
+		{"buy rounded down", normalize, "BUY", "10", "100.03", "", "", "10", "100.00"},
+		{"sell rounded up", normalize, "SELL", "10", "100.03", "", "", "10", "100.05"},
+		{"off step", xt.OrderValidation{}, "BUY", "10.05", "100", "", "origQty", "", ""},
+		{"quantity rounded down", normalize, "BUY", "10.05", "100", "", "", "10.00", "100"},
+		{"below minimum", xt.OrderValidation{}, "BUY", "0.4", "100", "", "origQty", "", ""},
+		{"below minimum after rounding", normalize, "BUY", "0.49", "100", "", "origQty", "", ""},
+		{"below minimum price", xt.OrderValidation{}, "BUY", "10", "40", "", "price", "", ""},
+		{"above maximum price", xt.OrderValidation{}, "SELL", "10", "250", "", "price", "", ""},
+		{"below minimum value", xt.OrderValidation{}, "BUY", "4", "100", "", "origQty", "", ""},
+		{"above maximum value", xt.OrderValidation{}, "SELL", "101", "100", "", "origQty", "", ""},
+		{"market value at the mark price", xt.OrderValidation{}, "BUY", "4.9", "", "", "origQty", "", ""},
+		{"market order", xt.OrderValidation{}, "BUY", "5", "", "", "", "5", ""},
+		{"buy inside the band", bands, "BUY", "10", "110.25", "", "", "10", "110.25"},
+		{"buy above the band", bands, "BUY", "10", "110.3", "", "price", "", ""},
+		{"sell below the band", bands, "SELL", "10", "90.2", "", "price", "", ""},
+		{"sell above the mark price", bands, "SELL", "10", "110.3", "", "", "10", "110.3"},
+		{"trigger off tick", xt.OrderValidation{}, "BUY", "10", "100", "95.03", "triggerStopPrice", "", ""},
+		{"trigger rounded", normalize, "BUY", "10", "100", "95.03", "", "10", "100"},
+	}
+	for _, tt := range tests {
+		client, sent := newValidatingServer(t)
+		client.SetOrderValidation(&tt.validation)
+		req := xt.PlaceOrderRequest{Symbol: "eth_usdt", OrderSide: tt.side, OrderType: "MARKET", OrigQty: tt.qty, PositionSide: "LONG"}
+		if tt.side == "SELL" {
+			req.PositionSide = "SHORT"
+		}
+		if tt.price != "" {
+			req.OrderType, req.Price = "LIMIT", ptr(tt.price)
+		}
+		if tt.stop != "" {
+			req.TriggerStopPrice = ptr(tt.stop)
+		}
+		_, err := client.PlaceOrder(context.Background(), req)
+
+		var verr *exchange.ValidationError
+		if tt.field != "" {
+			if !errors.As(err, &verr) || verr.Field != tt.field || !errors.Is(err, exchange.ErrInvalidParameter) {
+				t.Errorf("%s: got %v, want a %s ValidationError", tt.name, err, tt.field)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: %v", tt.name, err)
+			continue
+		}
+		order := sent()
+		var price string
+		if order.Price != nil {
+			price = *order.Price
+		}
+		if order.OrigQty != tt.sentQty || price != tt.sentPrice {
+			t.Errorf("%s: sent %s at %q, want %s at %q", tt.name, order.OrigQty, price, tt.sentQty, tt.sentPrice)
+		}
+		if tt.stop != "" && *order.TriggerStopPrice != "95.05" {
+			t.Errorf("%s: sent trigger %s, want 95.05", tt.name, *order.TriggerStopPrice)
+		}
+	}
+}
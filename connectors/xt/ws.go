@@ -0,0 +1,456 @@
+package xt
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/neqin/futures/wsconn"
+)
+
+const (
+	defaultWSMarketURL     = "wss://fstream.xt.com/ws/market"
+	defaultWSUserURL       = "wss://fstream.xt.com/ws/user"
+	defaultCoinWSMarketURL = "wss://dstream.xt.com/ws/market"
+	defaultCoinWSUserURL   = "wss://dstream.xt.com/ws/user"
+	wsPingInterval         = 20 * time.Second
+	wsReadTimeout          = 60 * time.Second
+	wsWriteTimeout         = 10 * time.Second
+	wsMaxReconnectDelay    = 30 * time.Second
+	wsSubscriptionBuffer   = 256
+)
+
+var (
+	// ErrWSClosed is returned when using a WSClient after Close has been called.
+	ErrWSClosed = errors.New("xt websocket client closed")
+	// ErrWSBufferFull is reported on Errors when a push is dropped because
+	// its subscription's consumer has fallen behind.
+	ErrWSBufferFull = wsconn.ErrBufferFull
+)
+
+// wsRequest is a client-to-server frame on the XT futures stream.
+type wsRequest struct {
+	Method string   `json:"method"` // SUBSCRIBE or UNSUBSCRIBE
+	Params []string `json:"params"`
+	ID     string   `json:"id"`
+}
+
+// wsMessage is a server-to-client frame: either a request acknowledgement
+// (ID, Code, Msg) or a topic push (Topic, Event, Data).
+type wsMessage struct {
+	ID    string          `json:"id"`
+	Code  *int            `json:"code"`
+	Msg   string          `json:"msg"`
+	Topic string          `json:"topic"`
+	Event string          `json:"event"`
+	Data  json.RawMessage `json:"data"`
+}
+
+// WSError is an error returned by the XT websocket server.
+type WSError struct {
+	Code int    `json:"code"`
+	Msg  string `json:"msg"`
+}
+
+// Error returns the error message string.
+func (e *WSError) Error() string {
+	return fmt.Sprintf("XT WS Error: code=%d, msg=%s", e.Code, e.Msg)
+}
+
+// wsSubscription tracks an active topic subscription so it can be
+// dispatched to and replayed after a reconnect.
+type wsSubscription struct {
+	topic   string // Topic parameter, e.g. "kline@btc_usdt,1m" or "order" for user topics
+	event   string // Push event the subscription receives, when it differs from topic
+	private bool   // User topic, sent as "<topic>@<listenKey>"
+	raw     chan json.RawMessage
+	done    chan struct{}
+}
+
+// WSClient is an XT futures websocket client. A single connection is shared
+// by all subscriptions; it is established lazily on the first subscription
+// and transparently re-established (with all subscriptions replayed) if it
+// drops.
+type WSClient struct {
+	ws   *wsconn.Conn
+	rest *Client // Used to obtain listen keys for user topics, nil for market clients
+
+	mu        sync.Mutex
+	subs      map[*wsSubscription]struct{}
+	pending   map[string]chan *wsMessage
+	listenKey string
+	closed    bool
+
+	nextID atomic.Int64
+}
+
+func newWSClient(url string, rest *Client, dialer *websocket.Dialer) *WSClient {
+	w := &WSClient{
+		rest:    rest,
+		subs:    make(map[*wsSubscription]struct{}),
+		pending: make(map[string]chan *wsMessage),
+	}
+	w.ws = wsconn.New(url, wsconn.Config{
+		Dialer:            dialer,
+		PingInterval:      wsPingInterval,
+		ReadTimeout:       wsReadTimeout,
+		WriteTimeout:      wsWriteTimeout,
+		MaxReconnectDelay: wsMaxReconnectDelay,
+		Ping:              func() []byte { return []byte("ping") }, // XT answers a plain-text "pong"
+		Handle:            w.handle,
+		Resubscribe:       w.resubscribe,
+	})
+	return w
+}
+
+// SetURL allows overriding the default websocket endpoint URL.
+// Must be called before the first subscription.
+func (w *WSClient) SetURL(url string) {
+	w.ws.SetURL(strings.TrimSuffix(url, "/"))
+}
+
+// Errors returns a channel of asynchronous errors (decode failures, reconnects,
+// listen key refresh failures, pushes dropped with ErrWSBufferFull for a
+// consumer that falls behind). Errors are dropped if the channel is not drained.
+func (w *WSClient) Errors() <-chan error {
+	return w.ws.Errors()
+}
+
+// Close terminates the connection and ends all subscriptions.
+func (w *WSClient) Close() error {
+	w.mu.Lock()
+	if w.closed {
+		w.mu.Unlock()
+		return nil
+	}
+	w.closed = true
+	for sub := range w.subs {
+		close(sub.done)
+		delete(w.subs, sub)
+	}
+	w.mu.Unlock()
+	return w.ws.Close()
+}
+
+// param returns the wire parameter for a subscription using the given listen key.
+func (sub *wsSubscription) param(listenKey string) string {
+	if sub.private {
+		return sub.topic + "@" + listenKey
+	}
+	return sub.topic
+}
+
+// request sends a SUBSCRIBE/UNSUBSCRIBE for params and waits for the server's acknowledgement.
+func (w *WSClient) request(ctx context.Context, conn *websocket.Conn, method string, params ...string) error {
+	req := wsRequest{
+		Method: method,
+		Params: params,
+		ID:     strconv.FormatInt(w.nextID.Add(1), 10),
+	}
+	data, err := json.Marshal(req)
+	if err != nil {
+		return fmt.Errorf("failed to marshal %s request: %w", method, err)
+	}
+
+	ack := make(chan *wsMessage, 1)
+	w.mu.Lock()
+	w.pending[req.ID] = ack
+	w.mu.Unlock()
+	defer func() {
+		w.mu.Lock()
+		delete(w.pending, req.ID)
+		w.mu.Unlock()
+	}()
+
+	if err := w.ws.Write(conn, data); err != nil {
+		return fmt.Errorf("failed to send %s %v: %w", method, params, err)
+	}
+
+	select {
+	case msg := <-ack:
+		if msg.Code != nil && *msg.Code != 0 {
+			return fmt.Errorf("%s %v rejected: %w", method, params, &WSError{Code: *msg.Code, Msg: msg.Msg})
+		}
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-w.ws.Done():
+		return ErrWSClosed
+	}
+}
+
+// subscribe registers a subscription and sends the subscribe request.
+func (w *WSClient) subscribe(ctx context.Context, sub *wsSubscription) error {
+	if sub.private {
+		if err := w.ensureListenKey(ctx); err != nil {
+			return err
+		}
+	}
+	if err := w.ws.Connect(ctx); err != nil {
+		if errors.Is(err, wsconn.ErrClosed) {
+			return ErrWSClosed
+		}
+		return err
+	}
+	w.mu.Lock()
+	if w.closed {
+		w.mu.Unlock()
+		return ErrWSClosed
+	}
+	if other := w.conflictLocked(sub); other != nil {
+		w.mu.Unlock()
+		return fmt.Errorf("cannot subscribe to %s while %s is active: their pushes share the event %s", sub.topic, other.topic, sub.event)
+	}
+	param := sub.param(w.listenKey)
+	w.subs[sub] = struct{}{}
+	w.mu.Unlock()
+	// Read after registering: a reconnect installs its connection before
+	// replaying the registered subscriptions, so sub is not missed.
+	conn := w.ws.Current()
+
+	// A nil conn means the connection dropped in the meantime; the
+	// reconnect loop replays all registered subscriptions.
+	if conn != nil {
+		if err := w.request(ctx, conn, "SUBSCRIBE", param); err != nil {
+			w.removeSubscription(sub)
+			return err
+		}
+	}
+
+	// End the subscription when the caller's context is done.
+	go func() {
+		select {
+		case <-ctx.Done():
+		case <-sub.done:
+			return
+		}
+		if w.removeSubscription(sub) {
+			w.mu.Lock()
+			param := sub.param(w.listenKey)
+			shared := w.hasSubscriptionLocked(sub.topic, sub.private)
+			w.mu.Unlock()
+			conn := w.ws.Current()
+			if conn != nil && !shared {
+				unsubCtx, cancel := context.WithTimeout(context.Background(), wsWriteTimeout)
+				defer cancel()
+				w.request(unsubCtx, conn, "UNSUBSCRIBE", param) // Best effort
+			}
+		}
+	}()
+	return nil
+}
+
+// removeSubscription unregisters sub and closes its done channel. It reports
+// whether the subscription was still active.
+func (w *WSClient) removeSubscription(sub *wsSubscription) bool {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if _, ok := w.subs[sub]; !ok {
+		return false
+	}
+	delete(w.subs, sub)
+	close(sub.done)
+	return true
+}
+
+// hasSubscriptionLocked reports whether another active subscription uses the
+// same topic, in which case the server-side subscription must be kept.
+// w.mu must be held.
+func (w *WSClient) hasSubscriptionLocked(topic string, private bool) bool {
+	for sub := range w.subs {
+		if sub.topic == topic && sub.private == private {
+			return true
+		}
+	}
+	return false
+}
+
+// pushEvent returns the event of the pushes sub receives. XT pushes most
+// topics with the topic itself as the event (e.g. "kline@btc_usdt,1m"), but
+// omits the parameters of some, such as the interval of depth_update.
+func (sub *wsSubscription) pushEvent() string {
+	if sub.event != "" {
+		return sub.event
+	}
+	return sub.topic
+}
+
+// conflictLocked returns an active subscription to a different topic whose
+// pushes carry the same event as those of sub, so they could not be told
+// apart, or nil. w.mu must be held.
+func (w *WSClient) conflictLocked(sub *wsSubscription) *wsSubscription {
+	for other := range w.subs {
+		if other.topic != sub.topic && other.private == sub.private && other.pushEvent() == sub.pushEvent() {
+			return other
+		}
+	}
+	return nil
+}
+
+// handle decodes a frame read off the connection and dispatches it.
+func (w *WSClient) handle(data []byte) {
+	if bytes.Equal(bytes.TrimSpace(data), []byte("pong")) {
+		return
+	}
+	var msg wsMessage
+	if err := json.Unmarshal(data, &msg); err != nil {
+		w.reportError(fmt.Errorf("failed to decode websocket message: %w (body: %s)", err, string(data)))
+		return
+	}
+	w.dispatch(&msg)
+}
+
+func (w *WSClient) dispatch(msg *wsMessage) {
+	if msg.ID != "" && msg.Event == "" {
+		w.mu.Lock()
+		ack, ok := w.pending[msg.ID]
+		w.mu.Unlock()
+		if ok {
+			ack <- msg
+		}
+		return
+	}
+	if msg.Event == "" {
+		return
+	}
+
+	w.mu.Lock()
+	targets := make([]*wsSubscription, 0, 1)
+	for sub := range w.subs {
+		if sub.pushEvent() == msg.Event {
+			targets = append(targets, sub)
+		}
+	}
+	w.mu.Unlock()
+
+	for _, sub := range targets {
+		w.ws.Deliver(sub.raw, msg.Data, sub.topic)
+	}
+}
+
+// resubscribe replays every active subscription on a new connection.
+func (w *WSClient) resubscribe(conn *websocket.Conn) {
+	w.mu.Lock()
+	params := w.paramsLocked()
+	w.mu.Unlock()
+	if len(params) == 0 {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), wsWriteTimeout)
+	defer cancel()
+	if err := w.request(ctx, conn, "SUBSCRIBE", params...); err != nil {
+		w.reportError(fmt.Errorf("failed to resubscribe %v: %w", params, err))
+	}
+}
+
+// paramsLocked returns the distinct wire parameters of all active
+// subscriptions. w.mu must be held.
+func (w *WSClient) paramsLocked() []string {
+	seen := make(map[string]struct{}, len(w.subs))
+	params := make([]string, 0, len(w.subs))
+	for sub := range w.subs {
+		p := sub.param(w.listenKey)
+		if _, ok := seen[p]; ok {
+			continue
+		}
+		seen[p] = struct{}{}
+		params = append(params, p)
+	}
+	return params
+}
+
+func (w *WSClient) reportError(err error) {
+	w.ws.Report(err)
+}
+
+// subscribeTyped subscribes to a topic and decodes every push into values of
+// type T delivered on the returned channel. keep filters decoded values; it
+// may be nil. The channel is closed when ctx is done or the client is closed.
+func subscribeTyped[T any](ctx context.Context, w *WSClient, topic, event string, private bool, keep func(*T) bool) (<-chan T, error) {
+	return subscribeDecoded(ctx, w, topic, event, private, decodeWSData[T], keep)
+}
+
+// subscribeConverted is like subscribeTyped but decodes pushes into the wire
+// type W first and converts them with convert. It is used for topics whose
+// payload encodes fields differently from the equivalent REST types.
+func subscribeConverted[W, T any](ctx context.Context, w *WSClient, topic, event string, private bool, convert func(*W) T, keep func(*T) bool) (<-chan T, error) {
+	decode := func(raw json.RawMessage) ([]T, error) {
+		wire, err := decodeWSData[W](raw)
+		if err != nil {
+			return nil, err
+		}
+		items := make([]T, 0, len(wire))
+		for i := range wire {
+			items = append(items, convert(&wire[i]))
+		}
+		return items, nil
+	}
+	return subscribeDecoded(ctx, w, topic, event, private, decode, keep)
+}
+
+func subscribeDecoded[T any](ctx context.Context, w *WSClient, topic, event string, private bool, decode func(json.RawMessage) ([]T, error), keep func(*T) bool) (<-chan T, error) {
+	sub := &wsSubscription{
+		topic:   topic,
+		event:   event,
+		private: private,
+		raw:     make(chan json.RawMessage, wsSubscriptionBuffer),
+		done:    make(chan struct{}),
+	}
+	out := make(chan T, wsSubscriptionBuffer)
+
+	if err := w.subscribe(ctx, sub); err != nil {
+		return nil, err
+	}
+
+	go func() {
+		defer close(out)
+		for {
+			select {
+			case <-sub.done:
+				return
+			case raw := <-sub.raw:
+				items, err := decode(raw)
+				if err != nil {
+					w.reportError(fmt.Errorf("failed to decode %s push: %w (body: %s)", topic, err, string(raw)))
+					continue
+				}
+				for i := range items {
+					if keep != nil && !keep(&items[i]) {
+						continue
+					}
+					select {
+					case out <- items[i]:
+					case <-sub.done:
+						return
+					}
+				}
+			}
+		}
+	}()
+	return out, nil
+}
+
+// decodeWSData decodes push data that may be either a single object or an array of objects.
+func decodeWSData[T any](raw json.RawMessage) ([]T, error) {
+	trimmed := bytes.TrimSpace(raw)
+	if len(trimmed) > 0 && trimmed[0] == '[' {
+		var items []T
+		if err := json.Unmarshal(raw, &items); err != nil {
+			return nil, err
+		}
+		return items, nil
+	}
+	var item T
+	if err := json.Unmarshal(raw, &item); err != nil {
+		return nil, err
+	}
+	return []T{item}, nil
+}
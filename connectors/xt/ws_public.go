@@ -0,0 +1,111 @@
+package xt
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gorilla/websocket"
+)
+
+// NewMarketWSClient creates a websocket client for XT public futures market topics.
+// Subscriptions are unsubscribed when their context is done.
+// If dialer is nil, websocket.DefaultDialer is used.
+func NewMarketWSClient(dialer *websocket.Dialer) *WSClient {
+	return newWSClient(defaultWSMarketURL, nil, dialer)
+}
+
+// NewCoinMarketWSClient creates a websocket client for XT public market topics
+// of coin-margined (COIN-M) contracts.
+// If dialer is nil, websocket.DefaultDialer is used.
+func NewCoinMarketWSClient(dialer *websocket.Dialer) *WSClient {
+	return newWSClient(defaultCoinWSMarketURL, nil, dialer)
+}
+
+// SubscribeDepthUpdate streams incremental order book updates for a symbol.
+// symbol: Trading pair (e.g., "btc_usdt")
+// interval: Push interval, "100ms" or "1000ms"
+// Updates carry FirstUpdateID/UpdateID so consumers can detect gaps against a
+// snapshot fetched with GetDepth. XT pushes them with the event
+// "depth_update@<symbol>", without the interval, so a client can only stream
+// one interval per symbol at a time; subscribing to another fails while the
+// first is active.
+// The returned channel is closed when ctx is done or the client is closed.
+func (w *WSClient) SubscribeDepthUpdate(ctx context.Context, symbol, interval string) (<-chan DepthUpdate, error) {
+	if symbol == "" {
+		return nil, fmt.Errorf("symbol is required")
+	}
+	topic := fmt.Sprintf("depth_update@%s,%s", symbol, interval)
+	return subscribeConverted(ctx, w, topic, "depth_update@"+symbol, false, (*wsDepthUpdate).depthUpdate, nil)
+}
+
+// SubscribeTrades streams public trades for a symbol.
+// The returned channel is closed when ctx is done or the client is closed.
+func (w *WSClient) SubscribeTrades(ctx context.Context, symbol string) (<-chan Trade, error) {
+	if symbol == "" {
+		return nil, fmt.Errorf("symbol is required")
+	}
+	return subscribeTyped[Trade](ctx, w, "trade@"+symbol, "", false, nil)
+}
+
+// SubscribeTicker streams 24h ticker updates for a symbol.
+// The returned channel is closed when ctx is done or the client is closed.
+func (w *WSClient) SubscribeTicker(ctx context.Context, symbol string) (<-chan TickerDetail, error) {
+	if symbol == "" {
+		return nil, fmt.Errorf("symbol is required")
+	}
+	return subscribeTyped[TickerDetail](ctx, w, "ticker@"+symbol, "", false, nil)
+}
+
+// SubscribeMarkPrice streams mark price updates for a symbol.
+// The returned channel is closed when ctx is done or the client is closed.
+func (w *WSClient) SubscribeMarkPrice(ctx context.Context, symbol string) (<-chan MarkPriceDetail, error) {
+	if symbol == "" {
+		return nil, fmt.Errorf("symbol is required")
+	}
+	return subscribeTyped[MarkPriceDetail](ctx, w, "mark_price@"+symbol, "", false, nil)
+}
+
+// SubscribeIndexPrice streams index price updates for a symbol.
+// The returned channel is closed when ctx is done or the client is closed.
+func (w *WSClient) SubscribeIndexPrice(ctx context.Context, symbol string) (<-chan IndexPriceDetail, error) {
+	if symbol == "" {
+		return nil, fmt.Errorf("symbol is required")
+	}
+	return subscribeTyped[IndexPriceDetail](ctx, w, "index_price@"+symbol, "", false, nil)
+}
+
+// SubscribeKlines streams candlestick updates for a symbol.
+// interval: 1m, 5m, 15m, 30m, 1h, 4h, 1d, 1w
+// XT pushes them with the event "kline@<symbol>,<interval>", so several
+// intervals of a symbol can be streamed at once.
+// The returned channel is closed when ctx is done or the client is closed.
+func (w *WSClient) SubscribeKlines(ctx context.Context, symbol, interval string) (<-chan Kline, error) {
+	if symbol == "" || interval == "" {
+		return nil, fmt.Errorf("symbol and interval are required")
+	}
+	topic := fmt.Sprintf("kline@%s,%s", symbol, interval)
+	return subscribeTyped[Kline](ctx, w, topic, "", false, nil)
+}
+
+// wsDepthUpdate is the depth_update push; update IDs may be sent as strings.
+type wsDepthUpdate struct {
+	Symbol        string       `json:"s"`
+	FirstUpdateID flexInt      `json:"fu"`
+	UpdateID      flexInt      `json:"u"`
+	PrevUpdateID  flexInt      `json:"pu"`
+	Asks          []DepthEntry `json:"a"`
+	Bids          []DepthEntry `json:"b"`
+	Time          flexInt      `json:"t"`
+}
+
+func (d *wsDepthUpdate) depthUpdate() DepthUpdate {
+	return DepthUpdate{
+		Symbol:        d.Symbol,
+		FirstUpdateID: int64(d.FirstUpdateID),
+		UpdateID:      int64(d.UpdateID),
+		PrevUpdateID:  int64(d.PrevUpdateID),
+		Asks:          d.Asks,
+		Bids:          d.Bids,
+		Time:          int64(d.Time),
+	}
+}
@@ -0,0 +1,63 @@
+package xt
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+func TestDispatchRoutesByPushEvent(t *testing.T) {
+	w := NewMarketWSClient(nil)
+	newSub := func(topic, event string) *wsSubscription {
+		sub := &wsSubscription{topic: topic, event: event, raw: make(chan json.RawMessage, 1), done: make(chan struct{})}
+		w.subs[sub] = struct{}{}
+		return sub
+	}
+	kline1m := newSub("kline@btc_usdt,1m", "")
+	kline5m := newSub("kline@btc_usdt,5m", "")
+	depth := newSub("depth_update@btc_usdt,100ms", "depth_update@btc_usdt")
+
+	w.dispatch(&wsMessage{Topic: "kline", Event: "kline@btc_usdt,5m", Data: json.RawMessage(`{}`)})
+	if len(kline1m.raw) != 0 || len(kline5m.raw) != 1 {
+		t.Errorf("5m kline delivered to 1m: %d, 5m: %d subscriptions", len(kline1m.raw), len(kline5m.raw))
+	}
+	w.dispatch(&wsMessage{Topic: "depth_update", Event: "depth_update@btc_usdt", Data: json.RawMessage(`{}`)})
+	if len(depth.raw) != 1 {
+		t.Error("depth update not delivered")
+	}
+
+	other := &wsSubscription{topic: "depth_update@btc_usdt,1000ms", event: "depth_update@btc_usdt"}
+	if w.conflictLocked(other) != depth {
+		t.Error("second depth interval of a symbol not reported as conflicting")
+	}
+	if sub := (&wsSubscription{topic: "kline@btc_usdt,15m"}); w.conflictLocked(sub) != nil {
+		t.Error("another kline interval reported as conflicting")
+	}
+}
+
+func TestDispatchDropsWhenBufferFull(t *testing.T) {
+	w := NewMarketWSClient(nil)
+	slow := &wsSubscription{topic: "trade@btc_usdt", raw: make(chan json.RawMessage, 1), done: make(chan struct{})}
+	fast := &wsSubscription{topic: "trade@btc_usdt", raw: make(chan json.RawMessage, 2), done: make(chan struct{})}
+	w.subs[slow] = struct{}{}
+	w.subs[fast] = struct{}{}
+
+	// Neither subscription is drained; the second push must not block.
+	w.dispatch(&wsMessage{Topic: "trade", Event: "trade@btc_usdt", Data: json.RawMessage(`1`)})
+	w.dispatch(&wsMessage{Topic: "trade", Event: "trade@btc_usdt", Data: json.RawMessage(`2`)})
+
+	if len(fast.raw) != 2 {
+		t.Errorf("fast subscription received %d pushes, want 2", len(fast.raw))
+	}
+	if got := string(<-slow.raw); got != "1" {
+		t.Errorf("slow subscription kept push %s, want 1", got)
+	}
+	select {
+	case err := <-w.Errors():
+		if !errors.Is(err, ErrWSBufferFull) {
+			t.Errorf("reported %v, want ErrWSBufferFull", err)
+		}
+	default:
+		t.Error("dropped push not reported")
+	}
+}
@@ -0,0 +1,266 @@
+package xt
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/neqin/futures/decimal"
+)
+
+const (
+	listenKeyValidity        = 8 * time.Hour
+	listenKeyRefreshInterval = listenKeyValidity / 2 // Refresh well before the key expires
+	listenKeyRetryDelay      = time.Minute
+)
+
+// NewUserWSClient creates a websocket client for the XT user-data stream
+// (order, trade, position and balance topics). The listen key is obtained
+// with client.GetListenKey on the first subscription and refreshed
+// periodically for as long as the websocket client is open.
+// The stream belongs to the client's underlying type: pass
+// client.WithUnderlying(CoinMargined) for the COIN-M account.
+// If dialer is nil, websocket.DefaultDialer is used.
+func NewUserWSClient(client *Client, dialer *websocket.Dialer) *WSClient {
+	url := defaultWSUserURL
+	if client.Underlying() == CoinMargined {
+		url = defaultCoinWSUserURL
+	}
+	return newWSClient(url, client, dialer)
+}
+
+// SubscribeOrders streams order updates for the account.
+// The returned channel is closed when ctx is done or the client is closed.
+func (w *WSClient) SubscribeOrders(ctx context.Context) (<-chan OrderDetail, error) {
+	return subscribeConverted(ctx, w, "order", "order", true, (*wsOrder).orderDetail, nil)
+}
+
+// SubscribeUserTrades streams fills for the account.
+// The returned channel is closed when ctx is done or the client is closed.
+func (w *WSClient) SubscribeUserTrades(ctx context.Context) (<-chan TradeDetail, error) {
+	return subscribeConverted(ctx, w, "trade", "trade", true, (*wsUserTrade).tradeDetail, nil)
+}
+
+// SubscribePositions streams position changes for the account.
+// The returned channel is closed when ctx is done or the client is closed.
+func (w *WSClient) SubscribePositions(ctx context.Context) (<-chan PositionDetail, error) {
+	return subscribeTyped[PositionDetail](ctx, w, "position", "position", true, nil)
+}
+
+// SubscribeBalances streams balance changes for the account.
+// The returned channel is closed when ctx is done or the client is closed.
+func (w *WSClient) SubscribeBalances(ctx context.Context) (<-chan BalanceDetail, error) {
+	return subscribeTyped[BalanceDetail](ctx, w, "balance", "balance", true, nil)
+}
+
+// ensureListenKey fetches the first listen key and starts the refresh loop.
+func (w *WSClient) ensureListenKey(ctx context.Context) error {
+	if w.rest == nil {
+		return fmt.Errorf("user topics require a client created with NewUserWSClient")
+	}
+	w.mu.Lock()
+	hasKey := w.listenKey != ""
+	w.mu.Unlock()
+	if hasKey {
+		return nil
+	}
+
+	key, err := w.fetchListenKey(ctx)
+	if err != nil {
+		return err
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.closed {
+		return ErrWSClosed
+	}
+	if w.listenKey == "" { // Another subscription may have won the race
+		w.listenKey = key
+		go w.listenKeyLoop()
+	}
+	return nil
+}
+
+func (w *WSClient) fetchListenKey(ctx context.Context) (string, error) {
+	result, err := w.rest.GetListenKey(ctx)
+	if err != nil {
+		return "", err
+	}
+	if result.Result.ListenKey == "" {
+		return "", fmt.Errorf("GetListenKey returned an empty listen key")
+	}
+	return result.Result.ListenKey, nil
+}
+
+// listenKeyLoop refreshes the listen key before it expires. When XT issues a
+// new key, user topics are re-subscribed under it and the old ones dropped.
+func (w *WSClient) listenKeyLoop() {
+	delay := listenKeyRefreshInterval
+	for {
+		select {
+		case <-w.ws.Done():
+			return
+		case <-time.After(delay):
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), wsWriteTimeout)
+		err := w.refreshListenKey(ctx)
+		cancel()
+		if err != nil {
+			w.reportError(fmt.Errorf("failed to refresh listen key: %w", err))
+			delay = listenKeyRetryDelay
+			continue
+		}
+		delay = listenKeyRefreshInterval
+	}
+}
+
+func (w *WSClient) refreshListenKey(ctx context.Context) error {
+	key, err := w.fetchListenKey(ctx)
+	if err != nil {
+		return err
+	}
+
+	w.mu.Lock()
+	if w.closed || key == w.listenKey {
+		w.mu.Unlock()
+		return nil
+	}
+	oldKey := w.listenKey
+	w.listenKey = key
+	var oldParams, newParams []string
+	seen := make(map[string]struct{})
+	for sub := range w.subs {
+		if !sub.private {
+			continue
+		}
+		if _, ok := seen[sub.topic]; ok {
+			continue
+		}
+		seen[sub.topic] = struct{}{}
+		oldParams = append(oldParams, sub.param(oldKey))
+		newParams = append(newParams, sub.param(key))
+	}
+	w.mu.Unlock()
+
+	// Without a connection the reconnect loop subscribes with the new key.
+	conn := w.ws.Current()
+	if conn == nil || len(newParams) == 0 {
+		return nil
+	}
+	if err := w.request(ctx, conn, "SUBSCRIBE", newParams...); err != nil {
+		return err
+	}
+	w.request(ctx, conn, "UNSUBSCRIBE", oldParams...) // Best effort, the old key expires anyway
+	return nil
+}
+
+// --- Wire types ---
+//
+// User-data pushes encode IDs as strings and report maker/taker as a boolean,
+// where the REST API uses numbers and "MAKER"/"TAKER". The types below accept
+// either encoding and are converted into the REST structs.
+
+// flexInt decodes a JSON number or numeric string into an int64.
+type flexInt int64
+
+func (f *flexInt) UnmarshalJSON(data []byte) error {
+	s := strings.Trim(string(bytes.TrimSpace(data)), `"`)
+	if s == "" || s == "null" {
+		*f = 0
+		return nil
+	}
+	n, err := strconv.ParseInt(s, 10, 64)
+	if err != nil {
+		return err
+	}
+	*f = flexInt(n)
+	return nil
+}
+
+type wsOrder struct {
+	ClientOrderID      *string          `json:"clientOrderId"`
+	AvgPrice           decimal.Decimal  `json:"avgPrice"`
+	ClosePosition      *bool            `json:"closePosition"`
+	CloseProfit        decimal.Decimal  `json:"closeProfit"`
+	CreatedTime        flexInt          `json:"createdTime"`
+	ExecutedQty        decimal.Decimal  `json:"executedQty"`
+	ForceClose         *bool            `json:"forceClose"`
+	MarginFrozen       decimal.Decimal  `json:"marginFrozen"`
+	OrderID            flexInt          `json:"orderId"`
+	OrderSide          string           `json:"orderSide"`
+	OrderType          string           `json:"orderType"`
+	OrigQty            decimal.Decimal  `json:"origQty"`
+	PositionSide       string           `json:"positionSide"`
+	Price              decimal.Decimal  `json:"price"`
+	SourceID           *int64           `json:"sourceId"`
+	State              string           `json:"state"`
+	Symbol             string           `json:"symbol"`
+	TimeInForce        string           `json:"timeInForce"`
+	TriggerProfitPrice *decimal.Decimal `json:"triggerProfitPrice"`
+	TriggerStopPrice   *decimal.Decimal `json:"triggerStopPrice"`
+}
+
+func (o *wsOrder) orderDetail() OrderDetail {
+	return OrderDetail{
+		ClientOrderID:      o.ClientOrderID,
+		AvgPrice:           o.AvgPrice,
+		ClosePosition:      o.ClosePosition,
+		CloseProfit:        o.CloseProfit,
+		CreatedTime:        int64(o.CreatedTime),
+		ExecutedQty:        o.ExecutedQty,
+		ForceClose:         o.ForceClose,
+		MarginFrozen:       o.MarginFrozen,
+		OrderID:            int64(o.OrderID),
+		OrderSide:          o.OrderSide,
+		OrderType:          o.OrderType,
+		OrigQty:            o.OrigQty,
+		PositionSide:       o.PositionSide,
+		Price:              o.Price,
+		SourceID:           o.SourceID,
+		State:              o.State,
+		Symbol:             o.Symbol,
+		TimeInForce:        o.TimeInForce,
+		TriggerProfitPrice: o.TriggerProfitPrice,
+		TriggerStopPrice:   o.TriggerStopPrice,
+	}
+}
+
+type wsUserTrade struct {
+	Fee        decimal.Decimal `json:"fee"`
+	FeeCoin    string          `json:"feeCoin"`
+	OrderID    flexInt         `json:"orderId"`
+	ExecID     json.RawMessage `json:"execId"`
+	Price      decimal.Decimal `json:"price"`
+	Quantity   decimal.Decimal `json:"quantity"`
+	Symbol     string          `json:"symbol"`
+	Timestamp  flexInt         `json:"timestamp"`
+	TakerMaker string          `json:"takerMaker"`
+	IsMaker    *bool           `json:"isMaker"`
+}
+
+func (t *wsUserTrade) tradeDetail() TradeDetail {
+	takerMaker := t.TakerMaker
+	if takerMaker == "" && t.IsMaker != nil {
+		takerMaker = "TAKER"
+		if *t.IsMaker {
+			takerMaker = "MAKER"
+		}
+	}
+	return TradeDetail{
+		Fee:        t.Fee,
+		FeeCoin:    t.FeeCoin,
+		OrderID:    int64(t.OrderID),
+		ExecID:     strings.Trim(string(t.ExecID), `"`),
+		Price:      t.Price,
+		Quantity:   t.Quantity,
+		Symbol:     t.Symbol,
+		Timestamp:  int64(t.Timestamp),
+		TakerMaker: takerMaker,
+	}
+}
@@ -0,0 +1,720 @@
+// Package xttest provides an in-process emulation of the XT.com USDT-M
+// futures REST API for tests.
+//
+// A Server verifies the validate-* signature headers of private requests the
+// way XT does, keeps orders, positions, the balance and plan orders of a
+// hedge-mode account on a paper.Engine, and answers with XT JSON envelopes
+// (returnCode, msgInfo, error, result), so an xt.Client pointed at it behaves
+// as against the venue:
+//
+//	srv := xttest.NewServer(paper.Config{Currency: "USDT", Balance: decimal.NewFromInt(1000)})
+//	defer srv.Close()
+//	srv.AddContract(xt.Contract{Symbol: "btc_usdt", ContractSize: decimal.MustParse("0.0001"), PricePrecision: 1})
+//	srv.SetOrderBook(&exchange.OrderBook{Symbol: "btc_usdt", Bids: bids, Asks: asks})
+//	client := srv.Client()
+//
+// The COIN-M host is not emulated: it lists no symbols, so every symbol is
+// routed to the USDT-M host. Resting orders and plan orders are matched
+// whenever SetOrderBook replaces a book. Faults injected with InjectFault
+// replace the normal response of matching requests, e.g. to exercise retries
+// and error classification.
+package xttest
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/neqin/futures/connectors/xt"
+	"github.com/neqin/futures/decimal"
+	"github.com/neqin/futures/exchange"
+	"github.com/neqin/futures/paper"
+	"github.com/neqin/futures/symbols"
+)
+
+// Credentials accepted by a Server.
+const (
+	APIKey    = "xttest-key"
+	SecretKey = "xttest-secret"
+)
+
+// Account identifiers returned by a Server.
+const (
+	UserID    = 20002
+	AccountID = 30003
+	ListenKey = "xttest-listen-key"
+)
+
+// coinPrefix is the path prefix of the (empty) COIN-M host.
+const coinPrefix = "/dapi"
+
+// defaultRecvWindow is the validity window of signed requests without a
+// validate-recvwindow header.
+const defaultRecvWindow = 5 * time.Second
+
+// Fault is an error response returned instead of the normal handling of
+// matching requests.
+type Fault struct {
+	Method  string        // HTTP method to match; empty matches any
+	Path    string        // Request path, e.g. "/future/trade/v1/order/create"; empty matches any
+	Status  int           // HTTP status; 200 if zero
+	Code    string        // XT error code, e.g. "TOO_MANY_REQUESTS"; the body is not JSON if empty
+	Message string        // Error message
+	Delay   time.Duration // Wait before responding, e.g. to exceed a client timeout
+	Times   int           // Number of matching requests to fail; zero for all
+}
+
+// Request is a request received by a Server.
+type Request struct {
+	Method string
+	Path   string
+	Query  url.Values
+	Body   []byte
+	Header http.Header
+}
+
+// Server is an emulated XT.com futures API. It is safe for concurrent use.
+type Server struct {
+	URL string // Base URL for xt.Client.SetUsdtBaseURL
+
+	srv    *httptest.Server
+	engine *paper.Engine
+	trader *xt.PaperClient
+	books  *paper.StaticBooks
+
+	mu        sync.Mutex
+	contracts []xt.Contract
+	faults    []*Fault
+	requests  []Request
+}
+
+// NewServer starts a Server whose account starts with cfg (currency USDT if
+// empty). Close it when done.
+func NewServer(cfg paper.Config) *Server {
+	if cfg.Currency == "" {
+		cfg.Currency = "USDT"
+	}
+	books := paper.NewStaticBooks()
+	engine := paper.NewEngine(cfg, books)
+	s := &Server{
+		engine: engine,
+		trader: xt.NewPaperClient(engine),
+		books:  books,
+	}
+	s.srv = httptest.NewServer(s.handler())
+	s.URL = s.srv.URL
+	return s
+}
+
+// Close shuts the server down.
+func (s *Server) Close() {
+	s.srv.Close()
+}
+
+// Client returns an xt.Client for the server, authenticated with APIKey and
+// SecretKey.
+func (s *Server) Client() *xt.Client {
+	c := xt.NewClient(APIKey, SecretKey, s.srv.Client())
+	c.SetUsdtBaseURL(s.URL)
+	c.SetCoinBaseURL(s.URL + coinPrefix)
+	return c
+}
+
+// Engine returns the simulated account behind the server.
+func (s *Server) Engine() *paper.Engine {
+	return s.engine
+}
+
+// AddContract lists a USDT-M perpetual contract. Symbol and ContractSize are
+// required; the tick is MinStepPrice or else 10^-PricePrecision, and
+// quantities are whole contracts unless QuantityPrecision is set.
+func (s *Server) AddContract(c xt.Contract) {
+	c.Symbol = strings.ToLower(c.Symbol)
+	base, quote := symbols.SplitSymbol(c.Symbol)
+	if c.BaseCoin == "" {
+		c.BaseCoin = strings.ToLower(base)
+	}
+	if c.QuoteCoin == "" {
+		c.QuoteCoin = strings.ToLower(quote)
+	}
+	if c.MinStepPrice.IsZero() {
+		c.MinStepPrice = symbols.Step(int32(c.PricePrecision))
+	}
+	if c.MinQty.IsZero() {
+		c.MinQty = symbols.Step(int32(c.QuantityPrecision))
+	}
+	c.ContractType, c.ProductType, c.UnderlyingType = "PERPETUAL", "perpetual", "U_BASED"
+	c.TradeSwitch, c.OpenSwitch, c.IsOpenApi = true, true, true
+
+	s.mu.Lock()
+	s.contracts = append(s.contracts, c)
+	contracts := make([]symbols.Contract, len(s.contracts))
+	for i, c := range s.contracts {
+		contracts[i] = symbols.Contract{
+			Symbol:      symbols.Canonical(c.Symbol),
+			Venue:       "xt",
+			Settle:      strings.ToUpper(c.QuoteCoin),
+			TickSize:    c.MinStepPrice,
+			LotSize:     symbols.Step(int32(c.QuantityPrecision)),
+			MinSize:     c.MinQty,
+			Multiplier:  c.ContractSize,
+			MinNotional: c.MinNotional,
+			MaxNotional: c.MaxNotional,
+		}
+	}
+	s.mu.Unlock()
+	s.engine.SetContracts(contracts...)
+}
+
+// SetOrderBook replaces the book of a symbol and matches resting orders and
+// plan orders against it.
+func (s *Server) SetOrderBook(book *exchange.OrderBook) error {
+	s.books.Set(book)
+	return s.engine.Match(context.Background())
+}
+
+// InjectFault makes matching requests fail. Faults are checked in the order
+// they were injected.
+func (s *Server) InjectFault(f Fault) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.faults = append(s.faults, &f)
+}
+
+// ClearFaults removes all injected faults.
+func (s *Server) ClearFaults() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.faults = nil
+}
+
+// Requests returns the requests received so far, oldest first.
+func (s *Server) Requests() []Request {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]Request(nil), s.requests...)
+}
+
+// --- HTTP handling ---
+
+// handlerFunc handles a request whose query and form or JSON body
+// parameters have been merged into params.
+type handlerFunc func(r *http.Request, params url.Values, body []byte) (interface{}, error)
+
+func (s *Server) handler() http.Handler {
+	mux := http.NewServeMux()
+	public := func(pattern string, h handlerFunc) {
+		mux.Handle(pattern, s.endpoint(false, h))
+	}
+	private := func(pattern string, h handlerFunc) {
+		mux.Handle(pattern, s.endpoint(true, h))
+	}
+
+	public("GET /future/market/v1/public/time", s.serverTime)
+	public("GET /future/market/v3/public/symbol/list", s.listContracts)
+	public("GET /future/market/v1/public/symbol/detail", s.getContract)
+	public("GET /future/market/v1/public/q/depth", s.depth)
+	public("GET /future/market/v1/public/q/symbol-mark-price", s.markPrice)
+	public("GET "+coinPrefix+"/future/market/v3/public/symbol/list", s.listCoinContracts)
+
+	private("POST /future/trade/v1/order/create", s.placeOrder)
+	private("POST /future/trade/v2/order/create-batch", s.placeBatchOrder)
+	private("POST /future/trade/v1/order/cancel", s.cancelOrder)
+	private("POST /future/trade/v1/order/cancel-all", s.cancelOrders)
+	private("GET /future/trade/v1/order/detail", s.getOrder)
+	private("GET /future/trade/v1/order/list", s.listOrders)
+	private("POST /future/trade/v1/order/update", s.updateOrder)
+
+	private("GET /future/user/v1/account/info", s.accountInfo)
+	private("GET /future/user/v1/user/listen-key", s.listenKey)
+	private("GET /future/user/v1/balance/detail", s.balance)
+	private("GET /future/user/v1/position/list", s.positions)
+	private("POST /future/user/v1/position/adjust-leverage", s.adjustLeverage)
+	private("POST /future/user/v1/position/margin", s.updateMargin)
+
+	private("POST /future/trade/v1/entrust/create-plan", s.createPlan)
+	private("POST /future/trade/v1/entrust/cancel-plan", s.cancelPlan)
+	private("POST /future/trade/v1/entrust/cancel-all-plan", s.cancelPlans)
+	private("GET /future/trade/v1/entrust/plan-list", s.listPlans)
+	private("GET /future/trade/v1/entrust/plan-detail", s.getPlan)
+
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		writeError(w, http.StatusNotFound, "not_found", "unsupported endpoint "+r.Method+" "+r.URL.Path)
+	})
+	return mux
+}
+
+// endpoint wraps a handler with request recording, fault injection,
+// signature verification for private endpoints and response encoding.
+func (s *Server) endpoint(private bool, h handlerFunc) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "invalid_body", err.Error())
+			return
+		}
+		f := s.record(Request{Method: r.Method, Path: r.URL.Path, Query: r.URL.Query(), Body: body, Header: r.Header.Clone()})
+		if f != nil {
+			select {
+			case <-time.After(f.Delay):
+			case <-r.Context().Done():
+				return
+			}
+			status := f.Status
+			if status == 0 {
+				status = http.StatusOK
+			}
+			if f.Code == "" {
+				http.Error(w, f.Message, status)
+				return
+			}
+			writeError(w, status, f.Code, f.Message)
+			return
+		}
+		if private {
+			if code, msg := authenticate(r, body); code != "" {
+				writeError(w, http.StatusOK, code, msg)
+				return
+			}
+		}
+		params, err := requestParams(r, body)
+		if err != nil {
+			writeError(w, http.StatusOK, "invalid_params", err.Error())
+			return
+		}
+		result, err := h(r, params, body)
+		if err != nil {
+			writeError(w, http.StatusOK, errorCode(err), err.Error())
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(result)
+	})
+}
+
+// record logs a request and returns the fault to apply, if any.
+func (s *Server) record(req Request) *Fault {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.requests = append(s.requests, req)
+	for i, f := range s.faults {
+		if (f.Method != "" && f.Method != req.Method) || (f.Path != "" && f.Path != req.Path) {
+			continue
+		}
+		if f.Times > 0 {
+			f.Times--
+			if f.Times == 0 {
+				s.faults = append(s.faults[:i:i], s.faults[i+1:]...)
+			}
+		}
+		return f
+	}
+	return nil
+}
+
+// authenticate checks the validate-* headers of a private request and
+// returns the XT error code of a rejected one.
+func authenticate(r *http.Request, body []byte) (string, string) {
+	key, ts, sign := r.Header.Get("validate-appkey"), r.Header.Get("validate-timestamp"), r.Header.Get("validate-signature")
+	window := r.Header.Get("validate-recvwindow")
+	switch {
+	case key == "":
+		return "AUTH_001", "validate-appkey header is required"
+	case ts == "":
+		return "AUTH_002", "validate-timestamp header is required"
+	case sign == "":
+		return "AUTH_003", "validate-signature header is required"
+	case key != APIKey:
+		return "AUTH_101", "apikey does not exist"
+	}
+	ms, err := strconv.ParseInt(ts, 10, 64)
+	if err != nil {
+		return "AUTH_102", "invalid validate-timestamp"
+	}
+	valid := defaultRecvWindow
+	if window != "" {
+		w, err := strconv.ParseInt(window, 10, 64)
+		if err != nil {
+			return "AUTH_102", "invalid validate-recvwindow"
+		}
+		valid = time.Duration(w) * time.Millisecond
+	}
+	if skew := time.Since(time.UnixMilli(ms)); skew > valid || skew < -valid {
+		return "AUTH_104", "request expired"
+	}
+
+	// sign = X + Y, X = sorted validate-* headers, Y = #path[#query][#body]
+	signed := "validate-appkey=" + key
+	if window != "" {
+		signed += "&validate-recvwindow=" + window
+	}
+	signed += "&validate-timestamp=" + ts + "#" + r.URL.Path
+	if r.URL.RawQuery != "" && (r.Method == http.MethodGet || r.Method == http.MethodDelete) {
+		signed += "#" + r.URL.RawQuery
+	}
+	if len(body) > 0 {
+		signed += "#" + string(body)
+	}
+	mac := hmac.New(sha256.New, []byte(SecretKey))
+	mac.Write([]byte(signed))
+	if !hmac.Equal([]byte(sign), []byte(hex.EncodeToString(mac.Sum(nil)))) {
+		return "AUTH_105", "signature verification failed"
+	}
+	return "", ""
+}
+
+// requestParams merges the query with a form or flat JSON object body.
+func requestParams(r *http.Request, body []byte) (url.Values, error) {
+	params := r.URL.Query()
+	if len(body) == 0 {
+		return params, nil
+	}
+	if strings.HasPrefix(r.Header.Get("Content-Type"), "application/x-www-form-urlencoded") {
+		form, err := url.ParseQuery(string(body))
+		if err != nil {
+			return nil, err
+		}
+		for k, v := range form {
+			params[k] = v
+		}
+		return params, nil
+	}
+	var fields map[string]interface{}
+	if err := json.Unmarshal(body, &fields); err != nil {
+		return nil, fmt.Errorf("malformed request body: %v", err)
+	}
+	for k, v := range fields {
+		if v != nil {
+			params.Set(k, fmt.Sprint(v))
+		}
+	}
+	return params, nil
+}
+
+// errorCode maps an engine error to an XT error code.
+func errorCode(err error) string {
+	switch {
+	case errors.Is(err, exchange.ErrOrderNotFound):
+		return "order_not_exist"
+	case errors.Is(err, exchange.ErrInsufficientBalance):
+		return "insufficient_balance"
+	case errors.Is(err, exchange.ErrInvalidParameter):
+		return "invalid_params"
+	}
+	return "internal_error"
+}
+
+func writeError(w http.ResponseWriter, status int, code, message string) {
+	detail, _ := json.Marshal(xt.ErrorDetail{Code: code, Msg: message})
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(xt.CommonResponse{ReturnCode: 1, MsgInfo: "failure", Error: detail})
+}
+
+var ok = xt.CommonResponse{ReturnCode: 0, MsgInfo: "success"}
+
+func invalid(format string, args ...interface{}) error {
+	return fmt.Errorf(format+": %w", append(args, exchange.ErrInvalidParameter)...)
+}
+
+func decodeBody(body []byte, v interface{}) error {
+	if err := json.Unmarshal(body, v); err != nil {
+		return invalid("malformed request body: %v", err)
+	}
+	return nil
+}
+
+func paramInt64(params url.Values, name string) (int64, error) {
+	n, err := strconv.ParseInt(params.Get(name), 10, 64)
+	if err != nil {
+		return 0, invalid("invalid %s %q", name, params.Get(name))
+	}
+	return n, nil
+}
+
+func optString(params url.Values, name string) *string {
+	if !params.Has(name) {
+		return nil
+	}
+	v := params.Get(name)
+	return &v
+}
+
+func optInt(params url.Values, name string) (*int, error) {
+	if params.Get(name) == "" {
+		return nil, nil
+	}
+	n, err := strconv.Atoi(params.Get(name))
+	if err != nil {
+		return nil, invalid("invalid %s %q", name, params.Get(name))
+	}
+	return &n, nil
+}
+
+func optInt64(params url.Values, name string) (*int64, error) {
+	if params.Get(name) == "" {
+		return nil, nil
+	}
+	n, err := paramInt64(params, name)
+	return &n, err
+}
+
+// --- Market data ---
+
+func (s *Server) serverTime(r *http.Request, params url.Values, body []byte) (interface{}, error) {
+	return xt.ServerTimeResult{CommonResponse: ok, Result: time.Now().UnixMilli()}, nil
+}
+
+func (s *Server) listContracts(r *http.Request, params url.Values, body []byte) (interface{}, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	result := xt.ContractsResult{CommonResponse: ok}
+	result.Result.Time = time.Now().UnixMilli()
+	result.Result.Symbols = append([]xt.Contract{}, s.contracts...)
+	return result, nil
+}
+
+func (s *Server) listCoinContracts(r *http.Request, params url.Values, body []byte) (interface{}, error) {
+	result := xt.ContractsResult{CommonResponse: ok}
+	result.Result.Time = time.Now().UnixMilli()
+	result.Result.Symbols = []xt.Contract{}
+	return result, nil
+}
+
+func (s *Server) getContract(r *http.Request, params url.Values, body []byte) (interface{}, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, c := range s.contracts {
+		if c.Symbol == strings.ToLower(params.Get("symbol")) {
+			return xt.SingleContractResult{CommonResponse: ok, Result: c}, nil
+		}
+	}
+	return nil, invalid("symbol %s not found", params.Get("symbol"))
+}
+
+func (s *Server) book(symbol string) (*exchange.OrderBook, error) {
+	if symbol == "" {
+		return nil, invalid("symbol is required")
+	}
+	book, err := s.books.OrderBook(context.Background(), symbols.Canonical(symbol))
+	if err != nil {
+		return nil, invalid("%v", err)
+	}
+	return book, nil
+}
+
+func (s *Server) depth(r *http.Request, params url.Values, body []byte) (interface{}, error) {
+	book, err := s.book(params.Get("symbol"))
+	if err != nil {
+		return nil, err
+	}
+	level, err := optInt(params, "level")
+	if err != nil {
+		return nil, err
+	}
+	levels := func(in []exchange.OrderBookLevel) []xt.DepthEntry {
+		out := []xt.DepthEntry{}
+		for i, l := range in {
+			if level != nil && i >= *level {
+				break
+			}
+			out = append(out, xt.DepthEntry{decimal.NewFromFloat(l.Price), decimal.NewFromFloat(l.Size)})
+		}
+		return out
+	}
+	result := xt.DepthResult{CommonResponse: ok}
+	result.Result.Symbol = strings.ToLower(params.Get("symbol"))
+	result.Result.Asks = levels(book.Asks)
+	result.Result.Bids = levels(book.Bids)
+	result.Result.Time = time.Now().UnixMilli()
+	result.Result.UpdateID = book.UpdateID
+	return result, nil
+}
+
+func (s *Server) markPrice(r *http.Request, params url.Values, body []byte) (interface{}, error) {
+	book, err := s.book(params.Get("symbol"))
+	if err != nil {
+		return nil, err
+	}
+	if len(book.Bids) == 0 || len(book.Asks) == 0 {
+		return nil, invalid("no mark price for %s", params.Get("symbol"))
+	}
+	mid := decimal.NewFromFloat((book.Bids[0].Price + book.Asks[0].Price) / 2)
+	return xt.SingleMarkPriceResult{CommonResponse: ok, Result: xt.MarkPriceDetail{
+		Price:  mid,
+		Symbol: strings.ToLower(params.Get("symbol")),
+		Time:   time.Now().UnixMilli(),
+	}}, nil
+}
+
+// --- Orders ---
+
+func (s *Server) placeOrder(r *http.Request, params url.Values, body []byte) (interface{}, error) {
+	var req xt.PlaceOrderRequest
+	if err := decodeBody(body, &req); err != nil {
+		return nil, err
+	}
+	return s.trader.PlaceOrder(r.Context(), req)
+}
+
+// placeBatchOrder places the orders of the "list" parameter in turn and stops
+// at the first rejection.
+func (s *Server) placeBatchOrder(r *http.Request, params url.Values, body []byte) (interface{}, error) {
+	var list []xt.PlaceOrderRequest
+	if err := decodeBody([]byte(params.Get("list")), &list); err != nil {
+		return nil, err
+	}
+	for _, req := range list {
+		if _, err := s.trader.PlaceOrder(r.Context(), req); err != nil {
+			return nil, err
+		}
+	}
+	return xt.PlaceBatchOrderResult{CommonResponse: ok, Result: true}, nil
+}
+
+func (s *Server) cancelOrder(r *http.Request, params url.Values, body []byte) (interface{}, error) {
+	id, err := paramInt64(params, "orderId")
+	if err != nil {
+		return nil, err
+	}
+	return s.trader.CancelOrder(r.Context(), id)
+}
+
+func (s *Server) cancelOrders(r *http.Request, params url.Values, body []byte) (interface{}, error) {
+	return s.trader.CancelBatchOrder(r.Context(), optString(params, "symbol"))
+}
+
+func (s *Server) getOrder(r *http.Request, params url.Values, body []byte) (interface{}, error) {
+	id, err := paramInt64(params, "orderId")
+	if err != nil {
+		return nil, err
+	}
+	return s.trader.GetOrder(r.Context(), id)
+}
+
+func (s *Server) listOrders(r *http.Request, params url.Values, body []byte) (interface{}, error) {
+	req := xt.GetOrderListRequest{
+		State:         optString(params, "state"),
+		Symbol:        optString(params, "symbol"),
+		ClientOrderID: optString(params, "clientOrderId"),
+	}
+	var err error
+	if req.Page, err = optInt(params, "page"); err != nil {
+		return nil, err
+	}
+	if req.Size, err = optInt(params, "size"); err != nil {
+		return nil, err
+	}
+	if req.StartTime, err = optInt64(params, "startTime"); err != nil {
+		return nil, err
+	}
+	if req.EndTime, err = optInt64(params, "endTime"); err != nil {
+		return nil, err
+	}
+	return s.trader.GetOrderList(r.Context(), req)
+}
+
+func (s *Server) updateOrder(r *http.Request, params url.Values, body []byte) (interface{}, error) {
+	var req xt.UpdateOrderRequest
+	if err := decodeBody(body, &req); err != nil {
+		return nil, err
+	}
+	return s.trader.UpdateOrder(r.Context(), req)
+}
+
+// --- Account and positions ---
+
+func (s *Server) accountInfo(r *http.Request, params url.Values, body []byte) (interface{}, error) {
+	result := xt.AccountInfoResult{CommonResponse: ok}
+	result.Result.AccountID = AccountID
+	result.Result.UserID = UserID
+	result.Result.AllowOpenPosition = true
+	result.Result.AllowTrade = true
+	return result, nil
+}
+
+func (s *Server) listenKey(r *http.Request, params url.Values, body []byte) (interface{}, error) {
+	result := xt.ListenKeyResult{CommonResponse: ok}
+	result.Result.ListenKey = ListenKey
+	return result, nil
+}
+
+func (s *Server) balance(r *http.Request, params url.Values, body []byte) (interface{}, error) {
+	return s.trader.GetBalance(r.Context(), params.Get("coin"))
+}
+
+func (s *Server) positions(r *http.Request, params url.Values, body []byte) (interface{}, error) {
+	return s.trader.GetPositions(r.Context(), optString(params, "symbol"))
+}
+
+func (s *Server) adjustLeverage(r *http.Request, params url.Values, body []byte) (interface{}, error) {
+	leverage, err := strconv.Atoi(params.Get("leverage"))
+	if err != nil {
+		return nil, invalid("invalid leverage %q", params.Get("leverage"))
+	}
+	return s.trader.AdjustLeverage(r.Context(), params.Get("symbol"), params.Get("positionSide"), leverage)
+}
+
+func (s *Server) updateMargin(r *http.Request, params url.Values, body []byte) (interface{}, error) {
+	return s.trader.UpdatePositionMargin(r.Context(), params.Get("symbol"), params.Get("margin"), params.Get("type"), optString(params, "positionSide"))
+}
+
+// --- Plan orders ---
+
+func (s *Server) createPlan(r *http.Request, params url.Values, body []byte) (interface{}, error) {
+	var req xt.CreatePlanOrderRequest
+	if err := decodeBody(body, &req); err != nil {
+		return nil, err
+	}
+	return s.trader.CreatePlanOrder(r.Context(), req)
+}
+
+func (s *Server) cancelPlan(r *http.Request, params url.Values, body []byte) (interface{}, error) {
+	id, err := paramInt64(params, "entrustId")
+	if err != nil {
+		return nil, err
+	}
+	return s.trader.CancelPlanOrder(r.Context(), id)
+}
+
+func (s *Server) cancelPlans(r *http.Request, params url.Values, body []byte) (interface{}, error) {
+	return s.trader.CancelAllPlanOrder(r.Context(), params.Get("symbol"))
+}
+
+func (s *Server) listPlans(r *http.Request, params url.Values, body []byte) (interface{}, error) {
+	req := xt.GetPlanOrderListRequest{Symbol: params.Get("symbol"), State: params.Get("state")}
+	var err error
+	if req.Page, err = optInt(params, "page"); err != nil {
+		return nil, err
+	}
+	if req.Size, err = optInt(params, "size"); err != nil {
+		return nil, err
+	}
+	if req.StartTime, err = optInt64(params, "startTime"); err != nil {
+		return nil, err
+	}
+	if req.EndTime, err = optInt64(params, "endTime"); err != nil {
+		return nil, err
+	}
+	return s.trader.GetPlanOrderList(r.Context(), req)
+}
+
+func (s *Server) getPlan(r *http.Request, params url.Values, body []byte) (interface{}, error) {
+	id, err := paramInt64(params, "entrustId")
+	if err != nil {
+		return nil, err
+	}
+	return s.trader.GetPlanOrderDetail(r.Context(), id)
+}
@@ -0,0 +1,77 @@
+package credentials
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+	"sync"
+	"time"
+)
+
+// commandProvider runs a command for the key pair and caches its output.
+type commandProvider struct {
+	name string
+	args []string
+	ttl  time.Duration
+
+	mu      sync.Mutex
+	creds   *Credentials
+	fetched time.Time
+	closed  bool
+}
+
+// Command returns a Provider that runs name with args, e.g. a password
+// manager or vault CLI, and reads a JSON object {"apiKey": ..., "secretKey":
+// ...} from its standard output. The result is reused for ttl, so the keys
+// rotate within ttl of the command returning new ones; a zero ttl runs the
+// command for every request.
+func Command(ttl time.Duration, name string, args ...string) Provider {
+	return &commandProvider{name: name, args: args, ttl: ttl}
+}
+
+func (p *commandProvider) Credentials(ctx context.Context) (*Credentials, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.closed {
+		return nil, ErrClosed
+	}
+	if p.creds != nil && time.Since(p.fetched) < p.ttl {
+		return p.creds.clone(), nil
+	}
+
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, p.name, p.args...)
+	cmd.Stdout, cmd.Stderr = &stdout, &stderr
+	err := cmd.Run()
+	defer zero(stdout.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("credentials: %s: %w: %s", p.name, err, strings.TrimSpace(stderr.String()))
+	}
+	var out struct {
+		APIKey    string `json:"apiKey"`
+		SecretKey string `json:"secretKey"`
+	}
+	if err := json.Unmarshal(stdout.Bytes(), &out); err != nil {
+		return nil, fmt.Errorf("credentials: %s: malformed output: %w", p.name, err)
+	}
+	if out.APIKey == "" || out.SecretKey == "" {
+		return nil, fmt.Errorf("credentials: %s: %w", p.name, errors.New("apiKey or secretKey missing from output"))
+	}
+	p.creds.Zero()
+	p.creds = &Credentials{APIKey: out.APIKey, Secret: []byte(out.SecretKey)}
+	p.fetched = time.Now()
+	return p.creds.clone(), nil
+}
+
+func (p *commandProvider) Close() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.creds.Zero()
+	p.creds = nil
+	p.closed = true
+	return nil
+}
@@ -0,0 +1,140 @@
+// Package credentials supplies API keys to the connector clients.
+//
+// A client consults its Provider for every signed request, so keys can be
+// rotated without recreating the client: Static is updated with Set, and the
+// file-based providers pick up changed files on the next request. Secrets are
+// handed out as byte slices that the client zeroes once the request is
+// signed, and Close zeroes the copies a provider keeps:
+//
+//	provider := credentials.Env("GATE_API_KEY", "GATE_API_SECRET")
+//	client := gateio.NewPublicOnly(nil)
+//	client.SetCredentials(provider)
+//	defer client.Close()
+package credentials
+
+import (
+	"context"
+	"errors"
+	"os"
+	"sync"
+)
+
+// ErrClosed is returned by a Provider after Close.
+var ErrClosed = errors.New("credentials: provider closed")
+
+// Credentials is an API key pair.
+type Credentials struct {
+	APIKey string
+	Secret []byte
+}
+
+// Zero overwrites the secret.
+func (c *Credentials) Zero() {
+	if c == nil {
+		return
+	}
+	zero(c.Secret)
+	c.Secret = nil
+}
+
+// Empty reports whether c holds no API key.
+func (c *Credentials) Empty() bool {
+	return c == nil || c.APIKey == ""
+}
+
+// clone returns a copy of c whose secret the caller owns.
+func (c *Credentials) clone() *Credentials {
+	return &Credentials{APIKey: c.APIKey, Secret: append([]byte(nil), c.Secret...)}
+}
+
+func zero(b []byte) {
+	for i := range b {
+		b[i] = 0
+	}
+}
+
+// Provider supplies the credentials of signed requests.
+type Provider interface {
+	// Credentials returns the current key pair. The caller owns the returned
+	// value and should Zero it when done.
+	Credentials(ctx context.Context) (*Credentials, error)
+	// Close zeroes the secrets held by the provider. Credentials fails with
+	// ErrClosed afterwards.
+	Close() error
+}
+
+// Static is a Provider holding a key pair in memory. It is safe for
+// concurrent use.
+type Static struct {
+	mu     sync.RWMutex
+	creds  *Credentials
+	closed bool
+}
+
+// NewStatic creates a provider for apiKey and secretKey.
+func NewStatic(apiKey, secretKey string) *Static {
+	s := &Static{}
+	s.Set(apiKey, []byte(secretKey))
+	return s
+}
+
+// Set replaces the key pair, zeroing the previous secret. secret is copied.
+func (s *Static) Set(apiKey string, secret []byte) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.creds.Zero()
+	s.creds = (&Credentials{APIKey: apiKey, Secret: secret}).clone()
+	s.closed = false
+}
+
+// Credentials returns a copy of the key pair.
+func (s *Static) Credentials(context.Context) (*Credentials, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if s.closed {
+		return nil, ErrClosed
+	}
+	return s.creds.clone(), nil
+}
+
+// Close zeroes the secret.
+func (s *Static) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.creds.Zero()
+	s.closed = true
+	return nil
+}
+
+// envProvider reads the key pair from environment variables.
+type envProvider struct {
+	keyVar, secretVar string
+
+	mu     sync.RWMutex
+	closed bool
+}
+
+// Env returns a Provider reading the key pair from the environment
+// variables keyVar and secretVar on every request, so changes made with
+// os.Setenv take effect immediately. Unset variables yield empty
+// credentials, i.e. unsigned public requests.
+func Env(keyVar, secretVar string) Provider {
+	return &envProvider{keyVar: keyVar, secretVar: secretVar}
+}
+
+func (p *envProvider) Credentials(context.Context) (*Credentials, error) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	if p.closed {
+		return nil, ErrClosed
+	}
+	return &Credentials{APIKey: os.Getenv(p.keyVar), Secret: []byte(os.Getenv(p.secretVar))}, nil
+}
+
+// Close stops the provider. The process environment is left unchanged.
+func (p *envProvider) Close() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.closed = true
+	return nil
+}
@@ -0,0 +1,226 @@
+package credentials
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/joho/godotenv"
+)
+
+// fileProvider caches the key pair parsed from a file and reloads it when
+// the file's modification time or size changes.
+type fileProvider struct {
+	path  string
+	parse func(data []byte) (*Credentials, error)
+	wipe  func() // Zeroes provider state other than the cached pair; may be nil
+
+	mu      sync.Mutex
+	creds   *Credentials
+	modTime time.Time
+	size    int64
+	closed  bool
+}
+
+func (p *fileProvider) Credentials(context.Context) (*Credentials, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.closed {
+		return nil, ErrClosed
+	}
+	info, err := os.Stat(p.path)
+	if err != nil {
+		return nil, fmt.Errorf("credentials: %w", err)
+	}
+	if p.creds == nil || !info.ModTime().Equal(p.modTime) || info.Size() != p.size {
+		data, err := os.ReadFile(p.path)
+		if err != nil {
+			return nil, fmt.Errorf("credentials: %w", err)
+		}
+		creds, err := p.parse(data)
+		zero(data)
+		if err != nil {
+			return nil, fmt.Errorf("credentials: %s: %w", p.path, err)
+		}
+		p.creds.Zero()
+		p.creds, p.modTime, p.size = creds, info.ModTime(), info.Size()
+	}
+	return p.creds.clone(), nil
+}
+
+func (p *fileProvider) Close() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.creds.Zero()
+	p.creds = nil
+	if p.wipe != nil {
+		p.wipe()
+	}
+	p.closed = true
+	return nil
+}
+
+// File returns a Provider reading the key pair from the variables keyVar and
+// secretVar of a dotenv file (such as .env.local). The file is re-read when
+// it changes, so replacing it rotates the keys.
+func File(path, keyVar, secretVar string) Provider {
+	return &fileProvider{path: path, parse: func(data []byte) (*Credentials, error) {
+		env, err := godotenv.UnmarshalBytes(data)
+		if err != nil {
+			return nil, err
+		}
+		if env[keyVar] == "" || env[secretVar] == "" {
+			return nil, fmt.Errorf("%s or %s not set", keyVar, secretVar)
+		}
+		return &Credentials{APIKey: env[keyVar], Secret: []byte(env[secretVar])}, nil
+	}}
+}
+
+// Encrypted keyfiles hold the key pair as JSON sealed with AES-256-GCM under
+// a key derived from a passphrase with PBKDF2-HMAC-SHA256. Keyfiles asking
+// for more than maxKeyfileIterations are rejected so that a tampered file
+// cannot stall the key derivation.
+const (
+	keyfileVersion       = 1
+	keyfileIterations    = 600000
+	maxKeyfileIterations = 4 * keyfileIterations
+)
+
+type keyfile struct {
+	Version    int    `json:"version"`
+	KDF        string `json:"kdf"`
+	Iterations int    `json:"iterations"`
+	Salt       []byte `json:"salt"`
+	Nonce      []byte `json:"nonce"`
+	Ciphertext []byte `json:"ciphertext"`
+}
+
+type keyfilePlaintext struct {
+	APIKey string `json:"apiKey"`
+	Secret []byte `json:"secret"`
+}
+
+// EncryptedFile returns a Provider reading the key pair from a keyfile
+// written by WriteEncryptedFile. The passphrase is copied and zeroed on
+// Close. The file is decrypted again when it changes.
+func EncryptedFile(path string, passphrase []byte) Provider {
+	pass := append([]byte(nil), passphrase...)
+	return &fileProvider{
+		path:  path,
+		parse: func(data []byte) (*Credentials, error) { return decryptKeyfile(data, pass) },
+		wipe:  func() { zero(pass) },
+	}
+}
+
+// WriteEncryptedFile seals creds with passphrase and writes the keyfile to
+// path with mode 0600.
+func WriteEncryptedFile(path string, creds *Credentials, passphrase []byte) error {
+	plaintext, err := json.Marshal(keyfilePlaintext{APIKey: creds.APIKey, Secret: creds.Secret})
+	if err != nil {
+		return fmt.Errorf("credentials: %w", err)
+	}
+	defer zero(plaintext)
+
+	kf := keyfile{Version: keyfileVersion, KDF: "pbkdf2-sha256", Iterations: keyfileIterations, Salt: make([]byte, 16)}
+	if _, err := rand.Read(kf.Salt); err != nil {
+		return fmt.Errorf("credentials: %w", err)
+	}
+	aead, err := keyfileCipher(passphrase, kf.Salt, kf.Iterations)
+	if err != nil {
+		return err
+	}
+	kf.Nonce = make([]byte, aead.NonceSize())
+	if _, err := rand.Read(kf.Nonce); err != nil {
+		return fmt.Errorf("credentials: %w", err)
+	}
+	kf.Ciphertext = aead.Seal(nil, kf.Nonce, plaintext, nil)
+
+	data, err := json.MarshalIndent(kf, "", "  ")
+	if err != nil {
+		return fmt.Errorf("credentials: %w", err)
+	}
+	if err := os.WriteFile(path, append(data, '\n'), 0o600); err != nil {
+		return fmt.Errorf("credentials: %w", err)
+	}
+	return nil
+}
+
+func decryptKeyfile(data, passphrase []byte) (*Credentials, error) {
+	var kf keyfile
+	if err := json.Unmarshal(data, &kf); err != nil {
+		return nil, err
+	}
+	if kf.Version != keyfileVersion || kf.KDF != "pbkdf2-sha256" {
+		return nil, fmt.Errorf("unsupported keyfile (version %d, kdf %q)", kf.Version, kf.KDF)
+	}
+	if kf.Iterations <= 0 || kf.Iterations > maxKeyfileIterations {
+		return nil, fmt.Errorf("unsupported keyfile iteration count %d (maximum %d)", kf.Iterations, maxKeyfileIterations)
+	}
+	aead, err := keyfileCipher(passphrase, kf.Salt, kf.Iterations)
+	if err != nil {
+		return nil, err
+	}
+	if len(kf.Nonce) != aead.NonceSize() {
+		return nil, errors.New("malformed keyfile nonce")
+	}
+	plaintext, err := aead.Open(nil, kf.Nonce, kf.Ciphertext, nil)
+	if err != nil {
+		return nil, errors.New("wrong passphrase or corrupted keyfile")
+	}
+	defer zero(plaintext)
+	var pt keyfilePlaintext
+	if err := json.Unmarshal(plaintext, &pt); err != nil {
+		return nil, err
+	}
+	return &Credentials{APIKey: pt.APIKey, Secret: pt.Secret}, nil
+}
+
+func keyfileCipher(passphrase, salt []byte, iterations int) (cipher.AEAD, error) {
+	key := pbkdf2SHA256(passphrase, salt, iterations, 32)
+	defer zero(key)
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("credentials: %w", err)
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, fmt.Errorf("credentials: %w", err)
+	}
+	return aead, nil
+}
+
+// pbkdf2SHA256 derives a key of keyLen bytes as specified in RFC 8018.
+func pbkdf2SHA256(password, salt []byte, iterations, keyLen int) []byte {
+	prf := hmac.New(sha256.New, password)
+	key := make([]byte, 0, keyLen)
+	u := make([]byte, sha256.Size)
+	for block := uint32(1); len(key) < keyLen; block++ {
+		prf.Reset()
+		prf.Write(salt)
+		prf.Write(binary.BigEndian.AppendUint32(nil, block))
+		u = prf.Sum(u[:0])
+		t := append([]byte(nil), u...)
+		for i := 1; i < iterations; i++ {
+			prf.Reset()
+			prf.Write(u)
+			u = prf.Sum(u[:0])
+			for j := range t {
+				t[j] ^= u[j]
+			}
+		}
+		key = append(key, t...)
+		zero(t)
+	}
+	zero(u)
+	return key[:keyLen]
+}
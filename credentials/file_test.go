@@ -0,0 +1,105 @@
+package credentials
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// PBKDF2-HMAC-SHA256 known answers for the RFC 6070 inputs.
+var pbkdf2Vectors = []struct {
+	password, salt string
+	iterations     int
+	key            string
+}{
+	{"password", "salt", 1, "120fb6cffcf8b32c43e7225256c4f837a86548c92ccc35480805987cb70be17b"},
+	{"password", "salt", 2, "ae4d0c95af6b46d32d0adff928f06dd02a303f8ef3c251dfd6e2d85a95474c43"},
+	{"password", "salt", 4096, "c5e478d59288c841aa530db6845c4c8d962893a001ce4e11a4963873aa98134a"},
+	{ // Two output blocks
+		"passwordPASSWORDpassword", "saltSALTsaltSALTsaltSALTsaltSALTsalt", 4096,
+		"348c89dbcbd32b2f32d814b8116e84cf2b17347ebc1800181c4e2a1fb8dd53e1c635518c7dac47e9",
+	},
+	{"pass\x00word", "sa\x00lt", 4096, "89b69d0516f829893c696226650a8687"},
+}
+
+func TestPBKDF2SHA256(t *testing.T) {
+	for _, v := range pbkdf2Vectors {
+		want, _ := hex.DecodeString(v.key)
+		got := pbkdf2SHA256([]byte(v.password), []byte(v.salt), v.iterations, len(want))
+		if hex.EncodeToString(got) != v.key {
+			t.Errorf("pbkdf2(%q, %q, %d) = %x, want %s", v.password, v.salt, v.iterations, got, v.key)
+		}
+	}
+}
+
+func TestEncryptedFile(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "keys.json")
+	passphrase := []byte("correct horse battery staple")
+	in := &Credentials{APIKey: "api-key", Secret: []byte("secret-key")}
+	if err := WriteEncryptedFile(path, in, passphrase); err != nil {
+		t.Fatal(err)
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if perm := info.Mode().Perm(); perm != 0o600 {
+		t.Errorf("keyfile mode = %o, want 600", perm)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(data), "api-key") || strings.Contains(string(data), "secret-key") {
+		t.Error("keyfile contains the plaintext key pair")
+	}
+
+	provider := EncryptedFile(path, passphrase)
+	out, err := provider.Credentials(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if out.APIKey != in.APIKey || string(out.Secret) != string(in.Secret) {
+		t.Errorf("decrypted %q/%q, want %q/%q", out.APIKey, out.Secret, in.APIKey, in.Secret)
+	}
+	if err := provider.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := provider.Credentials(ctx); !errors.Is(err, ErrClosed) {
+		t.Errorf("Credentials after Close: %v, want ErrClosed", err)
+	}
+
+	if _, err := EncryptedFile(path, []byte("wrong")).Credentials(ctx); err == nil {
+		t.Error("keyfile decrypted with a wrong passphrase")
+	}
+}
+
+func TestKeyfileIterationBounds(t *testing.T) {
+	passphrase := []byte("correct horse battery staple")
+	path := filepath.Join(t.TempDir(), "keys.json")
+	if err := WriteEncryptedFile(path, &Credentials{APIKey: "api-key", Secret: []byte("secret-key")}, passphrase); err != nil {
+		t.Fatal(err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, iterations := range []int{0, -1, maxKeyfileIterations + 1, 1 << 40} {
+		var kf keyfile
+		if err := json.Unmarshal(data, &kf); err != nil {
+			t.Fatal(err)
+		}
+		kf.Iterations = iterations
+		tampered, _ := json.Marshal(kf)
+		if _, err := decryptKeyfile(tampered, passphrase); err == nil || !strings.Contains(err.Error(), "iteration count") {
+			t.Errorf("%d iterations: %v, want an iteration count error", iterations, err)
+		}
+	}
+}
@@ -0,0 +1,389 @@
+// Package decimal provides the fixed-point decimal type used for prices,
+// sizes, rates and balances in the connector types.
+//
+// A Decimal is an arbitrary-precision integer coefficient scaled by a power
+// of ten, so values such as "0.1" are represented exactly and the digits
+// received from an exchange round-trip unchanged. JSON decoding accepts both
+// string ("123.45") and number (123.45) encodings, and empty strings and null
+// decode to zero; encoding always produces a string, which both Gate.io and
+// XT accept.
+//
+// Decimals are immutable values; the zero value is 0 and ready to use.
+package decimal
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// Decimal is a fixed-point decimal number: coef × 10^exp.
+type Decimal struct {
+	coef *big.Int // nil means zero; never mutated after construction
+	exp  int32
+}
+
+// Zero is the decimal 0.
+var Zero = Decimal{}
+
+var bigTen = big.NewInt(10)
+
+// maxExponent bounds the exponent accepted by Parse. Aligning two values
+// multiplies by 10^(exponent difference), so unbounded exponents from
+// untrusted input would make arithmetic and formatting arbitrarily slow.
+const maxExponent = 1000
+
+// New returns coef × 10^exp, e.g. New(12345, -2) is 123.45.
+func New(coef int64, exp int32) Decimal {
+	return Decimal{coef: big.NewInt(coef), exp: exp}
+}
+
+// NewFromInt returns the decimal value of i.
+func NewFromInt(i int64) Decimal {
+	return New(i, 0)
+}
+
+// NewFromFloat returns the shortest decimal that round-trips to f.
+// NaN and infinities return zero.
+func NewFromFloat(f float64) Decimal {
+	d, err := Parse(strconv.FormatFloat(f, 'f', -1, 64))
+	if err != nil {
+		return Zero
+	}
+	return d
+}
+
+// Parse parses a decimal string such as "-12.340", "1e-8" or "+5".
+// Values whose exponent (including the digits after the decimal point) is
+// beyond ±1000 are rejected.
+func Parse(s string) (Decimal, error) {
+	orig := s
+	if s == "" {
+		return Zero, fmt.Errorf("decimal: cannot parse empty string")
+	}
+	var exp int64
+	if i := strings.IndexAny(s, "eE"); i >= 0 {
+		e, err := strconv.ParseInt(s[i+1:], 10, 32)
+		if err != nil {
+			return Zero, fmt.Errorf("decimal: invalid exponent in %q", orig)
+		}
+		exp = e
+		s = s[:i]
+	}
+	if i := strings.IndexByte(s, '.'); i >= 0 {
+		frac := s[i+1:]
+		exp -= int64(len(frac))
+		s = s[:i] + frac
+	}
+	if s == "" || s == "+" || s == "-" {
+		return Zero, fmt.Errorf("decimal: cannot parse %q", orig)
+	}
+	for i, r := range s {
+		if (r < '0' || r > '9') && !(i == 0 && (r == '+' || r == '-')) {
+			return Zero, fmt.Errorf("decimal: cannot parse %q", orig)
+		}
+	}
+	coef, ok := new(big.Int).SetString(s, 10)
+	if !ok {
+		return Zero, fmt.Errorf("decimal: cannot parse %q", orig)
+	}
+	if exp < -maxExponent || exp > maxExponent {
+		return Zero, fmt.Errorf("decimal: exponent out of range in %q", orig)
+	}
+	return Decimal{coef: coef, exp: int32(exp)}, nil
+}
+
+// MustParse is like Parse but panics on error. Intended for constants.
+func MustParse(s string) Decimal {
+	d, err := Parse(s)
+	if err != nil {
+		panic(err)
+	}
+	return d
+}
+
+func (d Decimal) coefficient() *big.Int {
+	if d.coef == nil {
+		return new(big.Int)
+	}
+	return d.coef
+}
+
+// rescale returns the coefficient of d expressed with exponent exp (exp <= d.exp).
+func (d Decimal) rescale(exp int32) *big.Int {
+	c := new(big.Int).Set(d.coefficient())
+	if exp < d.exp {
+		c.Mul(c, pow10(int64(d.exp-exp)))
+	}
+	return c
+}
+
+func pow10(n int64) *big.Int {
+	return new(big.Int).Exp(bigTen, big.NewInt(n), nil)
+}
+
+// align returns the coefficients of a and b at their common (smaller) exponent.
+func align(a, b Decimal) (*big.Int, *big.Int, int32) {
+	exp := min(a.exp, b.exp)
+	return a.rescale(exp), b.rescale(exp), exp
+}
+
+// String returns the value in plain notation, keeping trailing zeros of the
+// original scale (e.g. "0.10").
+func (d Decimal) String() string {
+	c := d.coefficient()
+	if d.exp >= 0 {
+		return d.rescale(0).String()
+	}
+	neg := c.Sign() < 0
+	digits := new(big.Int).Abs(c).String()
+	scale := int(-d.exp)
+	if len(digits) <= scale {
+		digits = strings.Repeat("0", scale-len(digits)+1) + digits
+	}
+	s := digits[:len(digits)-scale] + "." + digits[len(digits)-scale:]
+	if neg {
+		s = "-" + s
+	}
+	return s
+}
+
+// StringFixed returns the value rounded to places decimal places.
+func (d Decimal) StringFixed(places int32) string {
+	return d.Round(places).rescaleTo(-places).String()
+}
+
+// rescaleTo returns d with exponent exp, which must not be greater than d.exp.
+func (d Decimal) rescaleTo(exp int32) Decimal {
+	if exp > d.exp {
+		return d
+	}
+	return Decimal{coef: d.rescale(exp), exp: exp}
+}
+
+// Float64 returns the nearest float64 value.
+func (d Decimal) Float64() float64 {
+	f, _ := strconv.ParseFloat(d.String(), 64)
+	return f
+}
+
+// IntPart returns the integer part of d, truncated toward zero.
+func (d Decimal) IntPart() int64 {
+	return d.Truncate(0).rescale(0).Int64()
+}
+
+// Sign returns -1, 0 or +1.
+func (d Decimal) Sign() int {
+	return d.coefficient().Sign()
+}
+
+// IsZero reports whether d == 0.
+func (d Decimal) IsZero() bool {
+	return d.Sign() == 0
+}
+
+// Cmp compares d and d2 and returns -1, 0 or +1.
+func (d Decimal) Cmp(d2 Decimal) int {
+	a, b, _ := align(d, d2)
+	return a.Cmp(b)
+}
+
+// Equal reports whether d == d2 (regardless of scale, so 1.0 equals 1).
+func (d Decimal) Equal(d2 Decimal) bool { return d.Cmp(d2) == 0 }
+
+// LessThan reports whether d < d2.
+func (d Decimal) LessThan(d2 Decimal) bool { return d.Cmp(d2) < 0 }
+
+// GreaterThan reports whether d > d2.
+func (d Decimal) GreaterThan(d2 Decimal) bool { return d.Cmp(d2) > 0 }
+
+// Add returns d + d2.
+func (d Decimal) Add(d2 Decimal) Decimal {
+	a, b, exp := align(d, d2)
+	return Decimal{coef: a.Add(a, b), exp: exp}
+}
+
+// Sub returns d - d2.
+func (d Decimal) Sub(d2 Decimal) Decimal {
+	a, b, exp := align(d, d2)
+	return Decimal{coef: a.Sub(a, b), exp: exp}
+}
+
+// Mul returns d × d2.
+func (d Decimal) Mul(d2 Decimal) Decimal {
+	return Decimal{coef: new(big.Int).Mul(d.coefficient(), d2.coefficient()), exp: d.exp + d2.exp}
+}
+
+// Div returns d / d2 rounded half away from zero to places decimal places.
+// It panics if d2 is zero.
+func (d Decimal) Div(d2 Decimal, places int32) Decimal {
+	if d2.IsZero() {
+		panic("decimal: division by zero")
+	}
+	// d/d2 = (dc × 10^(d.exp - d2.exp + places + 1)) / d2c × 10^-(places+1)
+	shift := int64(d.exp) - int64(d2.exp) + int64(places) + 1
+	num := new(big.Int).Set(d.coefficient())
+	den := new(big.Int).Set(d2.coefficient())
+	if shift >= 0 {
+		num.Mul(num, pow10(shift))
+	} else {
+		den.Mul(den, pow10(-shift))
+	}
+	q := new(big.Int).Quo(num, den)
+	return Decimal{coef: q, exp: -(places + 1)}.Round(places)
+}
+
+// Neg returns -d.
+func (d Decimal) Neg() Decimal {
+	return Decimal{coef: new(big.Int).Neg(d.coefficient()), exp: d.exp}
+}
+
+// Abs returns |d|.
+func (d Decimal) Abs() Decimal {
+	return Decimal{coef: new(big.Int).Abs(d.coefficient()), exp: d.exp}
+}
+
+// Round rounds d half away from zero to places decimal places.
+func (d Decimal) Round(places int32) Decimal {
+	return d.quantize(-places, roundHalfUp)
+}
+
+// Truncate drops digits beyond places decimal places (rounding toward zero).
+func (d Decimal) Truncate(places int32) Decimal {
+	return d.quantize(-places, roundDown)
+}
+
+// RoundToTick rounds d to the nearest multiple of tick, half away from zero.
+// A zero or negative tick returns d unchanged.
+func (d Decimal) RoundToTick(tick Decimal) Decimal {
+	return d.toTick(tick, roundHalfUp)
+}
+
+// FloorToTick rounds d down (toward negative infinity) to a multiple of tick,
+// e.g. for bid prices. A zero or negative tick returns d unchanged.
+func (d Decimal) FloorToTick(tick Decimal) Decimal {
+	return d.toTick(tick, roundFloor)
+}
+
+// CeilToTick rounds d up (toward positive infinity) to a multiple of tick,
+// e.g. for ask prices. A zero or negative tick returns d unchanged.
+func (d Decimal) CeilToTick(tick Decimal) Decimal {
+	return d.toTick(tick, roundCeil)
+}
+
+// IsMultipleOf reports whether d is an exact multiple of step.
+func (d Decimal) IsMultipleOf(step Decimal) bool {
+	if step.Sign() <= 0 {
+		return true
+	}
+	a, b, _ := align(d, step)
+	return new(big.Int).Rem(a, b).Sign() == 0
+}
+
+type roundingMode int
+
+const (
+	roundDown   roundingMode = iota // Toward zero
+	roundHalfUp                     // Half away from zero
+	roundFloor                      // Toward negative infinity
+	roundCeil                       // Toward positive infinity
+)
+
+// divRound returns num/den rounded according to mode. den must be positive.
+func divRound(num, den *big.Int, mode roundingMode) *big.Int {
+	q, r := new(big.Int).QuoRem(num, den, new(big.Int))
+	if r.Sign() == 0 {
+		return q
+	}
+	switch mode {
+	case roundHalfUp:
+		twice := new(big.Int).Abs(r)
+		twice.Mul(twice, big.NewInt(2))
+		if twice.Cmp(den) >= 0 {
+			if num.Sign() < 0 {
+				q.Sub(q, big.NewInt(1))
+			} else {
+				q.Add(q, big.NewInt(1))
+			}
+		}
+	case roundFloor:
+		if num.Sign() < 0 {
+			q.Sub(q, big.NewInt(1))
+		}
+	case roundCeil:
+		if num.Sign() > 0 {
+			q.Add(q, big.NewInt(1))
+		}
+	}
+	return q
+}
+
+// quantize rounds d to exponent exp.
+func (d Decimal) quantize(exp int32, mode roundingMode) Decimal {
+	if d.exp >= exp {
+		return d
+	}
+	q := divRound(d.coefficient(), pow10(int64(exp-d.exp)), mode)
+	return Decimal{coef: q, exp: exp}
+}
+
+func (d Decimal) toTick(tick Decimal, mode roundingMode) Decimal {
+	if tick.Sign() <= 0 {
+		return d
+	}
+	a, b, exp := align(d, tick)
+	n := divRound(a, b, mode)
+	return Decimal{coef: n.Mul(n, b), exp: exp}
+}
+
+// MarshalJSON encodes d as a JSON string.
+func (d Decimal) MarshalJSON() ([]byte, error) {
+	return []byte(`"` + d.String() + `"`), nil
+}
+
+// UnmarshalJSON decodes a JSON string or number. Null and "" decode to zero.
+func (d *Decimal) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+	if bytes.Equal(data, []byte("null")) {
+		*d = Zero
+		return nil
+	}
+	s := string(data)
+	if len(data) > 0 && data[0] == '"' {
+		if err := json.Unmarshal(data, &s); err != nil {
+			return fmt.Errorf("decimal: %w", err)
+		}
+		if s == "" {
+			*d = Zero
+			return nil
+		}
+	}
+	v, err := Parse(s)
+	if err != nil {
+		return err
+	}
+	*d = v
+	return nil
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (d Decimal) MarshalText() ([]byte, error) {
+	return []byte(d.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler. An empty text decodes to zero.
+func (d *Decimal) UnmarshalText(text []byte) error {
+	if len(text) == 0 {
+		*d = Zero
+		return nil
+	}
+	v, err := Parse(string(text))
+	if err != nil {
+		return err
+	}
+	*d = v
+	return nil
+}
@@ -0,0 +1,157 @@
+package decimal_test
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/neqin/futures/decimal"
+)
+
+func TestParseString(t *testing.T) {
+	tests := []struct {
+		in, want string
+	}{
+		{"0", "0"},
+		{"0.10", "0.10"},
+		{"-12.340", "-12.340"},
+		{"+5", "5"},
+		{"1e-8", "0.00000001"},
+		{"1.5E3", "1500"},
+		{"-0.000123", "-0.000123"},
+		{"123456789012345678901234567890.123456789", "123456789012345678901234567890.123456789"},
+		{"1e1000", "1" + strings.Repeat("0", 1000)},
+	}
+	for _, tt := range tests {
+		d, err := decimal.Parse(tt.in)
+		if err != nil {
+			t.Errorf("Parse(%q): %v", tt.in, err)
+			continue
+		}
+		if got := d.String(); got != tt.want {
+			t.Errorf("Parse(%q).String() = %q, want %q", tt.in, got, tt.want)
+		}
+		if again := decimal.MustParse(d.String()); !again.Equal(d) || again.String() != d.String() {
+			t.Errorf("Parse(%q) does not round-trip: %s", tt.in, again)
+		}
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	for _, in := range []string{
+		"", "+", "-", ".", "abc", "1.2.3", "1e", "1ex", "--1", "1-",
+		"1e20000000", "1e-20000000", "1e1001", "0." + strings.Repeat("0", 1001) + "1",
+	} {
+		if d, err := decimal.Parse(in); err == nil {
+			t.Errorf("Parse(%q) = %s, want error", in, d)
+		}
+	}
+}
+
+func TestJSON(t *testing.T) {
+	var v struct {
+		A, B, C, D decimal.Decimal
+	}
+	if err := json.Unmarshal([]byte(`{"A":"0.10","B":123.45,"C":"","D":null}`), &v); err != nil {
+		t.Fatal(err)
+	}
+	if v.A.String() != "0.10" || v.B.String() != "123.45" || !v.C.IsZero() || !v.D.IsZero() {
+		t.Errorf("decoded %s %s %s %s", v.A, v.B, v.C, v.D)
+	}
+	out, err := json.Marshal(v.A)
+	if err != nil || string(out) != `"0.10"` {
+		t.Errorf("Marshal = %s, %v", out, err)
+	}
+	if err := json.Unmarshal([]byte(`"1e20000000"`), &v.A); err == nil {
+		t.Error("Unmarshal accepted an out-of-range exponent")
+	}
+}
+
+func TestDiv(t *testing.T) {
+	tests := []struct {
+		a, b   string
+		places int32
+		want   string
+	}{
+		{"1", "3", 4, "0.3333"},
+		{"2", "3", 4, "0.6667"},
+		{"-2", "3", 4, "-0.6667"},
+		{"1", "8", 2, "0.13"},   // 0.125 rounds half away from zero
+		{"-1", "8", 2, "-0.13"}, // Likewise for negative values
+		{"10", "4", 0, "3"},
+		{"100", "0.25", 2, "400.00"},
+		{"0.0001", "3", 6, "0.000033"},
+		{"1", "-7", 3, "-0.143"},
+	}
+	for _, tt := range tests {
+		got := decimal.MustParse(tt.a).Div(decimal.MustParse(tt.b), tt.places)
+		if got.String() != tt.want {
+			t.Errorf("%s / %s (%d places) = %s, want %s", tt.a, tt.b, tt.places, got, tt.want)
+		}
+	}
+}
+
+func TestDivByZero(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("Div by zero did not panic")
+		}
+	}()
+	decimal.NewFromInt(1).Div(decimal.Zero, 2)
+}
+
+func TestTick(t *testing.T) {
+	tests := []struct {
+		d, tick            string
+		round, floor, ceil string
+	}{
+		{"30000.04", "0.1", "30000.00", "30000.00", "30000.10"}, // Result keeps the finer scale
+		{"30000.05", "0.1", "30000.10", "30000.00", "30000.10"},
+		{"30000.1", "0.1", "30000.1", "30000.1", "30000.1"},
+		{"-1.25", "0.5", "-1.50", "-1.50", "-1.00"},
+		{"-1.24", "0.5", "-1.00", "-1.50", "-1.00"},
+		{"17", "5", "15", "15", "20"},
+		{"0.0123", "0.005", "0.0100", "0.0100", "0.0150"},
+		{"0.0125", "0.005", "0.0150", "0.0100", "0.0150"},
+		{"1.23", "0", "1.23", "1.23", "1.23"}, // Zero tick leaves d unchanged
+		{"1.23", "-0.1", "1.23", "1.23", "1.23"},
+	}
+	for _, tt := range tests {
+		d, tick := decimal.MustParse(tt.d), decimal.MustParse(tt.tick)
+		if got := d.RoundToTick(tick).String(); got != tt.round {
+			t.Errorf("%s.RoundToTick(%s) = %s, want %s", tt.d, tt.tick, got, tt.round)
+		}
+		if got := d.FloorToTick(tick).String(); got != tt.floor {
+			t.Errorf("%s.FloorToTick(%s) = %s, want %s", tt.d, tt.tick, got, tt.floor)
+		}
+		if got := d.CeilToTick(tick).String(); got != tt.ceil {
+			t.Errorf("%s.CeilToTick(%s) = %s, want %s", tt.d, tt.tick, got, tt.ceil)
+		}
+		if r := d.RoundToTick(tick); tick.Sign() > 0 && !r.IsMultipleOf(tick) {
+			t.Errorf("%s.RoundToTick(%s) = %s is not a multiple of the tick", tt.d, tt.tick, r)
+		}
+	}
+}
+
+func TestIsMultipleOf(t *testing.T) {
+	tests := []struct {
+		d, step string
+		want    bool
+	}{
+		{"0.3", "0.1", true},
+		{"0.30", "0.1", true},
+		{"0.35", "0.1", false},
+		{"-0.3", "0.1", true},
+		{"15", "5", true},
+		{"16", "5", false},
+		{"0", "0.001", true},
+		{"1.2345", "0.0005", true},
+		{"1.2346", "0.0005", false},
+		{"1.23", "0", true}, // Non-positive steps accept every value
+	}
+	for _, tt := range tests {
+		if got := decimal.MustParse(tt.d).IsMultipleOf(decimal.MustParse(tt.step)); got != tt.want {
+			t.Errorf("%s.IsMultipleOf(%s) = %t, want %t", tt.d, tt.step, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,35 @@
+package exchange
+
+import "errors"
+
+// Error classes shared by all connectors. Venue error types (gateio.APIError,
+// xt.APIError) match them with errors.Is, so callers can branch on the kind
+// of failure without knowing each venue's error codes:
+//
+//	if errors.Is(err, exchange.ErrInsufficientBalance) { ... }
+var (
+	ErrAuth                = errors.New("authentication failed")     // Invalid key, signature, timestamp or permissions
+	ErrRateLimit           = errors.New("rate limit exceeded")       // Request rejected by the venue's rate limiter
+	ErrInsufficientBalance = errors.New("insufficient balance")      // Not enough balance or margin for the request
+	ErrOrderNotFound       = errors.New("order not found")           // Order does not exist or is already finished
+	ErrInvalidParameter    = errors.New("invalid request parameter") // Malformed or out-of-range request parameter, including unknown symbols
+)
+
+// ValidationError reports an order rejected by a connector's local pre-trade
+// validation, before anything was sent to the venue. It matches
+// ErrInvalidParameter.
+type ValidationError struct {
+	Symbol string // Venue symbol of the order
+	Field  string // Offending field, e.g. "price", "size", "notional"
+	Reason string // Human-readable explanation including the limit that was violated
+}
+
+// Error returns a description of the violated rule.
+func (e *ValidationError) Error() string {
+	return "order validation failed for " + e.Symbol + ": " + e.Field + " " + e.Reason
+}
+
+// Is reports whether target is ErrInvalidParameter.
+func (e *ValidationError) Is(target error) bool {
+	return target == ErrInvalidParameter
+}
@@ -0,0 +1,60 @@
+// Package exchange defines a venue-agnostic view of a futures exchange.
+//
+// The connectors under connectors/ expose each venue's REST API as-is. This
+// package describes the common subset that strategies need (market data,
+// order management and account state) using normalized request and response
+// types, so that a bot can be pointed at a different venue by swapping the
+// adapter it is given instead of rewriting its code.
+//
+// Symbols are written in canonical "BASE_QUOTE" upper-case form (e.g.
+// "BTC_USDT"); adapters translate to the venue's own spelling. Sizes are
+// expressed in the venue's contract units.
+package exchange
+
+import "context"
+
+// MarketData provides public market information for a single venue.
+type MarketData interface {
+	// Ticker returns the latest ticker for a symbol.
+	Ticker(ctx context.Context, symbol string) (*Ticker, error)
+	// OrderBook returns an order book snapshot limited to depth levels per side.
+	OrderBook(ctx context.Context, symbol string, depth int) (*OrderBook, error)
+	// Trades returns up to limit recent public trades, newest first.
+	Trades(ctx context.Context, symbol string, limit int) ([]Trade, error)
+	// Candles returns up to limit candles for the given interval (e.g. "1m", "1h").
+	Candles(ctx context.Context, symbol, interval string, limit int) ([]Candle, error)
+}
+
+// Trading places and manages orders on a single venue.
+type Trading interface {
+	// PlaceOrder submits a new order and returns it as acknowledged by the venue.
+	PlaceOrder(ctx context.Context, req OrderRequest) (*Order, error)
+	// CancelOrder cancels a single order by its venue order ID.
+	CancelOrder(ctx context.Context, symbol, orderID string) error
+	// CancelAllOrders cancels every open order on a symbol.
+	CancelAllOrders(ctx context.Context, symbol string) error
+	// GetOrder returns the current state of an order.
+	GetOrder(ctx context.Context, symbol, orderID string) (*Order, error)
+	// OpenOrders lists the open orders on a symbol.
+	OpenOrders(ctx context.Context, symbol string) ([]Order, error)
+}
+
+// Account exposes balances, positions and leverage settings.
+type Account interface {
+	// Balances returns the futures wallet balances.
+	Balances(ctx context.Context) ([]Balance, error)
+	// Positions returns all non-empty positions.
+	Positions(ctx context.Context) ([]Position, error)
+	// SetLeverage changes the leverage used for a symbol.
+	SetLeverage(ctx context.Context, symbol string, leverage int) error
+}
+
+// Exchange is the full set of capabilities offered by an adapter.
+type Exchange interface {
+	// Name returns the venue identifier (e.g. "gateio", "xt").
+	Name() string
+
+	MarketData
+	Trading
+	Account
+}
@@ -0,0 +1,151 @@
+package exchange
+
+import "time"
+
+// Side is the direction of an order or trade.
+type Side string
+
+const (
+	Buy  Side = "buy"
+	Sell Side = "sell"
+)
+
+// OrderType is the execution type of an order.
+type OrderType string
+
+const (
+	Limit  OrderType = "limit"
+	Market OrderType = "market"
+)
+
+// TimeInForce controls how long an order stays active.
+type TimeInForce string
+
+const (
+	GTC      TimeInForce = "gtc"       // Good till cancelled
+	IOC      TimeInForce = "ioc"       // Immediate or cancel
+	FOK      TimeInForce = "fok"       // Fill or kill
+	PostOnly TimeInForce = "post_only" // Maker only, rejected if it would take
+)
+
+// PositionSide identifies a position leg.
+type PositionSide string
+
+const (
+	Long  PositionSide = "long"
+	Short PositionSide = "short"
+)
+
+// OrderStatus is the normalized lifecycle state of an order.
+type OrderStatus string
+
+const (
+	StatusOpen            OrderStatus = "open"
+	StatusPartiallyFilled OrderStatus = "partially_filled"
+	StatusFilled          OrderStatus = "filled"
+	StatusCanceled        OrderStatus = "canceled"
+	StatusRejected        OrderStatus = "rejected"
+)
+
+// Ticker is a normalized market ticker.
+type Ticker struct {
+	Symbol      string
+	Last        float64
+	Bid         float64 // Best bid, 0 if not provided by the venue
+	Ask         float64 // Best ask, 0 if not provided by the venue
+	MarkPrice   float64
+	IndexPrice  float64
+	High24h     float64
+	Low24h      float64
+	Volume24h   float64 // 24h volume in contracts
+	FundingRate float64
+	Time        time.Time
+}
+
+// OrderBookLevel is a single price level.
+type OrderBookLevel struct {
+	Price float64
+	Size  float64
+}
+
+// OrderBook is a normalized order book snapshot. Bids are sorted by price
+// descending and asks by price ascending.
+type OrderBook struct {
+	Symbol   string
+	Bids     []OrderBookLevel
+	Asks     []OrderBookLevel
+	UpdateID int64 // Venue sequence number of the snapshot, 0 if unavailable
+	Time     time.Time
+}
+
+// Trade is a normalized public trade.
+type Trade struct {
+	ID     string
+	Symbol string
+	Price  float64
+	Size   float64 // Always positive, see Side
+	Side   Side    // Taker side
+	Time   time.Time
+}
+
+// Candle is a normalized OHLCV bar.
+type Candle struct {
+	Time   time.Time // Bar open time
+	Open   float64
+	High   float64
+	Low    float64
+	Close  float64
+	Volume float64
+}
+
+// OrderRequest describes an order to be placed.
+type OrderRequest struct {
+	Symbol        string
+	Side          Side
+	Type          OrderType
+	Size          float64 // Always positive, in contracts
+	Price         float64 // Ignored for market orders
+	TimeInForce   TimeInForce
+	ReduceOnly    bool
+	ClientOrderID string       // Optional caller-assigned identifier
+	PositionSide  PositionSide // Optional, for venues running in hedge mode
+}
+
+// Order is a normalized order.
+type Order struct {
+	ID            string
+	ClientOrderID string
+	Symbol        string
+	Side          Side
+	Type          OrderType
+	Price         float64
+	Size          float64 // Always positive
+	FilledSize    float64
+	AvgPrice      float64
+	TimeInForce   TimeInForce
+	ReduceOnly    bool
+	Status        OrderStatus
+	CreateTime    time.Time
+}
+
+// Position is a normalized open position.
+type Position struct {
+	Symbol        string
+	Side          PositionSide
+	Size          float64 // Always positive, in contracts
+	EntryPrice    float64
+	MarkPrice     float64
+	LiqPrice      float64
+	UnrealizedPnL float64
+	Margin        float64
+	Leverage      float64 // 0 means cross margin on venues that encode it that way
+}
+
+// Balance is a normalized wallet balance.
+type Balance struct {
+	Currency      string
+	Total         float64
+	Available     float64
+	Frozen        float64 // Margin locked by positions and open orders
+	UnrealizedPnL float64
+}
@@ -2,4 +2,7 @@ module github.com/neqin/futures
 
 go 1.23.4
 
-require github.com/joho/godotenv v1.5.1 // indirect
+require (
+	github.com/gorilla/websocket v1.5.3
+	github.com/joho/godotenv v1.5.1
+)
@@ -0,0 +1,127 @@
+// Package httplog logs the REST requests of the connector clients with
+// log/slog.
+//
+// Every attempt is logged once its response has been read: at Debug level
+// when it succeeded, at Warn level when the transport failed, the HTTP status
+// is 400 or above, or the venue reported an error code. Records carry the
+// exchange, method, path, status, latency and exchange error code. Request
+// and response bodies are only logged when enabled with SetMaxBody; they are
+// truncated, and credentials, signatures, listen keys and account
+// identifiers are redacted from them and from the query string with package
+// redact. Credential headers are never logged.
+package httplog
+
+import (
+	"context"
+	"log/slog"
+	"strconv"
+	"time"
+
+	"github.com/neqin/futures/redact"
+)
+
+// Redacted replaces redacted values.
+const Redacted = redact.Redacted
+
+// Fields whose values are redacted from logged bodies and query strings.
+var sensitiveFields = redact.Fields()
+
+// Entry describes a completed request attempt.
+type Entry struct {
+	Method       string
+	Path         string // Endpoint path without the query
+	Query        string // Raw query string
+	RequestBody  []byte
+	Status       int // HTTP status, 0 if no response was received
+	Latency      time.Duration
+	ResponseBody []byte
+	ErrorCode    string // Exchange error code or label, empty if none
+	Err          error  // Transport error
+}
+
+// Failed reports whether e is logged at Warn level.
+func (e *Entry) Failed() bool {
+	return e.Err != nil || e.Status >= 400 || e.ErrorCode != ""
+}
+
+// Logger logs the requests of one client. The zero value is not usable;
+// create one with New.
+type Logger struct {
+	exchange string
+	logger   *slog.Logger // nil disables logging
+	maxBody  int
+}
+
+// New creates a Logger for exchange (e.g. "gateio") writing to
+// slog.Default(), without bodies.
+func New(exchange string) *Logger {
+	return &Logger{exchange: exchange, logger: slog.Default()}
+}
+
+// SetLogger replaces the destination. A nil logger disables logging.
+func (l *Logger) SetLogger(logger *slog.Logger) {
+	l.logger = logger
+}
+
+// SetMaxBody enables logging of request and response bodies, truncated to
+// maxBytes; a negative value logs them in full and 0 (the default) omits
+// them.
+func (l *Logger) SetMaxBody(maxBytes int) {
+	l.maxBody = maxBytes
+}
+
+// Info logs a client configuration change.
+func (l *Logger) Info(msg string, args ...any) {
+	if l.logger != nil {
+		l.logger.Info(msg, append([]any{"exchange", l.exchange}, args...)...)
+	}
+}
+
+// Enabled reports whether an attempt at level would be logged, so callers
+// can skip extracting an error code.
+func (l *Logger) Enabled(ctx context.Context, level slog.Level) bool {
+	return l.logger != nil && l.logger.Enabled(ctx, level)
+}
+
+// Request logs a completed attempt.
+func (l *Logger) Request(ctx context.Context, e Entry) {
+	level, msg := slog.LevelDebug, "request"
+	if e.Failed() {
+		level, msg = slog.LevelWarn, "request failed"
+	}
+	if !l.Enabled(ctx, level) {
+		return
+	}
+	attrs := []slog.Attr{
+		slog.String("exchange", l.exchange),
+		slog.String("method", e.Method),
+		slog.String("path", e.Path),
+		slog.Int("status", e.Status),
+		slog.Duration("latency", e.Latency),
+	}
+	if e.Query != "" {
+		attrs = append(attrs, slog.String("query", redact.Query(e.Query, sensitiveFields)))
+	}
+	if e.ErrorCode != "" {
+		attrs = append(attrs, slog.String("error_code", e.ErrorCode))
+	}
+	if e.Err != nil {
+		attrs = append(attrs, slog.String("error", e.Err.Error()))
+	}
+	if l.maxBody != 0 {
+		if len(e.RequestBody) > 0 {
+			attrs = append(attrs, slog.String("request_body", truncate(redact.Body(e.RequestBody, sensitiveFields), l.maxBody)))
+		}
+		if len(e.ResponseBody) > 0 {
+			attrs = append(attrs, slog.String("response_body", truncate(redact.Body(e.ResponseBody, sensitiveFields), l.maxBody)))
+		}
+	}
+	l.logger.LogAttrs(ctx, level, msg, attrs...)
+}
+
+func truncate(s string, max int) string {
+	if max < 0 || len(s) <= max {
+		return s
+	}
+	return s[:max] + "...(" + strconv.Itoa(len(s)) + " bytes)"
+}
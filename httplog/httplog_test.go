@@ -0,0 +1,88 @@
+package httplog_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"testing"
+
+	"github.com/neqin/futures/httplog"
+)
+
+// record logs e through a Logger with the given body limit and returns the
+// attributes of the single record written.
+func record(t *testing.T, maxBody int, e httplog.Entry) map[string]any {
+	t.Helper()
+	var buf bytes.Buffer
+	l := httplog.New("gateio")
+	l.SetLogger(slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug})))
+	l.SetMaxBody(maxBody)
+	l.Request(context.Background(), e)
+	var attrs map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &attrs); err != nil {
+		t.Fatalf("log output %q: %v", buf.String(), err)
+	}
+	return attrs
+}
+
+func TestRequestLevel(t *testing.T) {
+	tests := []struct {
+		name  string
+		entry httplog.Entry
+		level string
+	}{
+		{"success", httplog.Entry{Status: http.StatusOK}, "DEBUG"},
+		{"HTTP error", httplog.Entry{Status: http.StatusBadRequest}, "WARN"},
+		{"exchange error", httplog.Entry{Status: http.StatusOK, ErrorCode: "INVALID_PARAM"}, "WARN"},
+		{"transport error", httplog.Entry{Err: errors.New("connection reset")}, "WARN"},
+	}
+	for _, tt := range tests {
+		if got := record(t, 0, tt.entry)["level"]; got != tt.level {
+			t.Errorf("%s: level %v, want %s", tt.name, got, tt.level)
+		}
+	}
+}
+
+func TestRedaction(t *testing.T) {
+	attrs := record(t, -1, httplog.Entry{
+		Method:       http.MethodPost,
+		Path:         "/futures/usdt/orders",
+		Query:        "listenKey=lk-secret&symbol=btc_usdt",
+		RequestBody:  []byte("apiKey=key-secret&size=1"),
+		Status:       http.StatusOK,
+		ResponseBody: []byte(`{"id":1,"user":12345,"result":{"userId":"u-secret"}}`),
+	})
+	want := map[string]string{
+		"query":         "listenKey=REDACTED&symbol=btc_usdt",
+		"request_body":  "apiKey=REDACTED&size=1",
+		"response_body": `{"id":1,"result":{"userId":"REDACTED"},"user":0}`,
+	}
+	for k, v := range want {
+		if attrs[k] != v {
+			t.Errorf("%s = %v, want %s", k, attrs[k], v)
+		}
+	}
+}
+
+func TestBodies(t *testing.T) {
+	e := httplog.Entry{Status: http.StatusOK, RequestBody: []byte(`{"size":"1"}`), ResponseBody: []byte(`{"contract":"BTC_USDT"}`)}
+	tests := []struct {
+		maxBody  int
+		request  any
+		response any
+	}{
+		{0, nil, nil}, // Bodies omitted
+		{-1, `{"size":"1"}`, `{"contract":"BTC_USDT"}`},
+		{12, `{"size":"1"}`, `{"contract":...(23 bytes)`},
+		{100, `{"size":"1"}`, `{"contract":"BTC_USDT"}`},
+	}
+	for _, tt := range tests {
+		attrs := record(t, tt.maxBody, e)
+		if attrs["request_body"] != tt.request || attrs["response_body"] != tt.response {
+			t.Errorf("max body %d: logged %v and %v, want %v and %v", tt.maxBody, attrs["request_body"], attrs["response_body"], tt.request, tt.response)
+		}
+	}
+}
@@ -41,7 +41,7 @@ func main() {
 	if err != nil {
 		log.Printf("ERROR fetching contract stats for %s: %v\n", contractName, err)
 	} else if stats != nil && len(*stats) > 0 {
-		log.Printf("OK: Fetched %d stats entries for %s. First entry time: %d, MarkPrice: %f\n", len(*stats), contractName, (*stats)[0].Time, (*stats)[0].MarkPrice)
+		log.Printf("OK: Fetched %d stats entries for %s. First entry time: %d, MarkPrice: %s\n", len(*stats), contractName, (*stats)[0].Time, (*stats)[0].MarkPrice)
 	} else {
 		log.Printf("WARN: Fetched contract stats for %s, but list is empty or nil.\n", contractName)
 	}
@@ -83,7 +83,7 @@ func main() {
 		log.Printf("ERROR fetching candlesticks for %s: %v\n", contractName, err)
 	} else if candles != nil && len(*candles) > 0 {
 		// Access fields using dot notation
-		log.Printf("OK: Fetched %d candlesticks for %s. First candle timestamp: %s, Open: %s\n", len(*candles), contractName, (*candles)[0].Timestamp, (*candles)[0].Open)
+		log.Printf("OK: Fetched %d candlesticks for %s. First candle timestamp: %d, Open: %s\n", len(*candles), contractName, (*candles)[0].Timestamp, (*candles)[0].Open)
 	} else {
 		log.Printf("WARN: Fetched candlesticks for %s, but list is empty or nil.\n", contractName)
 	}
@@ -98,7 +98,7 @@ func main() {
 		log.Printf("ERROR fetching premium index for %s: %v\n", contractName, err)
 	} else if premiumIndex != nil && len(*premiumIndex) > 0 {
 		// Access fields using dot notation - using corrected fields
-		log.Printf("OK: Fetched %d premium index entries for %s. First entry timestamp: %d, MarkPrice: %f\n", len(*premiumIndex), contractName, (*premiumIndex)[0].Timestamp, (*premiumIndex)[0].MarkPrice)
+		log.Printf("OK: Fetched %d premium index entries for %s. First entry timestamp: %d, MarkPrice: %s\n", len(*premiumIndex), contractName, (*premiumIndex)[0].Timestamp, (*premiumIndex)[0].MarkPrice)
 	} else {
 		log.Printf("WARN: Fetched premium index for %s, but list is empty or nil.\n", contractName)
 	}
@@ -0,0 +1,295 @@
+// Package orderbook maintains a local L2 order book from a REST snapshot and
+// a stream of sequenced WebSocket diffs.
+//
+// A Book is seeded with a Snapshot and then updated with Diffs. Every Diff
+// carries the range of update IDs it covers; a diff that does not continue
+// from the book's current update ID is a gap, and Run discards the book and
+// re-seeds it from a fresh snapshot. Venue-specific Sources live in the
+// connectors (gateio.NewOrderBookSource, xt.NewOrderBookSource).
+//
+// All Book methods are safe for concurrent use.
+package orderbook
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/neqin/futures/exchange"
+)
+
+// ErrNotSynced is returned by queries on a book that has not been seeded yet
+// or is resyncing after a gap.
+var ErrNotSynced = errors.New("order book not synced")
+
+// GapError reports a diff that does not continue from the book's update ID.
+type GapError struct {
+	Symbol   string
+	UpdateID int64 // Book update ID when the diff arrived
+	FirstID  int64 // First update ID of the offending diff
+}
+
+// Error returns the error message string.
+func (e *GapError) Error() string {
+	return fmt.Sprintf("order book %s: update gap, have %d, next diff starts at %d", e.Symbol, e.UpdateID, e.FirstID)
+}
+
+// Snapshot is a full order book state as of UpdateID.
+type Snapshot struct {
+	UpdateID int64
+	Bids     []exchange.OrderBookLevel
+	Asks     []exchange.OrderBookLevel
+}
+
+// Diff is a set of level changes covering update IDs FirstID through LastID.
+// A level with Size 0 removes that price.
+type Diff struct {
+	FirstID int64
+	LastID  int64
+	Bids    []exchange.OrderBookLevel
+	Asks    []exchange.OrderBookLevel
+	Time    time.Time
+}
+
+// Book is a locally maintained L2 order book for a single symbol.
+type Book struct {
+	symbol string
+
+	mu       sync.RWMutex
+	bids     bookSide
+	asks     bookSide
+	updateID int64
+	synced   bool
+	time     time.Time
+
+	errs chan error
+}
+
+// NewBook creates an empty, unsynced book for symbol.
+func NewBook(symbol string) *Book {
+	return &Book{
+		symbol: symbol,
+		bids:   newBookSide(true),
+		asks:   newBookSide(false),
+		errs:   make(chan error, 16),
+	}
+}
+
+// Symbol returns the symbol the book was created for.
+func (b *Book) Symbol() string {
+	return b.symbol
+}
+
+// ApplySnapshot replaces the book contents with s and marks it synced.
+func (b *Book) ApplySnapshot(s *Snapshot) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.bids.reset()
+	b.asks.reset()
+	for _, l := range s.Bids {
+		b.bids.set(l.Price, l.Size)
+	}
+	for _, l := range s.Asks {
+		b.asks.set(l.Price, l.Size)
+	}
+	b.updateID = s.UpdateID
+	b.synced = true
+	b.time = time.Now()
+}
+
+// ApplyDiff applies d to the book. Diffs entirely older than the book are
+// ignored. A diff that skips update IDs returns a *GapError and marks the
+// book unsynced until the next ApplySnapshot.
+func (b *Book) ApplyDiff(d *Diff) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if !b.synced {
+		return ErrNotSynced
+	}
+	if d.LastID <= b.updateID {
+		return nil // Already reflected in the snapshot
+	}
+	if d.FirstID > b.updateID+1 {
+		b.synced = false
+		return &GapError{Symbol: b.symbol, UpdateID: b.updateID, FirstID: d.FirstID}
+	}
+	for _, l := range d.Bids {
+		b.bids.set(l.Price, l.Size)
+	}
+	for _, l := range d.Asks {
+		b.asks.set(l.Price, l.Size)
+	}
+	b.updateID = d.LastID
+	if d.Time.IsZero() {
+		b.time = time.Now()
+	} else {
+		b.time = d.Time
+	}
+	return nil
+}
+
+// Synced reports whether the book reflects a continuous update sequence.
+func (b *Book) Synced() bool {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return b.synced
+}
+
+// UpdateID returns the ID of the last applied update.
+func (b *Book) UpdateID() int64 {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return b.updateID
+}
+
+// BestBid returns the highest bid.
+func (b *Book) BestBid() (exchange.OrderBookLevel, error) {
+	return b.best(&b.bids)
+}
+
+// BestAsk returns the lowest ask.
+func (b *Book) BestAsk() (exchange.OrderBookLevel, error) {
+	return b.best(&b.asks)
+}
+
+func (b *Book) best(side *bookSide) (exchange.OrderBookLevel, error) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	if !b.synced {
+		return exchange.OrderBookLevel{}, ErrNotSynced
+	}
+	if len(side.prices) == 0 {
+		return exchange.OrderBookLevel{}, fmt.Errorf("order book %s: side is empty", b.symbol)
+	}
+	p := side.prices[0]
+	return exchange.OrderBookLevel{Price: p, Size: side.sizes[p]}, nil
+}
+
+// SizeAt returns the resting size at price on the given side (Buy for bids,
+// Sell for asks), or 0 if there is no level at that price.
+func (b *Book) SizeAt(side exchange.Side, price float64) (float64, error) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	if !b.synced {
+		return 0, ErrNotSynced
+	}
+	return b.side(side).sizes[price], nil
+}
+
+// CumulativeSize returns the total size resting on the given side at prices
+// equal to or better than price: bids at or above price, asks at or below.
+func (b *Book) CumulativeSize(side exchange.Side, price float64) (float64, error) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	if !b.synced {
+		return 0, ErrNotSynced
+	}
+	s := b.side(side)
+	var total float64
+	for _, p := range s.prices {
+		if s.worse(p, price) {
+			break
+		}
+		total += s.sizes[p]
+	}
+	return total, nil
+}
+
+// OrderBook returns a copy of the top depth levels per side (all levels if depth <= 0).
+func (b *Book) OrderBook(depth int) (*exchange.OrderBook, error) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	if !b.synced {
+		return nil, ErrNotSynced
+	}
+	return &exchange.OrderBook{
+		Symbol:   b.symbol,
+		Bids:     b.bids.levels(depth),
+		Asks:     b.asks.levels(depth),
+		UpdateID: b.updateID,
+		Time:     b.time,
+	}, nil
+}
+
+// Errors returns a channel of asynchronous errors raised by Run (gaps,
+// snapshot failures, stream interruptions). Errors are dropped if the channel
+// is not drained.
+func (b *Book) Errors() <-chan error {
+	return b.errs
+}
+
+func (b *Book) side(side exchange.Side) *bookSide {
+	if side == exchange.Sell {
+		return &b.asks
+	}
+	return &b.bids
+}
+
+func (b *Book) markUnsynced() {
+	b.mu.Lock()
+	b.synced = false
+	b.mu.Unlock()
+}
+
+func (b *Book) reportError(err error) {
+	select {
+	case b.errs <- err:
+	default:
+	}
+}
+
+// bookSide holds the levels of one side, with prices kept sorted best first.
+type bookSide struct {
+	desc   bool // Bids sort descending, asks ascending
+	sizes  map[float64]float64
+	prices []float64
+}
+
+func newBookSide(desc bool) bookSide {
+	return bookSide{desc: desc, sizes: make(map[float64]float64)}
+}
+
+func (s *bookSide) reset() {
+	clear(s.sizes)
+	s.prices = s.prices[:0]
+}
+
+// worse reports whether price p is strictly worse than ref on this side.
+func (s *bookSide) worse(p, ref float64) bool {
+	if s.desc {
+		return p < ref
+	}
+	return p > ref
+}
+
+func (s *bookSide) set(price, size float64) {
+	_, exists := s.sizes[price]
+	i := sort.Search(len(s.prices), func(i int) bool { return !s.worse(price, s.prices[i]) })
+	if size <= 0 {
+		if exists {
+			delete(s.sizes, price)
+			s.prices = append(s.prices[:i], s.prices[i+1:]...)
+		}
+		return
+	}
+	s.sizes[price] = size
+	if !exists {
+		s.prices = append(s.prices, 0)
+		copy(s.prices[i+1:], s.prices[i:])
+		s.prices[i] = price
+	}
+}
+
+func (s *bookSide) levels(depth int) []exchange.OrderBookLevel {
+	n := len(s.prices)
+	if depth > 0 && depth < n {
+		n = depth
+	}
+	levels := make([]exchange.OrderBookLevel, n)
+	for i, p := range s.prices[:n] {
+		levels[i] = exchange.OrderBookLevel{Price: p, Size: s.sizes[p]}
+	}
+	return levels
+}
@@ -0,0 +1,244 @@
+package orderbook
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/neqin/futures/exchange"
+)
+
+type levels = []exchange.OrderBookLevel
+
+func seeded(t *testing.T) *Book {
+	t.Helper()
+	b := NewBook("BTC_USDT")
+	b.ApplySnapshot(&Snapshot{
+		UpdateID: 10,
+		Bids:     levels{{Price: 99, Size: 1}, {Price: 100, Size: 2}, {Price: 98, Size: 3}},
+		Asks:     levels{{Price: 102, Size: 4}, {Price: 101, Size: 5}},
+	})
+	return b
+}
+
+func checkSide(t *testing.T, name string, got, want levels) {
+	t.Helper()
+	if !slices.Equal(got, want) {
+		t.Errorf("%s = %v, want %v", name, got, want)
+	}
+}
+
+func TestSnapshotSeeding(t *testing.T) {
+	b := NewBook("BTC_USDT")
+	if _, err := b.BestBid(); !errors.Is(err, ErrNotSynced) {
+		t.Errorf("BestBid before the snapshot: %v, want ErrNotSynced", err)
+	}
+	if err := b.ApplyDiff(&Diff{FirstID: 1, LastID: 1}); !errors.Is(err, ErrNotSynced) {
+		t.Errorf("ApplyDiff before the snapshot: %v, want ErrNotSynced", err)
+	}
+
+	b = seeded(t)
+	ob, err := b.OrderBook(0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkSide(t, "bids", ob.Bids, levels{{Price: 100, Size: 2}, {Price: 99, Size: 1}, {Price: 98, Size: 3}})
+	checkSide(t, "asks", ob.Asks, levels{{Price: 101, Size: 5}, {Price: 102, Size: 4}})
+	if !b.Synced() || b.UpdateID() != 10 || ob.UpdateID != 10 {
+		t.Errorf("synced %t, update ID %d", b.Synced(), b.UpdateID())
+	}
+
+	// A new snapshot replaces the book rather than merging into it.
+	b.ApplySnapshot(&Snapshot{UpdateID: 20, Bids: levels{{Price: 90, Size: 1}}})
+	ob, _ = b.OrderBook(0)
+	checkSide(t, "bids after reseeding", ob.Bids, levels{{Price: 90, Size: 1}})
+	checkSide(t, "asks after reseeding", ob.Asks, levels{})
+}
+
+func TestDiffs(t *testing.T) {
+	b := seeded(t)
+	diffs := []Diff{
+		{FirstID: 5, LastID: 10, Bids: levels{{Price: 100, Size: 99}}}, // Already in the snapshot
+		{FirstID: 8, LastID: 11, Bids: levels{{Price: 100, Size: 7}}},  // Overlaps the snapshot
+		{FirstID: 12, LastID: 12, Asks: levels{{Price: 100.5, Size: 1}, {Price: 102, Size: 0}}},
+		{FirstID: 13, LastID: 14, Bids: levels{{Price: 98, Size: 0}, {Price: 97, Size: 0}}}, // 97 does not exist
+	}
+	for i := range diffs {
+		if err := b.ApplyDiff(&diffs[i]); err != nil {
+			t.Fatalf("diff %d-%d: %v", diffs[i].FirstID, diffs[i].LastID, err)
+		}
+	}
+	ob, _ := b.OrderBook(0)
+	checkSide(t, "bids", ob.Bids, levels{{Price: 100, Size: 7}, {Price: 99, Size: 1}})
+	checkSide(t, "asks", ob.Asks, levels{{Price: 100.5, Size: 1}, {Price: 101, Size: 5}})
+	if b.UpdateID() != 14 {
+		t.Errorf("update ID = %d, want 14", b.UpdateID())
+	}
+	if size, _ := b.SizeAt(exchange.Sell, 102); size != 0 {
+		t.Errorf("size at the deleted level = %v, want 0", size)
+	}
+}
+
+func TestGap(t *testing.T) {
+	b := seeded(t)
+	err := b.ApplyDiff(&Diff{FirstID: 12, LastID: 13})
+	var gap *GapError
+	if !errors.As(err, &gap) || gap.UpdateID != 10 || gap.FirstID != 12 {
+		t.Fatalf("diff after a gap: %v, want a GapError from 10 to 12", err)
+	}
+	if b.Synced() {
+		t.Error("book still synced after a gap")
+	}
+	if _, err := b.BestAsk(); !errors.Is(err, ErrNotSynced) {
+		t.Errorf("BestAsk after a gap: %v, want ErrNotSynced", err)
+	}
+}
+
+func TestQueries(t *testing.T) {
+	b := seeded(t)
+	if l, err := b.BestBid(); err != nil || l != (exchange.OrderBookLevel{Price: 100, Size: 2}) {
+		t.Errorf("BestBid = %v, %v", l, err)
+	}
+	if l, err := b.BestAsk(); err != nil || l != (exchange.OrderBookLevel{Price: 101, Size: 5}) {
+		t.Errorf("BestAsk = %v, %v", l, err)
+	}
+	tests := []struct {
+		side  exchange.Side
+		price float64
+		at    float64
+		cum   float64
+	}{
+		{exchange.Buy, 100, 2, 2},
+		{exchange.Buy, 99, 1, 3},
+		{exchange.Buy, 98.5, 0, 3},
+		{exchange.Buy, 50, 0, 6},
+		{exchange.Buy, 101, 0, 0},
+		{exchange.Sell, 101, 5, 5},
+		{exchange.Sell, 102, 4, 9},
+		{exchange.Sell, 100, 0, 0},
+	}
+	for _, tt := range tests {
+		if got, _ := b.SizeAt(tt.side, tt.price); got != tt.at {
+			t.Errorf("SizeAt(%s, %v) = %v, want %v", tt.side, tt.price, got, tt.at)
+		}
+		if got, _ := b.CumulativeSize(tt.side, tt.price); got != tt.cum {
+			t.Errorf("CumulativeSize(%s, %v) = %v, want %v", tt.side, tt.price, got, tt.cum)
+		}
+	}
+	ob, _ := b.OrderBook(2)
+	checkSide(t, "top 2 bids", ob.Bids, levels{{Price: 100, Size: 2}, {Price: 99, Size: 1}})
+	checkSide(t, "top 2 asks", ob.Asks, levels{{Price: 101, Size: 5}, {Price: 102, Size: 4}})
+
+	b.ApplySnapshot(&Snapshot{UpdateID: 11, Bids: levels{{Price: 100, Size: 1}}})
+	if _, err := b.BestAsk(); err == nil || errors.Is(err, ErrNotSynced) {
+		t.Errorf("BestAsk of an empty side: %v, want an empty side error", err)
+	}
+}
+
+func TestBookSideSet(t *testing.T) {
+	tests := []struct {
+		desc bool
+		ops  [][2]float64 // Price, size
+		want []float64
+	}{
+		{true, [][2]float64{{100, 1}, {102, 1}, {101, 1}, {99, 1}}, []float64{102, 101, 100, 99}},
+		{false, [][2]float64{{100, 1}, {102, 1}, {101, 1}, {99, 1}}, []float64{99, 100, 101, 102}},
+		{true, [][2]float64{{100, 1}, {100, 2}, {101, 1}}, []float64{101, 100}},                 // Update in place
+		{false, [][2]float64{{100, 1}, {101, 1}, {100, 0}, {105, 0}}, []float64{101}},           // Delete, and delete a missing level
+		{false, [][2]float64{{100, 1}, {101, 1}, {101, -1}, {100.5, 1}}, []float64{100, 100.5}}, // Negative size deletes
+	}
+	for _, tt := range tests {
+		s := newBookSide(tt.desc)
+		for _, op := range tt.ops {
+			s.set(op[0], op[1])
+		}
+		if !slices.Equal(s.prices, tt.want) || len(s.sizes) != len(tt.want) {
+			t.Errorf("desc %t, ops %v: prices %v (%d sizes), want %v", tt.desc, tt.ops, s.prices, len(s.sizes), tt.want)
+		}
+	}
+}
+
+// fakeSource serves queued snapshots and forwards diffs fed by the test until
+// the subscription context is done.
+type fakeSource struct {
+	mu        sync.Mutex
+	snapshots []*Snapshot
+	diffs     chan Diff
+}
+
+func (s *fakeSource) Snapshot(ctx context.Context) (*Snapshot, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if len(s.snapshots) == 0 {
+		return nil, errors.New("no snapshot queued")
+	}
+	snapshot := s.snapshots[0]
+	s.snapshots = s.snapshots[1:]
+	return snapshot, nil
+}
+
+func (s *fakeSource) Diffs(ctx context.Context) (<-chan Diff, error) {
+	out := make(chan Diff)
+	go func() {
+		defer close(out)
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case d := <-s.diffs:
+				select {
+				case out <- d:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+	return out, nil
+}
+
+func waitFor(t *testing.T, b *Book, updateID int64) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for b.UpdateID() != updateID || !b.Synced() {
+		if time.Now().After(deadline) {
+			t.Fatalf("book at update %d (synced %t), want %d", b.UpdateID(), b.Synced(), updateID)
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestRunResyncsAfterGap(t *testing.T) {
+	src := &fakeSource{
+		snapshots: []*Snapshot{
+			{UpdateID: 10, Bids: levels{{Price: 100, Size: 1}}},
+			{UpdateID: 14, Bids: levels{{Price: 100, Size: 3}}, Asks: levels{{Price: 101, Size: 1}}},
+		},
+		diffs: make(chan Diff),
+	}
+	b := NewBook("BTC_USDT")
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- b.Run(ctx, src) }()
+
+	src.diffs <- Diff{FirstID: 11, LastID: 11, Bids: levels{{Price: 99, Size: 1}}}
+	waitFor(t, b, 11)
+	src.diffs <- Diff{FirstID: 14, LastID: 15, Asks: levels{{Price: 101, Size: 2}}} // 12-13 missing
+	waitFor(t, b, 15)
+
+	ob, _ := b.OrderBook(0)
+	checkSide(t, "bids", ob.Bids, levels{{Price: 100, Size: 3}}) // Level 99 came from before the gap
+	checkSide(t, "asks", ob.Asks, levels{{Price: 101, Size: 2}}) // The diff revealing the gap was applied
+	var gap *GapError
+	if err := <-b.Errors(); !errors.As(err, &gap) {
+		t.Errorf("reported %v, want a GapError", err)
+	}
+
+	cancel()
+	if err := <-done; !errors.Is(err, context.Canceled) {
+		t.Errorf("Run = %v, want context.Canceled", err)
+	}
+}
@@ -0,0 +1,89 @@
+package orderbook
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+const maxResyncDelay = 30 * time.Second
+
+// Source provides the snapshot and diff stream for one symbol on one venue.
+type Source interface {
+	// Snapshot fetches the current order book together with its update ID.
+	Snapshot(ctx context.Context) (*Snapshot, error)
+	// Diffs subscribes to incremental updates. The channel is closed when ctx
+	// is done or the underlying stream ends.
+	Diffs(ctx context.Context) (<-chan Diff, error)
+}
+
+// Run keeps the book in sync with src until ctx is done, and then returns
+// ctx.Err(). Diffs are subscribed before the snapshot is fetched so that no
+// update is missed; diffs older than the snapshot are discarded. On a gap the
+// book is marked unsynced and re-seeded from a new snapshot, and if the diff
+// stream ends it is re-subscribed. Intermediate errors are reported on Errors.
+func (b *Book) Run(ctx context.Context, src Source) error {
+	delay := time.Second
+	for {
+		start := time.Now()
+		err := b.runOnce(ctx, src)
+		b.markUnsynced()
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if err != nil {
+			b.reportError(err)
+		}
+		if time.Since(start) > maxResyncDelay {
+			delay = time.Second // The stream was healthy for a while, retry promptly
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(delay):
+		}
+		delay = min(delay*2, maxResyncDelay)
+	}
+}
+
+// runOnce subscribes to diffs and applies them until the stream ends.
+func (b *Book) runOnce(ctx context.Context, src Source) error {
+	streamCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	diffs, err := src.Diffs(streamCtx)
+	if err != nil {
+		return fmt.Errorf("order book %s: failed to subscribe to diffs: %w", b.symbol, err)
+	}
+	if err := b.resync(streamCtx, src); err != nil {
+		return err
+	}
+
+	for d := range diffs {
+		err := b.ApplyDiff(&d)
+		var gap *GapError
+		if errors.As(err, &gap) {
+			b.reportError(err)
+			if err := b.resync(streamCtx, src); err != nil {
+				return err
+			}
+			// The diff that revealed the gap may be newer than the snapshot.
+			err = b.ApplyDiff(&d)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return fmt.Errorf("order book %s: diff stream ended", b.symbol)
+}
+
+func (b *Book) resync(ctx context.Context, src Source) error {
+	snapshot, err := src.Snapshot(ctx)
+	if err != nil {
+		return fmt.Errorf("order book %s: failed to fetch snapshot: %w", b.symbol, err)
+	}
+	b.ApplySnapshot(snapshot)
+	return nil
+}
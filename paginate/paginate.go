@@ -0,0 +1,52 @@
+// Package paginate walks paginated history endpoints for the connectors'
+// iter.Seq2 iterators.
+//
+// Each connector supplies a function fetching the next page from its cursor
+// (last ID, offset or time window); Walk yields the items, skips those
+// repeated at page boundaries and stops at the last page, an error or when
+// the context is done.
+package paginate
+
+import "context"
+
+// Walk yields the items of the pages returned by next until it reports the
+// last page, an error occurs or ctx is done. Items already yielded from the
+// previous page (same key) are skipped, since cursors based on IDs, time or
+// offsets overlap at page boundaries. A page without new items ends the walk.
+// An error returned together with items is yielded after them and the walk
+// goes on if the consumer continues; an error without items ends the walk.
+func Walk[T any, K comparable](ctx context.Context, yield func(T, error) bool, key func(T) K, next func() (page []T, last bool, err error)) {
+	var prev map[K]struct{}
+	var zero T
+	for {
+		if err := ctx.Err(); err != nil {
+			yield(zero, err)
+			return
+		}
+		page, last, err := next()
+		if err != nil && len(page) == 0 {
+			yield(zero, err)
+			return
+		}
+		seen := make(map[K]struct{}, len(page))
+		fresh := 0
+		for _, item := range page {
+			k := key(item)
+			seen[k] = struct{}{}
+			if _, dup := prev[k]; dup {
+				continue
+			}
+			fresh++
+			if !yield(item, nil) {
+				return
+			}
+		}
+		if err != nil && !yield(zero, err) {
+			return
+		}
+		if last || fresh == 0 {
+			return
+		}
+		prev = seen
+	}
+}
@@ -0,0 +1,94 @@
+package paginate_test
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/neqin/futures/paginate"
+)
+
+type page struct {
+	items []int
+	last  bool
+	err   error
+}
+
+// walk runs Walk over pages and collects what it yields, stopping after stop
+// items if stop is positive. It returns the items, the errors and the number
+// of pages fetched.
+func walk(ctx context.Context, pages []page, stop int) ([]int, []error, int) {
+	var items []int
+	var errs []error
+	fetched := 0
+	yield := func(item int, err error) bool {
+		if err != nil {
+			errs = append(errs, err)
+		} else {
+			items = append(items, item)
+		}
+		return stop <= 0 || len(items) < stop
+	}
+	next := func() ([]int, bool, error) {
+		if fetched == len(pages) {
+			panic("page requested after the last one")
+		}
+		p := pages[fetched]
+		fetched++
+		return p.items, p.last, p.err
+	}
+	paginate.Walk(ctx, yield, func(i int) int { return i }, next)
+	return items, errs, fetched
+}
+
+func TestWalk(t *testing.T) {
+	failed := errors.New("page failed")
+	tests := []struct {
+		name    string
+		pages   []page
+		stop    int
+		items   []int
+		errs    int
+		fetched int
+	}{
+		{"single page", []page{{items: []int{3, 2, 1}, last: true}}, 0, []int{3, 2, 1}, 0, 1},
+		{"boundary duplicates", []page{{items: []int{9, 8, 7}}, {items: []int{7, 6, 5}}, {items: []int{5, 4}, last: true}}, 0, []int{9, 8, 7, 6, 5, 4}, 0, 3},
+		{"stops at the last page", []page{{items: []int{9, 8}}, {items: []int{7, 6}, last: true}, {items: []int{5}}}, 0, []int{9, 8, 7, 6}, 0, 2},
+		{"page without new items", []page{{items: []int{9, 8}}, {items: []int{8, 9}}, {items: []int{7}}}, 0, []int{9, 8}, 0, 2},
+		{"empty page", []page{{items: []int{9, 8}}, {}, {items: []int{7}}}, 0, []int{9, 8}, 0, 2},
+		{"consumer stops", []page{{items: []int{9, 8}}, {items: []int{7, 6}}, {items: []int{5}}}, 3, []int{9, 8, 7}, 0, 2},
+		{"error without items", []page{{items: []int{9, 8}}, {err: failed}, {items: []int{7}}}, 0, []int{9, 8}, 1, 2},
+		{"error after items", []page{{items: []int{9, 8}, err: failed}, {items: []int{7}, last: true}}, 0, []int{9, 8, 7}, 1, 2},
+	}
+	for _, tt := range tests {
+		items, errs, fetched := walk(context.Background(), tt.pages, tt.stop)
+		if !slices.Equal(items, tt.items) || len(errs) != tt.errs || fetched != tt.fetched {
+			t.Errorf("%s: got %v, %d errors, %d pages; want %v, %d errors, %d pages", tt.name, items, len(errs), fetched, tt.items, tt.errs, tt.fetched)
+		}
+	}
+}
+
+func TestWalkCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	var items []int
+	var errs []error
+	fetched := 0
+	paginate.Walk(ctx, func(item int, err error) bool {
+		if err != nil {
+			errs = append(errs, err)
+			return true
+		}
+		items = append(items, item)
+		return true
+	}, func(i int) int { return i }, func() ([]int, bool, error) {
+		fetched++
+		if fetched == 2 {
+			cancel() // Cancelled while the second page is fetched
+		}
+		return []int{fetched * 10, fetched*10 + 1}, false, nil
+	})
+	if fetched != 2 || len(items) != 4 || len(errs) != 1 || !errors.Is(errs[0], context.Canceled) {
+		t.Errorf("got %v, errors %v after %d pages; want the first two pages and context.Canceled", items, errs, fetched)
+	}
+}
@@ -0,0 +1,81 @@
+package paper
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/neqin/futures/exchange"
+	"github.com/neqin/futures/orderbook"
+	"github.com/neqin/futures/symbols"
+)
+
+// PolledBooks returns a BookSource that fetches a REST snapshot of up to depth
+// levels per side from md on every call, e.g. from gateio.NewExchange or
+// xt.NewExchange.
+func PolledBooks(md exchange.MarketData, depth int) BookSource {
+	return polledBooks{md: md, depth: depth}
+}
+
+type polledBooks struct {
+	md    exchange.MarketData
+	depth int
+}
+
+func (p polledBooks) OrderBook(ctx context.Context, symbol string) (*exchange.OrderBook, error) {
+	return p.md.OrderBook(ctx, symbol, p.depth)
+}
+
+// StreamedBooks returns a BookSource that reads up to depth levels per side
+// from local books kept in sync by orderbook.Book.Run. Books are matched to
+// symbols by their canonical name, so "btc_usdt" serves "BTC_USDT".
+func StreamedBooks(depth int, books ...*orderbook.Book) BookSource {
+	s := streamedBooks{depth: depth, books: make(map[string]*orderbook.Book, len(books))}
+	for _, b := range books {
+		s.books[symbols.Canonical(b.Symbol())] = b
+	}
+	return s
+}
+
+type streamedBooks struct {
+	depth int
+	books map[string]*orderbook.Book
+}
+
+func (s streamedBooks) OrderBook(ctx context.Context, symbol string) (*exchange.OrderBook, error) {
+	b, ok := s.books[symbol]
+	if !ok {
+		return nil, fmt.Errorf("paper: no order book for %s", symbol)
+	}
+	return b.OrderBook(s.depth)
+}
+
+// StaticBooks is a BookSource of books set by hand, e.g. to replay recorded
+// books or to drive an Engine in tests. It is safe for concurrent use.
+type StaticBooks struct {
+	mu    sync.RWMutex
+	books map[string]*exchange.OrderBook
+}
+
+// NewStaticBooks creates an empty StaticBooks.
+func NewStaticBooks() *StaticBooks {
+	return &StaticBooks{books: make(map[string]*exchange.OrderBook)}
+}
+
+// Set replaces the book of book.Symbol, matched by its canonical name.
+func (s *StaticBooks) Set(book *exchange.OrderBook) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.books[symbols.Canonical(book.Symbol)] = book
+}
+
+// OrderBook implements BookSource.
+func (s *StaticBooks) OrderBook(ctx context.Context, symbol string) (*exchange.OrderBook, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	b, ok := s.books[symbol]
+	if !ok {
+		return nil, fmt.Errorf("paper: no order book for %s", symbol)
+	}
+	return b, nil
+}
@@ -0,0 +1,1072 @@
+package paper
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/neqin/futures/decimal"
+	"github.com/neqin/futures/exchange"
+	"github.com/neqin/futures/symbols"
+)
+
+// divPlaces is the precision of averaged prices and prorated margin.
+const divPlaces = 12
+
+// Engine is a simulated futures account that matches its orders against
+// external order books. An Engine is safe for concurrent use.
+type Engine struct {
+	cfg   Config
+	books BookSource
+
+	mu        sync.Mutex
+	contracts map[string]symbols.Contract // Canonical symbol -> contract
+	balance   decimal.Decimal
+	realized  decimal.Decimal
+	fees      decimal.Decimal
+	orders    map[int64]*Order
+	triggers  map[int64]*Trigger
+	positions map[legKey]*Position
+	leverage  map[legKey]int // Leverage set before a leg was opened
+	marks     map[string]decimal.Decimal
+	used      map[bookLevel]decimal.Decimal // Size own fills took from the books' levels
+	fills     []Fill
+	lastID    int64
+
+	errs chan error
+}
+
+// legKey identifies a position leg.
+type legKey struct {
+	symbol string
+	side   exchange.PositionSide
+}
+
+// bookLevel identifies a price level of an external book.
+type bookLevel struct {
+	symbol string
+	bid    bool
+	price  string
+}
+
+// level is the part of a book level an order can still fill against.
+type level struct {
+	price decimal.Decimal
+	size  decimal.Decimal
+}
+
+// NewEngine creates an account with cfg.Balance that fills orders against the
+// books of books. Contracts must be registered with SetContracts or
+// LoadContracts before they can be traded.
+func NewEngine(cfg Config, books BookSource) *Engine {
+	if cfg.Leverage <= 0 {
+		cfg.Leverage = DefaultLeverage
+	}
+	return &Engine{
+		cfg:       cfg,
+		books:     books,
+		contracts: make(map[string]symbols.Contract),
+		balance:   cfg.Balance,
+		orders:    make(map[int64]*Order),
+		triggers:  make(map[int64]*Trigger),
+		positions: make(map[legKey]*Position),
+		leverage:  make(map[legKey]int),
+		marks:     make(map[string]decimal.Decimal),
+		used:      make(map[bookLevel]decimal.Decimal),
+		errs:      make(chan error, 16),
+	}
+}
+
+// Config returns the configuration of the account.
+func (e *Engine) Config() Config {
+	return e.cfg
+}
+
+// SetContracts registers the contracts that can be traded, replacing earlier
+// entries with the same canonical symbol. Sizes and notional values follow the
+// contracts' Multiplier.
+func (e *Engine) SetContracts(contracts ...symbols.Contract) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	for _, c := range contracts {
+		e.contracts[c.Symbol] = c
+	}
+}
+
+// LoadContracts registers the contracts listed by l, e.g.
+// gateio.NewSymbolLoader(client, "usdt"). Contracts loaded before an error are
+// registered too.
+func (e *Engine) LoadContracts(ctx context.Context, l symbols.Loader) error {
+	contracts, err := l.LoadContracts(ctx)
+	e.SetContracts(contracts...)
+	if err != nil {
+		return fmt.Errorf("paper: load %s contracts: %w", l.Venue(), err)
+	}
+	return nil
+}
+
+// Contract returns the registered contract of a canonical symbol.
+func (e *Engine) Contract(symbol string) (symbols.Contract, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.contract(symbol)
+}
+
+// --- Orders ---
+
+// PlaceOrder submits an order. After the configured latency it is matched
+// against the current book of its symbol: the marketable part fills at the
+// book's prices as taker, the rest of a GTC or post-only limit order rests
+// until Match fills or CancelOrder cancels it, and the rest of a market, IOC
+// or FOK order is cancelled.
+//
+// Reduce-only orders, and in hedge mode all orders that close a leg (sells on
+// the long leg, buys on the short leg), only fill up to the position size.
+// Orders whose opening part needs more margin than is available are rejected
+// with an error matching exchange.ErrInsufficientBalance; invalid requests
+// with one matching exchange.ErrInvalidParameter.
+func (e *Engine) PlaceOrder(ctx context.Context, req OrderRequest) (*Order, error) {
+	if err := e.checkRequest(&req); err != nil {
+		return nil, err
+	}
+	if err := e.wait(ctx); err != nil {
+		return nil, err
+	}
+	book, err := e.book(ctx, req.Symbol)
+	if err != nil {
+		return nil, err
+	}
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.observe(req.Symbol, book)
+	o, err := e.submit(req, book, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	return o.copy(), nil
+}
+
+// AmendOrder changes the size (including the filled part) and/or the price of
+// an open limit order; nil leaves a value unchanged. An amended order that
+// becomes marketable is matched against the current book as taker.
+func (e *Engine) AmendOrder(ctx context.Context, id int64, size, price *decimal.Decimal) (*Order, error) {
+	e.mu.Lock()
+	o, err := e.openOrder(id)
+	e.mu.Unlock()
+	if err != nil {
+		return nil, err
+	}
+	if size != nil && size.Sign() <= 0 {
+		return nil, invalid("size %s must be positive", size)
+	}
+	if price != nil && (o.Type != exchange.Limit || price.Sign() <= 0) {
+		return nil, invalid("price can only be amended to a positive price of a limit order")
+	}
+	if err := e.wait(ctx); err != nil {
+		return nil, err
+	}
+	book, err := e.book(ctx, o.Symbol)
+	if err != nil {
+		return nil, err
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if o, err = e.openOrder(id); err != nil { // It may have filled in the meantime
+		return nil, err
+	}
+	e.observe(o.Symbol, book)
+	now := time.Now()
+	if size != nil {
+		if !size.GreaterThan(o.Filled) {
+			return nil, invalid("size %s must exceed the filled size %s", size, o.Filled)
+		}
+		o.Size = *size
+	}
+	if price != nil {
+		o.Price = *price
+	}
+	o.UpdateTime = now
+	if o.TimeInForce == exchange.PostOnly && e.crosses(o, book) {
+		e.finish(o, exchange.StatusCanceled, FinishPostOnly, now)
+		return o.copy(), nil
+	}
+	e.take(o, book, now)
+	e.settle(o, now)
+	return o.copy(), nil
+}
+
+// CancelOrder cancels an open order after the configured latency. It returns
+// an error matching exchange.ErrOrderNotFound if the order is unknown or no
+// longer open.
+func (e *Engine) CancelOrder(ctx context.Context, id int64) (*Order, error) {
+	if err := e.wait(ctx); err != nil {
+		return nil, err
+	}
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	o, err := e.openOrder(id)
+	if err != nil {
+		return nil, err
+	}
+	e.finish(o, exchange.StatusCanceled, FinishCancelled, time.Now())
+	return o.copy(), nil
+}
+
+// CancelOrders cancels the open orders of symbol ("" for all symbols) on side
+// ("" for both) and returns them.
+func (e *Engine) CancelOrders(ctx context.Context, symbol string, side exchange.Side) ([]Order, error) {
+	if err := e.wait(ctx); err != nil {
+		return nil, err
+	}
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	now := time.Now()
+	var result []Order
+	for _, o := range e.sortedOrders() {
+		if o.IsOpen() && (symbol == "" || o.Symbol == symbol) && (side == "" || o.Side == side) {
+			e.finish(o, exchange.StatusCanceled, FinishCancelled, now)
+			result = append(result, *o)
+		}
+	}
+	return result, nil
+}
+
+// Order returns an order by ID.
+func (e *Engine) Order(id int64) (*Order, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	o, ok := e.orders[id]
+	if !ok {
+		return nil, fmt.Errorf("paper: order %d: %w", id, exchange.ErrOrderNotFound)
+	}
+	return o.copy(), nil
+}
+
+// Orders returns the open (open true) or finished orders of symbol ("" for
+// all symbols), newest first.
+func (e *Engine) Orders(symbol string, open bool) []Order {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	var result []Order
+	orders := e.sortedOrders()
+	for i := len(orders) - 1; i >= 0; i-- {
+		o := orders[i]
+		if o.IsOpen() == open && (symbol == "" || o.Symbol == symbol) {
+			result = append(result, *o)
+		}
+	}
+	return result
+}
+
+// Fills returns the executions of symbol ("" for all symbols), oldest first.
+func (e *Engine) Fills(symbol string) []Fill {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	var result []Fill
+	for _, f := range e.fills {
+		if symbol == "" || f.Symbol == symbol {
+			result = append(result, f)
+		}
+	}
+	return result
+}
+
+// --- Positions and account ---
+
+// Position returns a position leg; side is empty in one-way mode. A flat leg
+// is returned with zero size and the leverage new positions would use.
+func (e *Engine) Position(symbol string, side exchange.PositionSide) (Position, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if _, err := e.contract(symbol); err != nil {
+		return Position{}, err
+	}
+	return *e.leg(symbol, side), nil
+}
+
+// Positions returns the legs with a non-zero size, ordered by symbol and side.
+func (e *Engine) Positions() []Position {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	var result []Position
+	for _, p := range e.positions {
+		if !p.Size.IsZero() {
+			result = append(result, *p)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Symbol != result[j].Symbol {
+			return result[i].Symbol < result[j].Symbol
+		}
+		return result[i].Side < result[j].Side
+	})
+	return result
+}
+
+// SetLeverage changes the leverage of a position leg. The margin of an open
+// position is recomputed at its entry price; raising it needs available
+// balance.
+func (e *Engine) SetLeverage(symbol string, side exchange.PositionSide, leverage int) (Position, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	c, err := e.contract(symbol)
+	if err != nil {
+		return Position{}, err
+	}
+	if leverage < 1 {
+		return Position{}, invalid("leverage %d must be at least 1", leverage)
+	}
+	p := e.leg(symbol, side)
+	if !p.Size.IsZero() {
+		margin := c.Notional(p.Size, p.EntryPrice).Div(decimal.NewFromInt(int64(leverage)), divPlaces)
+		if extra := margin.Sub(p.Margin); extra.GreaterThan(e.available()) {
+			return Position{}, insufficient(extra, e.available())
+		}
+		p.Margin = trim(margin)
+	}
+	p.Leverage = leverage
+	e.leverage[legKey{symbol, side}] = leverage
+	return *p, nil
+}
+
+// AddMargin moves change (negative to withdraw) between the available
+// balance and the isolated margin of an open position leg. Liquidations are
+// not simulated, so withdrawing margin only requires some to remain.
+func (e *Engine) AddMargin(symbol string, side exchange.PositionSide, change decimal.Decimal) (Position, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if _, err := e.contract(symbol); err != nil {
+		return Position{}, err
+	}
+	p := e.leg(symbol, side)
+	switch {
+	case p.Size.IsZero():
+		return Position{}, invalid("no %s position to change the margin of", symbol)
+	case change.GreaterThan(e.available()):
+		return Position{}, insufficient(change, e.available())
+	case p.Margin.Add(change).Sign() <= 0:
+		return Position{}, invalid("cannot withdraw %s of %s margin", change.Neg(), p.Margin)
+	}
+	p.Margin = p.Margin.Add(change)
+	return *p, nil
+}
+
+// Account returns the wallet summary.
+func (e *Engine) Account() Account {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	a := Account{
+		Currency:    e.cfg.Currency,
+		Balance:     e.balance,
+		OrderMargin: e.orderMargin(),
+		RealizedPnL: e.realized,
+		Fees:        e.fees,
+	}
+	for _, p := range e.positions {
+		a.PositionMargin = a.PositionMargin.Add(p.Margin)
+		a.UnrealizedPnL = a.UnrealizedPnL.Add(p.UnrealizedPnL)
+	}
+	a.Available = a.Balance.Sub(a.PositionMargin).Sub(a.OrderMargin)
+	return a
+}
+
+// --- Triggers ---
+
+// PlaceTrigger registers an order to be placed once the mid price of its
+// symbol crosses req.Price, which Match checks. If the order is rejected when
+// the trigger fires, the trigger fails with the reason.
+func (e *Engine) PlaceTrigger(ctx context.Context, req TriggerRequest) (*Trigger, error) {
+	if req.Rule != PriceAbove && req.Rule != PriceBelow {
+		return nil, invalid("unknown trigger rule %d", req.Rule)
+	}
+	if req.Price.Sign() <= 0 {
+		return nil, invalid("trigger price %s must be positive", req.Price)
+	}
+	if err := e.checkRequest(&req.Order); err != nil {
+		return nil, err
+	}
+	if err := e.wait(ctx); err != nil {
+		return nil, err
+	}
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	now := time.Now()
+	t := &Trigger{TriggerRequest: req, ID: e.nextID(), Status: TriggerOpen, CreateTime: now}
+	e.triggers[t.ID] = t
+	c := *t
+	return &c, nil
+}
+
+// CancelTrigger cancels an open trigger. It returns an error matching
+// exchange.ErrOrderNotFound if the trigger is unknown or no longer open.
+func (e *Engine) CancelTrigger(ctx context.Context, id int64) (*Trigger, error) {
+	if err := e.wait(ctx); err != nil {
+		return nil, err
+	}
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	t, ok := e.triggers[id]
+	if !ok || t.Status != TriggerOpen {
+		return nil, fmt.Errorf("paper: trigger %d: %w", id, exchange.ErrOrderNotFound)
+	}
+	t.Status, t.FinishTime = TriggerCancelled, time.Now()
+	c := *t
+	return &c, nil
+}
+
+// CancelTriggers cancels the open triggers of symbol ("" for all symbols)
+// and returns them.
+func (e *Engine) CancelTriggers(ctx context.Context, symbol string) ([]Trigger, error) {
+	if err := e.wait(ctx); err != nil {
+		return nil, err
+	}
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	now := time.Now()
+	var result []Trigger
+	for _, t := range e.sortedTriggers() {
+		if t.Status == TriggerOpen && (symbol == "" || t.Order.Symbol == symbol) {
+			t.Status, t.FinishTime = TriggerCancelled, now
+			result = append(result, *t)
+		}
+	}
+	return result, nil
+}
+
+// Trigger returns a trigger by ID.
+func (e *Engine) Trigger(id int64) (*Trigger, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	t, ok := e.triggers[id]
+	if !ok {
+		return nil, fmt.Errorf("paper: trigger %d: %w", id, exchange.ErrOrderNotFound)
+	}
+	c := *t
+	return &c, nil
+}
+
+// Triggers returns the open (open true) or finished triggers of symbol (""
+// for all symbols), newest first.
+func (e *Engine) Triggers(symbol string, open bool) []Trigger {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	var result []Trigger
+	triggers := e.sortedTriggers()
+	for i := len(triggers) - 1; i >= 0; i-- {
+		t := triggers[i]
+		if (t.Status == TriggerOpen) == open && (symbol == "" || t.Order.Symbol == symbol) {
+			result = append(result, *t)
+		}
+	}
+	return result
+}
+
+// --- Matching ---
+
+// Match fetches the book of every symbol with an open order, trigger or
+// position, updates mark prices, fires or expires triggers and fills resting
+// orders that the book has reached. It returns the joined errors of the books
+// that could not be fetched.
+func (e *Engine) Match(ctx context.Context) error {
+	e.mu.Lock()
+	active := make(map[string]bool)
+	for _, o := range e.orders {
+		if o.IsOpen() {
+			active[o.Symbol] = true
+		}
+	}
+	for _, t := range e.triggers {
+		if t.Status == TriggerOpen {
+			active[t.Order.Symbol] = true
+		}
+	}
+	for k, p := range e.positions {
+		if !p.Size.IsZero() {
+			active[k.symbol] = true
+		}
+	}
+	e.mu.Unlock()
+
+	names := make([]string, 0, len(active))
+	for symbol := range active {
+		names = append(names, symbol)
+	}
+	sort.Strings(names)
+	var errs []error
+	for _, symbol := range names {
+		book, err := e.book(ctx, symbol)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		e.mu.Lock()
+		e.match(symbol, book, time.Now())
+		e.mu.Unlock()
+	}
+	return errors.Join(errs...)
+}
+
+// Run calls Match immediately and then every interval until ctx is done, and
+// then returns ctx.Err(). Match errors are reported on Errors.
+func (e *Engine) Run(ctx context.Context, interval time.Duration) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		if err := e.Match(ctx); err != nil && ctx.Err() == nil {
+			select {
+			case e.errs <- err:
+			default:
+			}
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// Errors returns a channel of errors raised by Run. Errors are dropped if the
+// channel is not drained.
+func (e *Engine) Errors() <-chan error {
+	return e.errs
+}
+
+// match processes one book of symbol. Called with e.mu held.
+func (e *Engine) match(symbol string, book *exchange.OrderBook, now time.Time) {
+	e.observe(symbol, book)
+	for _, o := range e.sortedOrders() {
+		if !o.IsOpen() || o.Symbol != symbol || o.Type != exchange.Limit {
+			continue
+		}
+		levels := e.depth(o, book)
+		qty := minDecimal(o.Left(), total(levels))
+		if e.reducing(o) {
+			qty = minDecimal(qty, e.closable(o))
+		}
+		if qty.Sign() > 0 {
+			e.fill(o, qty, o.Price, true, now)
+			e.use(o, levels, qty)
+		}
+		e.settle(o, now)
+	}
+
+	// Triggers fire after resting orders were matched, so resting orders
+	// have priority on the liquidity of this book.
+	mark, ok := e.marks[symbol]
+	for _, t := range e.sortedTriggers() {
+		if t.Status != TriggerOpen || t.Order.Symbol != symbol {
+			continue
+		}
+		if t.Expiration > 0 && now.Sub(t.CreateTime) >= t.Expiration {
+			t.Status, t.FinishTime = TriggerExpired, now
+			continue
+		}
+		if !ok || (t.Rule == PriceAbove && mark.LessThan(t.Price)) || (t.Rule == PriceBelow && mark.GreaterThan(t.Price)) {
+			continue
+		}
+		t.FinishTime = now
+		o, err := e.submit(t.Order, book, now)
+		if err != nil {
+			t.Status, t.Reason = TriggerFailed, err.Error()
+			continue
+		}
+		t.Status, t.OrderID = TriggerFinished, o.ID
+	}
+}
+
+// submit creates and matches an order. Called with e.mu held.
+func (e *Engine) submit(req OrderRequest, book *exchange.OrderBook, now time.Time) (*Order, error) {
+	if req.Type == exchange.Market {
+		req.TimeInForce = exchange.IOC
+	} else if req.TimeInForce == "" {
+		req.TimeInForce = exchange.GTC
+	}
+	if req.Close {
+		p := e.leg(req.Symbol, req.PositionSide)
+		if p.Size.IsZero() {
+			return nil, invalid("no %s position to close", req.Symbol)
+		}
+		req.Size, req.ReduceOnly = p.Size.Abs(), true
+		req.Side = exchange.Sell
+		if p.Size.Sign() < 0 {
+			req.Side = exchange.Buy
+		}
+	}
+	o := &Order{OrderRequest: req, Status: exchange.StatusOpen, CreateTime: now, UpdateTime: now}
+
+	if !e.reducing(o) {
+		c := e.contracts[o.Symbol]
+		price := o.Price
+		if o.Type == exchange.Market {
+			price = e.refPrice(o, book)
+		}
+		opening := o.Size.Sub(minDecimal(o.Size, e.closable(o)))
+		notional := c.Notional(opening, price)
+		need := notional.Div(decimal.NewFromInt(int64(e.leg(o.Symbol, o.PositionSide).Leverage)), divPlaces)
+		if e.cfg.TakerFee.Sign() > 0 {
+			need = need.Add(notional.Mul(e.cfg.TakerFee))
+		}
+		if need.GreaterThan(e.available()) {
+			return nil, insufficient(need, e.available())
+		}
+	}
+
+	o.ID = e.nextID()
+	e.orders[o.ID] = o
+	switch {
+	case o.TimeInForce == exchange.PostOnly && e.crosses(o, book):
+		e.finish(o, exchange.StatusCanceled, FinishPostOnly, now)
+		return o, nil
+	case o.TimeInForce == exchange.FOK && e.liquidity(o, book).LessThan(o.Size):
+		e.finish(o, exchange.StatusCanceled, FinishFOK, now)
+		return o, nil
+	}
+	e.take(o, book, now)
+	e.settle(o, now)
+	return o, nil
+}
+
+// take fills the marketable part of o against book as taker.
+func (e *Engine) take(o *Order, book *exchange.OrderBook, now time.Time) {
+	levels := e.depth(o, book)
+	for i, l := range levels {
+		qty := minDecimal(o.Left(), l.size)
+		if e.reducing(o) {
+			qty = minDecimal(qty, e.closable(o))
+		}
+		if qty.Sign() <= 0 {
+			break
+		}
+		e.fill(o, qty, l.price, false, now)
+		e.use(o, levels[i:i+1], qty)
+	}
+}
+
+// settle finishes o if it can no longer fill: filled, the rest of an IOC
+// order, or a reduce-only order without a position left to reduce.
+func (e *Engine) settle(o *Order, now time.Time) {
+	switch {
+	case !o.IsOpen():
+	case o.Left().Sign() <= 0:
+		e.finish(o, exchange.StatusFilled, FinishFilled, now)
+	case o.TimeInForce == exchange.IOC || o.TimeInForce == exchange.FOK:
+		e.finish(o, exchange.StatusCanceled, FinishIOC, now)
+	case e.reducing(o) && e.closable(o).Sign() <= 0:
+		e.finish(o, exchange.StatusCanceled, FinishReduceOnly, now)
+	}
+}
+
+// fill executes qty of o at price and books it on the position and wallet.
+func (e *Engine) fill(o *Order, qty, price decimal.Decimal, maker bool, now time.Time) {
+	c := e.contracts[o.Symbol]
+	rate := e.cfg.TakerFee
+	if maker {
+		rate = e.cfg.MakerFee
+	}
+	fee := c.Notional(qty, price).Mul(rate)
+	signed := qty
+	if o.Side == exchange.Sell {
+		signed = qty.Neg()
+	}
+
+	p := e.leg(o.Symbol, o.PositionSide)
+	pnl := e.trade(p, c, signed, price)
+	p.RealizedPnL = p.RealizedPnL.Add(pnl).Sub(fee)
+	e.balance = e.balance.Add(pnl).Sub(fee)
+	e.realized = e.realized.Add(pnl)
+	e.fees = e.fees.Add(fee)
+	e.revalue(p, c)
+
+	filled := o.Filled.Add(qty)
+	o.AvgPrice = trim(o.AvgPrice.Mul(o.Filled).Add(price.Mul(qty)).Div(filled, divPlaces))
+	o.Filled = filled
+	o.Fee = o.Fee.Add(fee)
+	o.Status = exchange.StatusPartiallyFilled
+	o.UpdateTime = now
+	e.fills = append(e.fills, Fill{
+		ID:      e.nextID(),
+		OrderID: o.ID,
+		Symbol:  o.Symbol,
+		Side:    o.Side,
+		Size:    qty,
+		Price:   price,
+		Fee:     fee,
+		Maker:   maker,
+		Time:    now,
+	})
+}
+
+// trade adds signed contracts at price to p and returns the realized PnL of
+// the part that reduced it.
+func (e *Engine) trade(p *Position, c symbols.Contract, signed, price decimal.Decimal) decimal.Decimal {
+	var pnl decimal.Decimal
+	if !p.Size.IsZero() && p.Size.Sign() != signed.Sign() {
+		closed := minDecimal(signed.Abs(), p.Size.Abs())
+		pnl = c.Notional(closed, price).Sub(c.Notional(closed, p.EntryPrice))
+		if p.Size.Sign() < 0 {
+			pnl = pnl.Neg()
+		}
+		released := p.Margin.Mul(closed).Div(p.Size.Abs(), divPlaces)
+		if signed.Sign() < 0 {
+			closed = closed.Neg()
+		}
+		p.Size = p.Size.Add(closed)
+		signed = signed.Sub(closed)
+		p.Margin = trim(p.Margin.Sub(released))
+		if p.Size.IsZero() {
+			p.EntryPrice, p.Margin = decimal.Zero, decimal.Zero
+		}
+	}
+	if !signed.IsZero() {
+		if p.Size.IsZero() {
+			p.RealizedPnL = decimal.Zero
+		}
+		size := p.Size.Abs().Add(signed.Abs())
+		p.EntryPrice = trim(p.EntryPrice.Mul(p.Size.Abs()).Add(price.Mul(signed.Abs())).Div(size, divPlaces))
+		p.Margin = trim(p.Margin.Add(c.Notional(signed, price).Div(decimal.NewFromInt(int64(p.Leverage)), divPlaces)))
+		p.Size = p.Size.Add(signed)
+	}
+	return pnl
+}
+
+// --- Helpers, called with e.mu held unless noted ---
+
+// checkRequest validates req and fills in defaults. It takes e.mu.
+func (e *Engine) checkRequest(req *OrderRequest) error {
+	e.mu.Lock()
+	c, err := e.contract(req.Symbol)
+	e.mu.Unlock()
+	if err != nil {
+		return err
+	}
+	if c.Inverse {
+		return invalid("inverse contract %s is not supported", req.Symbol)
+	}
+	switch {
+	case req.Side != exchange.Buy && req.Side != exchange.Sell:
+		return invalid("unknown side %q", req.Side)
+	case req.Type != exchange.Limit && req.Type != exchange.Market:
+		return invalid("unknown order type %q", req.Type)
+	case req.PositionSide != "" && req.PositionSide != exchange.Long && req.PositionSide != exchange.Short:
+		return invalid("unknown position side %q", req.PositionSide)
+	case !req.Close && req.Size.Sign() <= 0:
+		return invalid("size %s must be positive", req.Size)
+	case req.Type == exchange.Limit && req.Price.Sign() <= 0:
+		return invalid("limit price %s must be positive", req.Price)
+	}
+	switch req.TimeInForce {
+	case "", exchange.GTC, exchange.IOC, exchange.FOK:
+	case exchange.PostOnly:
+		if req.Type == exchange.Market {
+			return invalid("market orders cannot be post-only")
+		}
+	default:
+		return invalid("unknown time in force %q", req.TimeInForce)
+	}
+	return nil
+}
+
+func (e *Engine) contract(symbol string) (symbols.Contract, error) {
+	c, ok := e.contracts[symbol]
+	if !ok {
+		return symbols.Contract{}, invalid("unknown contract %s", symbol)
+	}
+	return c, nil
+}
+
+// book fetches the book of symbol. Called without e.mu held.
+func (e *Engine) book(ctx context.Context, symbol string) (*exchange.OrderBook, error) {
+	book, err := e.books.OrderBook(ctx, symbol)
+	if err != nil {
+		return nil, fmt.Errorf("paper: order book of %s: %w", symbol, err)
+	}
+	return book, nil
+}
+
+// wait sleeps for the configured latency. Called without e.mu held.
+func (e *Engine) wait(ctx context.Context) error {
+	if e.cfg.Latency <= 0 {
+		return ctx.Err()
+	}
+	timer := time.NewTimer(e.cfg.Latency)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// observe updates the mark price of symbol to the book's mid price,
+// revalues its positions and reconciles the liquidity own fills used.
+func (e *Engine) observe(symbol string, book *exchange.OrderBook) {
+	e.forget(symbol, book)
+	var bid, ask decimal.Decimal
+	if len(book.Bids) > 0 {
+		bid = decimal.NewFromFloat(book.Bids[0].Price)
+	}
+	if len(book.Asks) > 0 {
+		ask = decimal.NewFromFloat(book.Asks[0].Price)
+	}
+	switch {
+	case bid.Sign() > 0 && ask.Sign() > 0:
+		e.marks[symbol] = trim(bid.Add(ask).Div(decimal.NewFromInt(2), divPlaces))
+	case bid.Sign() > 0:
+		e.marks[symbol] = bid
+	case ask.Sign() > 0:
+		e.marks[symbol] = ask
+	default:
+		return
+	}
+	c := e.contracts[symbol]
+	for k, p := range e.positions {
+		if k.symbol == symbol {
+			e.revalue(p, c)
+		}
+	}
+}
+
+// revalue updates the mark price and unrealized PnL of p.
+func (e *Engine) revalue(p *Position, c symbols.Contract) {
+	mark, ok := e.marks[p.Symbol]
+	if !ok {
+		return
+	}
+	p.MarkPrice = mark
+	p.UnrealizedPnL = c.Notional(p.Size, mark).Sub(c.Notional(p.Size, p.EntryPrice))
+	if p.Size.Sign() < 0 {
+		p.UnrealizedPnL = p.UnrealizedPnL.Neg()
+	}
+}
+
+// leg returns the position leg of symbol and side, creating a flat one.
+func (e *Engine) leg(symbol string, side exchange.PositionSide) *Position {
+	k := legKey{symbol, side}
+	p, ok := e.positions[k]
+	if !ok {
+		leverage, ok := e.leverage[k]
+		if !ok {
+			leverage = e.cfg.Leverage
+		}
+		p = &Position{Symbol: symbol, Side: side, Leverage: leverage}
+		e.positions[k] = p
+	}
+	return p
+}
+
+// reducing reports whether o may only reduce its position leg.
+func (e *Engine) reducing(o *Order) bool {
+	return o.ReduceOnly ||
+		(o.PositionSide == exchange.Long && o.Side == exchange.Sell) ||
+		(o.PositionSide == exchange.Short && o.Side == exchange.Buy)
+}
+
+// closable returns how much of o would reduce its position leg.
+func (e *Engine) closable(o *Order) decimal.Decimal {
+	p, ok := e.positions[legKey{o.Symbol, o.PositionSide}]
+	if !ok || p.Size.IsZero() {
+		return decimal.Zero
+	}
+	if (o.Side == exchange.Buy) == (p.Size.Sign() < 0) {
+		return p.Size.Abs()
+	}
+	return decimal.Zero
+}
+
+// crosses reports whether limit order o would take liquidity from book.
+func (e *Engine) crosses(o *Order, book *exchange.OrderBook) bool {
+	if o.Side == exchange.Buy {
+		return len(book.Asks) > 0 && !decimal.NewFromFloat(book.Asks[0].Price).GreaterThan(o.Price)
+	}
+	return len(book.Bids) > 0 && !decimal.NewFromFloat(book.Bids[0].Price).LessThan(o.Price)
+}
+
+// liquidity returns the size o could take from book at once.
+func (e *Engine) liquidity(o *Order, book *exchange.OrderBook) decimal.Decimal {
+	size := total(e.depth(o, book))
+	if e.reducing(o) {
+		size = minDecimal(size, e.closable(o))
+	}
+	return size
+}
+
+// depth returns the levels of book that o can fill against, best first and
+// up to its limit price, without the size earlier fills used.
+func (e *Engine) depth(o *Order, book *exchange.OrderBook) []level {
+	levels, bid := book.Asks, false
+	if o.Side == exchange.Sell {
+		levels, bid = book.Bids, true
+	}
+	result := make([]level, 0, len(levels))
+	for _, l := range levels {
+		price := decimal.NewFromFloat(l.Price)
+		if o.Type == exchange.Limit && ((o.Side == exchange.Buy && price.GreaterThan(o.Price)) || (o.Side == exchange.Sell && price.LessThan(o.Price))) {
+			break
+		}
+		size := decimal.NewFromFloat(l.Size).Sub(e.used[bookLevel{o.Symbol, bid, price.String()}])
+		if size.Sign() > 0 {
+			result = append(result, level{price: price, size: size})
+		}
+	}
+	return result
+}
+
+// use records that a fill of qty for o took liquidity from levels, best
+// first.
+func (e *Engine) use(o *Order, levels []level, qty decimal.Decimal) {
+	bid := o.Side == exchange.Sell
+	for _, l := range levels {
+		if qty.Sign() <= 0 {
+			return
+		}
+		taken := minDecimal(qty, l.size)
+		k := bookLevel{o.Symbol, bid, l.price.String()}
+		e.used[k] = e.used[k].Add(taken)
+		qty = qty.Sub(taken)
+	}
+}
+
+// forget reconciles the liquidity own fills used with a new book of symbol.
+// The venue's book never reflects simulated fills, so the used size of a
+// level stays unavailable and only size the level gains can fill; a level
+// that shrinks below its used size counts as used up, and one that leaves
+// the book is fresh if it returns.
+func (e *Engine) forget(symbol string, book *exchange.OrderBook) {
+	shown := make(map[bookLevel]decimal.Decimal, len(book.Bids)+len(book.Asks))
+	for _, l := range book.Bids {
+		shown[bookLevel{symbol, true, decimal.NewFromFloat(l.Price).String()}] = decimal.NewFromFloat(l.Size)
+	}
+	for _, l := range book.Asks {
+		shown[bookLevel{symbol, false, decimal.NewFromFloat(l.Price).String()}] = decimal.NewFromFloat(l.Size)
+	}
+	for k, used := range e.used {
+		if k.symbol != symbol {
+			continue
+		}
+		size, ok := shown[k]
+		switch {
+		case !ok || size.Sign() <= 0:
+			delete(e.used, k)
+		case used.GreaterThan(size):
+			e.used[k] = size
+		}
+	}
+}
+
+func total(levels []level) decimal.Decimal {
+	var size decimal.Decimal
+	for _, l := range levels {
+		size = size.Add(l.size)
+	}
+	return size
+}
+
+// refPrice estimates the fill price of market order o for the margin check.
+func (e *Engine) refPrice(o *Order, book *exchange.OrderBook) decimal.Decimal {
+	levels := book.Asks
+	if o.Side == exchange.Sell {
+		levels = book.Bids
+	}
+	if len(levels) > 0 {
+		return decimal.NewFromFloat(levels[len(levels)-1].Price) // Worst level it could reach
+	}
+	return e.marks[o.Symbol]
+}
+
+// orderMargin returns the margin reserved for the opening part of open
+// limit orders.
+func (e *Engine) orderMargin() decimal.Decimal {
+	var total decimal.Decimal
+	for _, o := range e.orders {
+		if !o.IsOpen() || e.reducing(o) {
+			continue
+		}
+		opening := o.Left().Sub(minDecimal(o.Left(), e.closable(o)))
+		if opening.Sign() <= 0 {
+			continue
+		}
+		leverage := decimal.NewFromInt(int64(e.leg(o.Symbol, o.PositionSide).Leverage))
+		total = total.Add(e.contracts[o.Symbol].Notional(opening, o.Price).Div(leverage, divPlaces))
+	}
+	return trim(total)
+}
+
+// available returns the balance not held as position or order margin.
+func (e *Engine) available() decimal.Decimal {
+	a := e.balance.Sub(e.orderMargin())
+	for _, p := range e.positions {
+		a = a.Sub(p.Margin)
+	}
+	return a
+}
+
+func (e *Engine) openOrder(id int64) (*Order, error) {
+	o, ok := e.orders[id]
+	if !ok || !o.IsOpen() {
+		return nil, fmt.Errorf("paper: order %d: %w", id, exchange.ErrOrderNotFound)
+	}
+	return o, nil
+}
+
+func (e *Engine) finish(o *Order, status exchange.OrderStatus, reason FinishReason, now time.Time) {
+	o.Status, o.FinishAs, o.UpdateTime = status, reason, now
+}
+
+func (e *Engine) sortedOrders() []*Order {
+	result := make([]*Order, 0, len(e.orders))
+	for _, o := range e.orders {
+		result = append(result, o)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].ID < result[j].ID })
+	return result
+}
+
+func (e *Engine) sortedTriggers() []*Trigger {
+	result := make([]*Trigger, 0, len(e.triggers))
+	for _, t := range e.triggers {
+		result = append(result, t)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].ID < result[j].ID })
+	return result
+}
+
+func (e *Engine) nextID() int64 {
+	e.lastID++
+	return e.lastID
+}
+
+func (o *Order) copy() *Order {
+	c := *o
+	return &c
+}
+
+func minDecimal(a, b decimal.Decimal) decimal.Decimal {
+	if b.LessThan(a) {
+		return b
+	}
+	return a
+}
+
+// trim drops the trailing zeros a division leaves, e.g. "27000.000000000000".
+func trim(d decimal.Decimal) decimal.Decimal {
+	for p := int32(0); p < divPlaces; p++ {
+		if t := d.Truncate(p); t.Equal(d) {
+			return t
+		}
+	}
+	return d
+}
+
+func invalid(format string, args ...interface{}) error {
+	return fmt.Errorf("paper: %s: %w", fmt.Sprintf(format, args...), exchange.ErrInvalidParameter)
+}
+
+func insufficient(need, available decimal.Decimal) error {
+	return fmt.Errorf("paper: margin of %s needed, %s available: %w", need, available, exchange.ErrInsufficientBalance)
+}
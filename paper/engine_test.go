@@ -0,0 +1,216 @@
+package paper_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/neqin/futures/decimal"
+	"github.com/neqin/futures/exchange"
+	"github.com/neqin/futures/paper"
+	"github.com/neqin/futures/symbols"
+)
+
+const symbol = "BTC_USDT"
+
+func newEngine(t *testing.T, balance int64) (*paper.Engine, *paper.StaticBooks) {
+	t.Helper()
+	books := paper.NewStaticBooks()
+	e := paper.NewEngine(paper.Config{Currency: "USDT", Balance: decimal.NewFromInt(balance), Leverage: 1}, books)
+	e.SetContracts(symbols.Contract{
+		Symbol:     symbol,
+		TickSize:   decimal.MustParse("0.1"),
+		LotSize:    decimal.NewFromInt(1),
+		Multiplier: decimal.NewFromInt(1),
+	})
+	setBook(books, []exchange.OrderBookLevel{{Price: 99, Size: 10}}, []exchange.OrderBookLevel{{Price: 101, Size: 10}})
+	return e, books
+}
+
+func setBook(books *paper.StaticBooks, bids, asks []exchange.OrderBookLevel) {
+	books.Set(&exchange.OrderBook{Symbol: symbol, Bids: bids, Asks: asks})
+}
+
+func limit(side exchange.Side, size, price int64) paper.OrderRequest {
+	return paper.OrderRequest{Symbol: symbol, Side: side, Type: exchange.Limit, Size: decimal.NewFromInt(size), Price: decimal.NewFromInt(price)}
+}
+
+func place(t *testing.T, e *paper.Engine, req paper.OrderRequest) *paper.Order {
+	t.Helper()
+	o, err := e.PlaceOrder(context.Background(), req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return o
+}
+
+func match(t *testing.T, e *paper.Engine) {
+	t.Helper()
+	if err := e.Match(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func filled(t *testing.T, e *paper.Engine, id int64) decimal.Decimal {
+	t.Helper()
+	o, err := e.Order(id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return o.Filled
+}
+
+func TestPartialFillNotRepeated(t *testing.T) {
+	e, books := newEngine(t, 100000)
+	o := place(t, e, limit(exchange.Buy, 5, 100))
+	if o.Status != exchange.StatusOpen {
+		t.Fatalf("status = %s, want open", o.Status)
+	}
+
+	setBook(books, nil, []exchange.OrderBookLevel{{Price: 100, Size: 2}, {Price: 101, Size: 10}})
+	for pass := 1; pass <= 3; pass++ {
+		match(t, e)
+		if got := filled(t, e, o.ID); !got.Equal(decimal.NewFromInt(2)) {
+			t.Errorf("pass %d: filled %s, want 2 from the unchanged level", pass, got)
+		}
+	}
+
+	setBook(books, nil, []exchange.OrderBookLevel{{Price: 100, Size: 4}, {Price: 101, Size: 10}})
+	match(t, e)
+	if got := filled(t, e, o.ID); !got.Equal(decimal.NewFromInt(4)) {
+		t.Errorf("filled %s, want 4 after the level grew by 2", got)
+	}
+
+	setBook(books, nil, []exchange.OrderBookLevel{{Price: 101, Size: 10}})
+	match(t, e)
+	setBook(books, nil, []exchange.OrderBookLevel{{Price: 100, Size: 3}})
+	match(t, e)
+	if got, _ := e.Order(o.ID); got.Status != exchange.StatusFilled || len(e.Fills(symbol)) != 3 {
+		t.Errorf("after the level returned: status %s, %d fills, want filled in 3", got.Status, len(e.Fills(symbol)))
+	}
+}
+
+func TestOrdersShareLevel(t *testing.T) {
+	e, books := newEngine(t, 100000)
+	first := place(t, e, limit(exchange.Buy, 2, 100))
+	second := place(t, e, limit(exchange.Buy, 2, 100))
+
+	setBook(books, nil, []exchange.OrderBookLevel{{Price: 100, Size: 3}})
+	match(t, e)
+	if got := filled(t, e, first.ID); !got.Equal(decimal.NewFromInt(2)) {
+		t.Errorf("first order filled %s, want 2", got)
+	}
+	if got := filled(t, e, second.ID); !got.Equal(decimal.NewFromInt(1)) {
+		t.Errorf("second order filled %s, want the remaining 1", got)
+	}
+
+	// A market order arriving later finds the level used up.
+	market := place(t, e, paper.OrderRequest{Symbol: symbol, Side: exchange.Buy, Type: exchange.Market, Size: decimal.NewFromInt(1)})
+	if market.Status != exchange.StatusCanceled || !market.Filled.IsZero() {
+		t.Errorf("market order: %s with %s filled, want cancelled unfilled", market.Status, market.Filled)
+	}
+}
+
+func TestReduceOnlyLimit(t *testing.T) {
+	e, books := newEngine(t, 100000)
+	place(t, e, paper.OrderRequest{Symbol: symbol, Side: exchange.Buy, Type: exchange.Market, Size: decimal.NewFromInt(2)})
+
+	req := limit(exchange.Sell, 5, 100)
+	req.ReduceOnly = true
+	o := place(t, e, req)
+	setBook(books, []exchange.OrderBookLevel{{Price: 100, Size: 10}}, []exchange.OrderBookLevel{{Price: 101, Size: 10}})
+	match(t, e)
+
+	got, _ := e.Order(o.ID)
+	if !got.Filled.Equal(decimal.NewFromInt(2)) || got.FinishAs != paper.FinishReduceOnly {
+		t.Errorf("reduce-only order: %s filled, finished as %q; want 2 and %q", got.Filled, got.FinishAs, paper.FinishReduceOnly)
+	}
+	if p, _ := e.Position(symbol, ""); !p.Size.IsZero() {
+		t.Errorf("position size = %s, want flat", p.Size)
+	}
+}
+
+func TestMarginRejection(t *testing.T) {
+	e, _ := newEngine(t, 1000)
+	_, err := e.PlaceOrder(context.Background(), limit(exchange.Buy, 20, 100)) // 2000 USDT at 1x
+	if !errors.Is(err, exchange.ErrInsufficientBalance) {
+		t.Errorf("oversized order: %v, want ErrInsufficientBalance", err)
+	}
+	if o := place(t, e, limit(exchange.Buy, 5, 100)); o.Status != exchange.StatusOpen {
+		t.Errorf("affordable order status = %s, want open", o.Status)
+	}
+	// The resting order reserves 500 of the 1000.
+	if _, err := e.PlaceOrder(context.Background(), limit(exchange.Buy, 6, 100)); !errors.Is(err, exchange.ErrInsufficientBalance) {
+		t.Errorf("order beyond the reserved margin: %v, want ErrInsufficientBalance", err)
+	}
+}
+
+func TestTimeInForce(t *testing.T) {
+	tests := []struct {
+		name     string
+		tif      exchange.TimeInForce
+		size     int64
+		price    int64
+		filled   int64
+		finishAs paper.FinishReason
+	}{
+		{"FOK without enough liquidity", exchange.FOK, 11, 101, 0, paper.FinishFOK},
+		{"FOK filled", exchange.FOK, 10, 101, 10, paper.FinishFilled},
+		{"IOC rest cancelled", exchange.IOC, 12, 101, 10, paper.FinishIOC},
+		{"IOC not marketable", exchange.IOC, 1, 100, 0, paper.FinishIOC},
+		{"post-only crossing", exchange.PostOnly, 1, 101, 0, paper.FinishPostOnly},
+		{"post-only resting", exchange.PostOnly, 1, 100, 0, ""},
+	}
+	for _, tt := range tests {
+		e, _ := newEngine(t, 100000)
+		req := limit(exchange.Buy, tt.size, tt.price)
+		req.TimeInForce = tt.tif
+		o := place(t, e, req)
+		if !o.Filled.Equal(decimal.NewFromInt(tt.filled)) || o.FinishAs != tt.finishAs {
+			t.Errorf("%s: %s filled, finished as %q; want %d and %q", tt.name, o.Filled, o.FinishAs, tt.filled, tt.finishAs)
+		}
+	}
+}
+
+func TestTriggers(t *testing.T) {
+	ctx := context.Background()
+	e, books := newEngine(t, 100000)
+	above, err := e.PlaceTrigger(ctx, paper.TriggerRequest{
+		Order: paper.OrderRequest{Symbol: symbol, Side: exchange.Buy, Type: exchange.Market, Size: decimal.NewFromInt(1)},
+		Price: decimal.NewFromInt(110),
+		Rule:  paper.PriceAbove,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	rejected, err := e.PlaceTrigger(ctx, paper.TriggerRequest{
+		Order: paper.OrderRequest{Symbol: symbol, Side: exchange.Buy, Type: exchange.Limit, Size: decimal.NewFromInt(10000), Price: decimal.NewFromInt(110)},
+		Price: decimal.NewFromInt(110),
+		Rule:  paper.PriceAbove,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	match(t, e) // Mid price 100
+	if got, _ := e.Trigger(above.ID); got.Status != paper.TriggerOpen {
+		t.Errorf("trigger below its price: %s, want open", got.Status)
+	}
+
+	setBook(books, []exchange.OrderBookLevel{{Price: 110, Size: 10}}, []exchange.OrderBookLevel{{Price: 111, Size: 10}})
+	match(t, e)
+	got, _ := e.Trigger(above.ID)
+	if got.Status != paper.TriggerFinished {
+		t.Fatalf("trigger at its price: %s, want finished", got.Status)
+	}
+	o, err := e.Order(got.OrderID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if o.Status != exchange.StatusFilled || !o.AvgPrice.Equal(decimal.NewFromInt(111)) {
+		t.Errorf("triggered order: %s at %s, want filled at 111", o.Status, o.AvgPrice)
+	}
+	if got, _ := e.Trigger(rejected.ID); got.Status != paper.TriggerFailed || got.Reason == "" {
+		t.Errorf("unaffordable trigger: %s (%q), want failed with a reason", got.Status, got.Reason)
+	}
+}
@@ -0,0 +1,182 @@
+// Package paper simulates a futures account and matching engine, so trading
+// strategies can run against live market data without sending orders to a
+// venue.
+//
+// An Engine keeps the wallet, positions, orders and price-triggered orders in
+// memory and fills orders against the order books of a BookSource: REST
+// snapshots polled through an exchange.MarketData adapter (PolledBooks) or
+// local books kept in sync from WebSocket diffs (StreamedBooks). Marketable
+// orders take liquidity from the book when they arrive; resting limit orders
+// fill at their limit price once the opposite side of the book reaches it,
+// and triggers fire once the mid price crosses them, both checked by Match
+// (or periodically by Run). The connectors wrap an Engine in clients with the
+// method signatures of their REST clients (gateio.NewPaperClient,
+// xt.NewPaperClient).
+//
+// The venue's books never reflect simulated fills, so the engine remembers
+// the size its fills took from each level and only fills later orders
+// against size the level gained since. Otherwise the simulation is
+// deliberately simple: fills do not queue behind the size resting ahead of
+// them, margin is isolated per position, and funding, liquidations and
+// inverse (coin-margined) contracts are not modelled.
+package paper
+
+import (
+	"context"
+	"time"
+
+	"github.com/neqin/futures/decimal"
+	"github.com/neqin/futures/exchange"
+)
+
+// DefaultLeverage is the leverage of new positions if Config.Leverage is zero.
+const DefaultLeverage = 10
+
+// Config configures a simulated account.
+type Config struct {
+	Currency string          // Settlement currency, e.g. "USDT"
+	Balance  decimal.Decimal // Starting wallet balance
+	MakerFee decimal.Decimal // Fee rate of resting fills, e.g. 0.0002; negative for a rebate
+	TakerFee decimal.Decimal // Fee rate of fills that take liquidity, e.g. 0.0005
+	Latency  time.Duration   // Delay before an order, amendment or cancellation reaches the engine
+	Leverage int             // Leverage of new positions, DefaultLeverage if zero
+}
+
+// OrderRequest describes an order to be placed.
+type OrderRequest struct {
+	Symbol        string                // Canonical symbol, e.g. "BTC_USDT"
+	Side          exchange.Side         // Buy or Sell
+	Type          exchange.OrderType    // Limit or Market
+	Size          decimal.Decimal       // Contracts, positive; ignored if Close is set
+	Price         decimal.Decimal       // Limit price, ignored for market orders
+	TimeInForce   exchange.TimeInForce  // GTC if empty; market orders are always IOC
+	ReduceOnly    bool                  // Only reduce the position, see Engine.PlaceOrder
+	Close         bool                  // Close the whole position leg; implies ReduceOnly
+	PositionSide  exchange.PositionSide // Position leg in hedge mode, empty in one-way mode
+	ClientOrderID string                // Optional caller-assigned identifier
+}
+
+// FinishReason records why an order stopped being open.
+type FinishReason string
+
+const (
+	FinishFilled     FinishReason = "filled"      // Completely filled
+	FinishCancelled  FinishReason = "cancelled"   // Cancelled by the user
+	FinishIOC        FinishReason = "ioc"         // Unfilled rest of an IOC or market order
+	FinishFOK        FinishReason = "fok"         // FOK order that could not be filled completely
+	FinishPostOnly   FinishReason = "post_only"   // Post-only order that would have taken liquidity
+	FinishReduceOnly FinishReason = "reduce_only" // Reduce-only order left without a position to reduce
+)
+
+// Order is a simulated order.
+type Order struct {
+	OrderRequest
+	ID         int64
+	Status     exchange.OrderStatus
+	FinishAs   FinishReason    // Set once the order is no longer open
+	Filled     decimal.Decimal // Filled contracts
+	AvgPrice   decimal.Decimal // Average fill price, zero if nothing filled
+	Fee        decimal.Decimal // Fees paid, negative for rebates
+	CreateTime time.Time
+	UpdateTime time.Time
+}
+
+// IsOpen reports whether the order may still fill.
+func (o *Order) IsOpen() bool {
+	return o.Status == exchange.StatusOpen || o.Status == exchange.StatusPartiallyFilled
+}
+
+// Left returns the unfilled size.
+func (o *Order) Left() decimal.Decimal {
+	return o.Size.Sub(o.Filled)
+}
+
+// Fill is an execution of a simulated order.
+type Fill struct {
+	ID      int64
+	OrderID int64
+	Symbol  string
+	Side    exchange.Side
+	Size    decimal.Decimal // Contracts, positive
+	Price   decimal.Decimal
+	Fee     decimal.Decimal // Negative for rebates
+	Maker   bool            // The order was resting in the book
+	Time    time.Time
+}
+
+// Position is a position leg. In one-way mode each symbol has a single leg
+// with an empty Side whose Size is negative when short; in hedge mode the
+// "long" and "short" legs are kept apart.
+type Position struct {
+	Symbol        string
+	Side          exchange.PositionSide // Leg in hedge mode, empty in one-way mode
+	Size          decimal.Decimal       // Contracts, negative for short
+	EntryPrice    decimal.Decimal       // Average entry price, zero if flat
+	Leverage      int
+	Margin        decimal.Decimal // Isolated margin held by the position
+	MarkPrice     decimal.Decimal // Mid price of the last book seen
+	UnrealizedPnL decimal.Decimal // At MarkPrice
+	RealizedPnL   decimal.Decimal // Since the position was last opened, net of fees
+}
+
+// Account summarizes the wallet.
+type Account struct {
+	Currency       string
+	Balance        decimal.Decimal // Starting balance plus realized PnL minus fees
+	UnrealizedPnL  decimal.Decimal // Of all positions at their mark prices
+	PositionMargin decimal.Decimal // Margin held by positions
+	OrderMargin    decimal.Decimal // Margin reserved for the opening part of open orders
+	Available      decimal.Decimal // Balance minus position and order margin
+	RealizedPnL    decimal.Decimal // Total realized PnL, excluding fees
+	Fees           decimal.Decimal // Total fees paid, negative for net rebates
+}
+
+// Equity returns the balance plus unrealized PnL.
+func (a Account) Equity() decimal.Decimal {
+	return a.Balance.Add(a.UnrealizedPnL)
+}
+
+// TriggerRule is the condition of a price-triggered order.
+type TriggerRule int
+
+const (
+	PriceAbove TriggerRule = 1 // Fires when the price is at or above the trigger price
+	PriceBelow TriggerRule = 2 // Fires when the price is at or below the trigger price
+)
+
+// TriggerRequest describes an order that is placed once the mid price
+// crosses Price.
+type TriggerRequest struct {
+	Order      OrderRequest
+	Price      decimal.Decimal
+	Rule       TriggerRule
+	Expiration time.Duration // Cancel the trigger if it has not fired within this time; zero for never
+}
+
+// TriggerStatus is the lifecycle state of a price-triggered order.
+type TriggerStatus string
+
+const (
+	TriggerOpen      TriggerStatus = "open"      // Waiting for the price
+	TriggerFinished  TriggerStatus = "finished"  // Fired; OrderID holds the placed order
+	TriggerCancelled TriggerStatus = "cancelled" // Cancelled by the user
+	TriggerFailed    TriggerStatus = "failed"    // Fired, but the order was rejected; see Reason
+	TriggerExpired   TriggerStatus = "expired"   // Expiration passed before it fired
+)
+
+// Trigger is a simulated price-triggered order.
+type Trigger struct {
+	TriggerRequest
+	ID         int64
+	Status     TriggerStatus
+	OrderID    int64  // Order placed when the trigger fired
+	Reason     string // Why a failed trigger's order was rejected
+	CreateTime time.Time
+	FinishTime time.Time
+}
+
+// BookSource provides the order books orders are filled against.
+type BookSource interface {
+	// OrderBook returns the current book of a canonical symbol.
+	OrderBook(ctx context.Context, symbol string) (*exchange.OrderBook, error)
+}
@@ -0,0 +1,154 @@
+// Package ratelimit provides the client-side token-bucket limiter used by the
+// connectors to stay within each venue's published REST rate limits.
+//
+// Requests are classified into endpoint groups (public market data, private
+// trading, private account) and every group has its own bucket, so a burst of
+// order placement does not starve account polling and vice versa. A single
+// Limiter is safe for concurrent use and is normally shared by all goroutines
+// using one connector client.
+package ratelimit
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// ErrRateLimited is returned by Wait when the caller's context deadline
+// expires before a token becomes available.
+var ErrRateLimited = errors.New("client-side rate limit exceeded")
+
+// Group identifies a set of endpoints that share a rate limit.
+type Group string
+
+const (
+	PublicMarket   Group = "public_market"   // Public market data endpoints
+	PrivateTrading Group = "private_trading" // Order placement, amendment and cancellation
+	PrivateAccount Group = "private_account" // Account, position, order query and history endpoints
+)
+
+// Rate is the sustained request rate and burst size of a bucket.
+type Rate struct {
+	Requests int           // Requests allowed per Per
+	Per      time.Duration // Window the Requests are spread over
+	Burst    int           // Bucket capacity; defaults to Requests if 0
+}
+
+// Every returns a Rate of requests per window with burst equal to requests.
+func Every(requests int, per time.Duration) Rate {
+	return Rate{Requests: requests, Per: per}
+}
+
+func (r Rate) perSecond() float64 {
+	return float64(r.Requests) / r.Per.Seconds()
+}
+
+func (r Rate) burst() float64 {
+	if r.Burst > 0 {
+		return float64(r.Burst)
+	}
+	return float64(r.Requests)
+}
+
+// Limiter is a set of token buckets keyed by endpoint group. Groups without a
+// configured rate are not limited.
+type Limiter struct {
+	mu      sync.Mutex
+	buckets map[Group]*bucket
+	now     func() time.Time
+}
+
+type bucket struct {
+	rate   float64 // Tokens per second
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+// New creates a limiter with the given per-group rates.
+func New(rates map[Group]Rate) *Limiter {
+	l := &Limiter{
+		buckets: make(map[Group]*bucket, len(rates)),
+		now:     time.Now,
+	}
+	for g, r := range rates {
+		l.SetRate(g, r)
+	}
+	return l
+}
+
+// SetRate configures (or replaces) the rate of a group. The bucket starts full.
+// A Rate with zero Requests removes the limit for the group.
+func (l *Limiter) SetRate(group Group, r Rate) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if r.Requests <= 0 || r.Per <= 0 {
+		delete(l.buckets, group)
+		return
+	}
+	l.buckets[group] = &bucket{
+		rate:   r.perSecond(),
+		burst:  r.burst(),
+		tokens: r.burst(),
+		last:   l.now(),
+	}
+}
+
+// Wait blocks until a request in group may be sent. If ctx has a deadline
+// that would pass before a token is available, Wait returns ErrRateLimited
+// immediately instead of sleeping; without a deadline it blocks until a token
+// is available or ctx is cancelled. A nil Limiter never blocks.
+func (l *Limiter) Wait(ctx context.Context, group Group) error {
+	if l == nil {
+		return nil
+	}
+	delay, ok := l.reserve(group)
+	if !ok || delay == 0 {
+		return nil
+	}
+	if deadline, has := ctx.Deadline(); has && time.Until(deadline) < delay {
+		l.cancel(group)
+		return fmt.Errorf("%w for %s: next slot in %s", ErrRateLimited, group, delay.Round(time.Millisecond))
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		l.cancel(group)
+		return ctx.Err()
+	}
+}
+
+// reserve takes a token from the group's bucket, letting the balance go
+// negative, and returns how long the caller must wait for it to be covered.
+// ok is false if the group is not limited.
+func (l *Limiter) reserve(group Group) (time.Duration, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	b, ok := l.buckets[group]
+	if !ok {
+		return 0, false
+	}
+	now := l.now()
+	b.tokens = min(b.burst, b.tokens+now.Sub(b.last).Seconds()*b.rate)
+	b.last = now
+	b.tokens--
+	if b.tokens >= 0 {
+		return 0, true
+	}
+	return time.Duration(-b.tokens / b.rate * float64(time.Second)), true
+}
+
+// cancel returns a reserved token that will not be used.
+func (l *Limiter) cancel(group Group) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if b, ok := l.buckets[group]; ok {
+		b.tokens = min(b.burst, b.tokens+1)
+	}
+}
@@ -0,0 +1,103 @@
+package ratelimit
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+// fakeClock is a settable time source for the limiter.
+type fakeClock struct{ t time.Time }
+
+func (c *fakeClock) now() time.Time          { return c.t }
+func (c *fakeClock) advance(d time.Duration) { c.t = c.t.Add(d) }
+
+func newLimiter(rates map[Group]Rate) (*Limiter, *fakeClock) {
+	clock := &fakeClock{t: time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)}
+	l := New(nil)
+	l.now = clock.now
+	for g, r := range rates {
+		l.SetRate(g, r)
+	}
+	return l, clock
+}
+
+func TestReserve(t *testing.T) {
+	l, clock := newLimiter(map[Group]Rate{PrivateTrading: {Requests: 10, Per: time.Second, Burst: 2}})
+	steps := []struct {
+		advance time.Duration
+		want    time.Duration
+	}{
+		{0, 0}, // The bucket starts full
+		{0, 0},
+		{0, 100 * time.Millisecond},
+		{0, 200 * time.Millisecond}, // Reservations queue up
+		{time.Second, 0},            // Refilled, but only up to the burst
+		{0, 0},
+		{0, 100 * time.Millisecond},
+	}
+	for i, s := range steps {
+		clock.advance(s.advance)
+		got, ok := l.reserve(PrivateTrading)
+		if !ok || got != s.want {
+			t.Errorf("step %d: delay %v (limited %t), want %v", i, got, ok, s.want)
+		}
+	}
+	if _, ok := l.reserve(PublicMarket); ok {
+		t.Error("group without a rate is limited")
+	}
+}
+
+func TestSetRate(t *testing.T) {
+	l, _ := newLimiter(map[Group]Rate{PublicMarket: Every(1, time.Hour)})
+	l.reserve(PublicMarket)
+	if d, _ := l.reserve(PublicMarket); d != time.Hour {
+		t.Errorf("second request waits %v, want 1h", d)
+	}
+	l.SetRate(PublicMarket, Every(5, time.Second)) // Replaced with a full bucket
+	if d, _ := l.reserve(PublicMarket); d != 0 {
+		t.Errorf("after SetRate: waits %v, want 0", d)
+	}
+	l.SetRate(PublicMarket, Rate{})
+	if _, ok := l.reserve(PublicMarket); ok {
+		t.Error("zero rate did not remove the limit")
+	}
+}
+
+func TestWait(t *testing.T) {
+	l, _ := newLimiter(map[Group]Rate{PrivateAccount: Every(1, time.Minute)})
+	ctx := context.Background()
+	if err := l.Wait(ctx, PrivateAccount); err != nil {
+		t.Fatal(err)
+	}
+
+	deadline, cancel := context.WithTimeout(ctx, time.Second)
+	defer cancel()
+	start := time.Now()
+	if err := l.Wait(deadline, PrivateAccount); !errors.Is(err, ErrRateLimited) {
+		t.Errorf("Wait past the deadline: %v, want ErrRateLimited", err)
+	}
+	if elapsed := time.Since(start); elapsed > 100*time.Millisecond {
+		t.Errorf("Wait past the deadline blocked for %v", elapsed)
+	}
+	// The refused request gave its token back, so the next one still waits a
+	// minute rather than two.
+	if d, _ := l.reserve(PrivateAccount); d != time.Minute {
+		t.Errorf("after a refused Wait: delay %v, want 1m", d)
+	}
+
+	cancelled, cancelNow := context.WithCancel(ctx)
+	cancelNow()
+	if err := l.Wait(cancelled, PrivateAccount); !errors.Is(err, context.Canceled) {
+		t.Errorf("Wait with a cancelled context: %v, want context.Canceled", err)
+	}
+
+	var nilLimiter *Limiter
+	if err := nilLimiter.Wait(ctx, PrivateAccount); err != nil {
+		t.Errorf("nil Limiter: %v", err)
+	}
+	if err := l.Wait(ctx, PublicMarket); err != nil {
+		t.Errorf("unlimited group: %v", err)
+	}
+}
@@ -0,0 +1,92 @@
+// Package redact removes credentials, signatures, listen keys and account
+// identifiers from query strings and request and response bodies. It is
+// shared by the request logs of package httplog and the fixtures of package
+// replay, so both hide the same values.
+package redact
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/url"
+	"slices"
+)
+
+// Redacted replaces redacted string values.
+const Redacted = "REDACTED"
+
+// Fields returns the query parameters and JSON object keys that are
+// redacted by default: the API key, secret and signature parameters, listen
+// keys, and the user and account IDs of both venues.
+func Fields() []string {
+	return []string{
+		"apiKey", "secretKey", "signature", "sign",
+		"listenKey", "user", "userId", "uid", "accountId",
+	}
+}
+
+// Query redacts the parameters named in fields from a raw query string. The
+// query is returned unchanged if it has none of them or does not parse.
+func Query(query string, fields []string) string {
+	values, err := url.ParseQuery(query)
+	if err != nil {
+		return query
+	}
+	changed := false
+	for k := range values {
+		if slices.Contains(fields, k) {
+			values.Set(k, Redacted)
+			changed = true
+		}
+	}
+	if !changed {
+		return query
+	}
+	return values.Encode()
+}
+
+// Body redacts the keys named in fields at any depth of a JSON body, or the
+// parameters of a form body. Strings become Redacted and numbers 0, so a
+// redacted body still decodes into the same types. The body is returned
+// unchanged if nothing was redacted.
+func Body(body []byte, fields []string) string {
+	trimmed := bytes.TrimSpace(body)
+	if len(trimmed) == 0 || (trimmed[0] != '{' && trimmed[0] != '[') {
+		return Query(string(body), fields)
+	}
+	var v any
+	dec := json.NewDecoder(bytes.NewReader(trimmed))
+	dec.UseNumber()
+	if err := dec.Decode(&v); err != nil || !redactValue(v, fields) {
+		return string(body)
+	}
+	out, err := json.Marshal(v)
+	if err != nil {
+		return string(body)
+	}
+	return string(out)
+}
+
+// redactValue redacts v in place and reports whether anything changed.
+func redactValue(v any, fields []string) bool {
+	changed := false
+	switch v := v.(type) {
+	case map[string]any:
+		for k, field := range v {
+			if slices.Contains(fields, k) {
+				switch field.(type) {
+				case string:
+					v[k], changed = Redacted, true
+				case json.Number:
+					v[k], changed = json.Number("0"), true
+				}
+				continue
+			}
+			changed = redactValue(field, fields) || changed
+		}
+	case []any:
+		for _, item := range v {
+			changed = redactValue(item, fields) || changed
+		}
+	}
+	return changed
+}
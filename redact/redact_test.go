@@ -0,0 +1,46 @@
+package redact_test
+
+import (
+	"testing"
+
+	"github.com/neqin/futures/redact"
+)
+
+func TestQuery(t *testing.T) {
+	fields := redact.Fields()
+	tests := []struct {
+		query, want string
+	}{
+		{"", ""},
+		{"symbol=btc_usdt&limit=10", "symbol=btc_usdt&limit=10"}, // Unchanged, not re-encoded
+		{"listenKey=lk&symbol=btc_usdt", "listenKey=REDACTED&symbol=btc_usdt"},
+		{"signature=abc&apiKey=k", "apiKey=REDACTED&signature=REDACTED"},
+		{"Signature=abc", "Signature=abc"},   // Case-sensitive
+		{"a=%zz&sign=abc", "a=%zz&sign=abc"}, // Does not parse
+	}
+	for _, tt := range tests {
+		if got := redact.Query(tt.query, fields); got != tt.want {
+			t.Errorf("Query(%q) = %q, want %q", tt.query, got, tt.want)
+		}
+	}
+}
+
+func TestBody(t *testing.T) {
+	fields := redact.Fields()
+	tests := []struct {
+		name, body, want string
+	}{
+		{"nothing to redact", `{"symbol": "btc_usdt", "size": 1}`, `{"symbol": "btc_usdt", "size": 1}`},
+		{"string and number", `{"uid":123,"listenKey":"lk","size":1.50}`, `{"listenKey":"REDACTED","size":1.50,"uid":0}`},
+		{"nested", `{"result":{"items":[{"userId":"u-1","name":"kept"}]}}`, `{"result":{"items":[{"name":"kept","userId":"REDACTED"}]}}`},
+		{"array", `[{"accountId":7}]`, `[{"accountId":0}]`},
+		{"form body", "apiKey=k&size=1", "apiKey=REDACTED&size=1"},
+		{"invalid JSON", `{"uid":`, `{"uid":`},
+		{"empty", "", ""},
+	}
+	for _, tt := range tests {
+		if got := redact.Body([]byte(tt.body), fields); got != tt.want {
+			t.Errorf("%s: got %s, want %s", tt.name, got, tt.want)
+		}
+	}
+}
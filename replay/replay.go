@@ -0,0 +1,314 @@
+// Package replay records the HTTP exchanges of a connector client into
+// fixture files and plays them back, so response decoding can be tested
+// against payloads captured from the venues without network access or real
+// keys.
+//
+// Both Recorder and Replayer are http.RoundTrippers and plug into the
+// connectors through the httpClient parameter of their constructors:
+//
+//	rec := replay.NewRecorder(nil)
+//	client := gateio.New(apiKey, secretKey, &http.Client{Transport: rec})
+//	// ... call the endpoints to capture ...
+//	err := rec.Save("testdata/gateio_account.json")
+//
+//	rp, err := replay.Load("testdata/gateio_account.json")
+//	client := gateio.New("", "", &http.Client{Transport: rp})
+//
+// Credentials, signatures and account identifiers are redacted before an
+// exchange is stored (see DefaultRedaction), and replayed requests are
+// matched with the same redaction applied, so replaying needs no real keys.
+// A gateio.Client can replay private endpoints without any; an xt.Client
+// rejects private calls without a key before sending them, so create it
+// with a placeholder pair such as xt.NewClient("x", "x", ...). Recorded
+// responses keep their headers, including the server-time headers the
+// clients feed into their clock offset.
+package replay
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/neqin/futures/redact"
+)
+
+// Redacted replaces redacted header, query and string values.
+const Redacted = redact.Redacted
+
+// Interaction is a recorded request and the response it received.
+type Interaction struct {
+	Request  Request  `json:"request"`
+	Response Response `json:"response"`
+}
+
+// Request is a recorded HTTP request.
+type Request struct {
+	Method string      `json:"method"`
+	URL    string      `json:"url"`
+	Header http.Header `json:"header,omitempty"`
+	Body   string      `json:"body,omitempty"`
+}
+
+// Response is a recorded HTTP response.
+type Response struct {
+	StatusCode int         `json:"statusCode"`
+	Header     http.Header `json:"header,omitempty"`
+	Body       string      `json:"body,omitempty"`
+}
+
+// fixture is the layout of a fixture file.
+type fixture struct {
+	Interactions []Interaction `json:"interactions"`
+}
+
+// Redaction lists the values removed from recorded exchanges.
+type Redaction struct {
+	Headers     []string // Request and response headers, case-insensitive
+	QueryParams []string // Query parameters, case-sensitive
+	JSONFields  []string // Object keys at any depth of JSON bodies and parameters of form bodies, case-sensitive
+}
+
+// DefaultRedaction redacts the Gate.io (KEY, SIGN) and XT (validate-appkey,
+// validate-signature) credential headers and cookies, and the query
+// parameters and JSON fields of redact.Fields: credentials, signatures,
+// listen keys and the user and account IDs of both venues.
+func DefaultRedaction() Redaction {
+	return Redaction{
+		Headers: []string{
+			"KEY", "SIGN",
+			"validate-appkey", "validate-signature",
+			"Authorization", "Cookie", "Set-Cookie",
+		},
+		QueryParams: redact.Fields(),
+		JSONFields:  redact.Fields(),
+	}
+}
+
+func (r Redaction) header(h http.Header) http.Header {
+	h = h.Clone()
+	for _, name := range r.Headers {
+		if _, ok := h[http.CanonicalHeaderKey(name)]; ok {
+			h.Set(name, Redacted)
+		}
+	}
+	return h
+}
+
+func (r Redaction) url(u *url.URL) string {
+	redacted := *u
+	redacted.RawQuery = redact.Query(u.RawQuery, r.QueryParams)
+	return redacted.String()
+}
+
+// body redacts the fields of a JSON or form body; see redact.Body.
+func (r Redaction) body(body []byte) string {
+	if len(r.JSONFields) == 0 {
+		return string(body)
+	}
+	return redact.Body(body, r.JSONFields)
+}
+
+// request captures req with redaction applied. The request body is read and
+// replaced so it can still be sent.
+func (r Redaction) request(req *http.Request) (Request, error) {
+	var body []byte
+	if req.Body != nil && req.Body != http.NoBody {
+		var err error
+		body, err = io.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return Request{}, fmt.Errorf("replay: read request body: %w", err)
+		}
+		req.Body = io.NopCloser(bytes.NewReader(body))
+	}
+	return Request{
+		Method: req.Method,
+		URL:    r.url(req.URL),
+		Header: r.header(req.Header),
+		Body:   r.body(body),
+	}, nil
+}
+
+// Recorder is an http.RoundTripper that forwards requests to another
+// transport and records each exchange that receives a response. It is safe
+// for concurrent use.
+type Recorder struct {
+	transport http.RoundTripper
+	redaction Redaction
+
+	mu           sync.Mutex
+	interactions []Interaction
+}
+
+// NewRecorder creates a Recorder sending requests through transport
+// (http.DefaultTransport if nil) with DefaultRedaction.
+func NewRecorder(transport http.RoundTripper) *Recorder {
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+	return &Recorder{transport: transport, redaction: DefaultRedaction()}
+}
+
+// SetRedaction replaces the values removed from recorded exchanges. A
+// Replayer for the fixture must use the same redaction.
+func (r *Recorder) SetRedaction(redaction Redaction) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.redaction = redaction
+}
+
+// RoundTrip sends req and records the exchange.
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	r.mu.Lock()
+	redaction := r.redaction
+	r.mu.Unlock()
+
+	recorded, err := redaction.request(req)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := r.transport.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, fmt.Errorf("replay: read response body: %w", err)
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.interactions = append(r.interactions, Interaction{
+		Request: recorded,
+		Response: Response{
+			StatusCode: resp.StatusCode,
+			Header:     redaction.header(resp.Header),
+			Body:       redaction.body(body),
+		},
+	})
+	return resp, nil
+}
+
+// Interactions returns the exchanges recorded so far, oldest first.
+func (r *Recorder) Interactions() []Interaction {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]Interaction(nil), r.interactions...)
+}
+
+// Save writes the recorded exchanges to a fixture file, creating its
+// directory if needed.
+func (r *Recorder) Save(path string) error {
+	data, err := json.MarshalIndent(fixture{Interactions: r.Interactions()}, "", "  ")
+	if err != nil {
+		return fmt.Errorf("replay: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("replay: %w", err)
+	}
+	if err := os.WriteFile(path, append(data, '\n'), 0o644); err != nil {
+		return fmt.Errorf("replay: %w", err)
+	}
+	return nil
+}
+
+// ErrNoInteraction is returned by Replayer.RoundTrip for a request that
+// matches no unused recorded exchange.
+var ErrNoInteraction = errors.New("replay: no recorded interaction")
+
+// Replayer is an http.RoundTripper that answers requests from recorded
+// exchanges without network access. A request is answered by the first
+// unused exchange with the same method, URL and body once the recording's
+// redaction is applied to it; each exchange is used once, so repeated
+// requests replay their responses in recording order. It is safe for
+// concurrent use.
+type Replayer struct {
+	mu           sync.Mutex
+	redaction    Redaction
+	interactions []Interaction
+	used         []bool
+}
+
+// NewReplayer creates a Replayer for interactions recorded with
+// DefaultRedaction.
+func NewReplayer(interactions []Interaction) *Replayer {
+	return &Replayer{
+		redaction:    DefaultRedaction(),
+		interactions: interactions,
+		used:         make([]bool, len(interactions)),
+	}
+}
+
+// Load reads a fixture file written by Recorder.Save.
+func Load(path string) (*Replayer, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("replay: %w", err)
+	}
+	var f fixture
+	if err := json.Unmarshal(data, &f); err != nil {
+		return nil, fmt.Errorf("replay: %s: %w", path, err)
+	}
+	return NewReplayer(f.Interactions), nil
+}
+
+// SetRedaction sets the redaction the fixture was recorded with.
+func (p *Replayer) SetRedaction(redaction Redaction) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.redaction = redaction
+}
+
+// RoundTrip answers req from the recorded exchanges, or fails with
+// ErrNoInteraction.
+func (p *Replayer) RoundTrip(req *http.Request) (*http.Response, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	recorded, err := p.redaction.request(req)
+	if err != nil {
+		return nil, err
+	}
+	for i, in := range p.interactions {
+		if p.used[i] || !strings.EqualFold(in.Request.Method, recorded.Method) ||
+			in.Request.URL != recorded.URL || in.Request.Body != recorded.Body {
+			continue
+		}
+		p.used[i] = true
+		return &http.Response{
+			Status:        fmt.Sprintf("%d %s", in.Response.StatusCode, http.StatusText(in.Response.StatusCode)),
+			StatusCode:    in.Response.StatusCode,
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        in.Response.Header.Clone(),
+			Body:          io.NopCloser(strings.NewReader(in.Response.Body)),
+			ContentLength: int64(len(in.Response.Body)),
+			Request:       req,
+		}, nil
+	}
+	return nil, fmt.Errorf("%w for %s %s", ErrNoInteraction, recorded.Method, recorded.URL)
+}
+
+// Unused returns the recorded exchanges that have not been replayed, e.g. to
+// check that a test made every captured request.
+func (p *Replayer) Unused() []Interaction {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	var unused []Interaction
+	for i, in := range p.interactions {
+		if !p.used[i] {
+			unused = append(unused, in)
+		}
+	}
+	return unused
+}
@@ -0,0 +1,202 @@
+package replay_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/neqin/futures/connectors/gateio"
+	"github.com/neqin/futures/connectors/gateio/gateiotest"
+	"github.com/neqin/futures/connectors/xt"
+	"github.com/neqin/futures/connectors/xt/xttest"
+	"github.com/neqin/futures/decimal"
+	"github.com/neqin/futures/paper"
+	"github.com/neqin/futures/replay"
+	"github.com/neqin/futures/retry"
+)
+
+// checkFixture fails if any of secrets appears in the fixture at path.
+func checkFixture(t *testing.T, path string, secrets ...string) {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, secret := range secrets {
+		if strings.Contains(string(data), secret) {
+			t.Errorf("fixture contains %q", secret)
+		}
+	}
+}
+
+// checkRedacted fails unless every recorded request carries the headers,
+// redacted.
+func checkRedacted(t *testing.T, interactions []replay.Interaction, headers ...string) {
+	t.Helper()
+	for _, in := range interactions {
+		for _, h := range headers {
+			if got := in.Request.Header.Get(h); got != replay.Redacted {
+				t.Errorf("%s %s: header %s = %q, want %q", in.Request.Method, in.Request.URL, h, got, replay.Redacted)
+			}
+		}
+	}
+}
+
+func TestRecordReplayGateio(t *testing.T) {
+	ctx := context.Background()
+	srv := gateiotest.NewServer(paper.Config{Balance: decimal.NewFromInt(1000)})
+	defer srv.Close()
+	srv.AddContract(gateio.Ticker{Name: "BTC_USDT", OrderPriceRound: decimal.MustParse("0.1"), QuantoMultiplier: decimal.MustParse("0.01")})
+
+	rec := replay.NewRecorder(nil)
+	client := gateio.NewClient(gateiotest.APIKey, gateiotest.SecretKey, &http.Client{Transport: rec})
+	client.SetBaseURL(srv.URL)
+	client.SetLogger(nil)
+	account, err := client.GetFuturesAccount(ctx, "usdt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if account.User != gateiotest.UserID {
+		t.Fatalf("recorded user = %d, want %d", account.User, gateiotest.UserID)
+	}
+	contracts, err := client.ListFuturesContracts(ctx, "usdt")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	path := filepath.Join(t.TempDir(), "gateio.json")
+	if err := rec.Save(path); err != nil {
+		t.Fatal(err)
+	}
+	checkRedacted(t, rec.Interactions()[:1], "KEY", "SIGN")
+	checkFixture(t, path, gateiotest.APIKey, `"user":10001`)
+
+	rp, err := replay.Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	replayed := gateio.NewClient("", "", &http.Client{Transport: rp})
+	replayed.SetBaseURL(srv.URL) // Recorded URLs include the host
+	replayed.SetRetryPolicy(retry.Never)
+	replayed.SetLogger(nil)
+	got, err := replayed.GetFuturesAccount(ctx, "usdt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !got.Total.Equal(account.Total) || !got.Available.Equal(account.Available) || got.Currency != account.Currency {
+		t.Errorf("replayed account = %+v, want %+v", got, account)
+	}
+	if got.User != 0 {
+		t.Errorf("replayed user = %d, want the redacted 0", got.User)
+	}
+	gotContracts, err := replayed.ListFuturesContracts(ctx, "usdt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(*gotContracts) != 1 || (*gotContracts)[0].Name != (*contracts)[0].Name {
+		t.Errorf("replayed contracts = %+v", *gotContracts)
+	}
+	if unused := rp.Unused(); len(unused) != 0 {
+		t.Errorf("%d recorded interactions not replayed", len(unused))
+	}
+	if _, err := replayed.GetFuturesAccount(ctx, "usdt"); !errors.Is(err, replay.ErrNoInteraction) {
+		t.Errorf("request beyond the recording: %v, want ErrNoInteraction", err)
+	}
+}
+
+func TestRecordReplayXT(t *testing.T) {
+	ctx := context.Background()
+	srv := xttest.NewServer(paper.Config{Balance: decimal.NewFromInt(1000)})
+	defer srv.Close()
+
+	rec := replay.NewRecorder(nil)
+	client := xt.NewClient(xttest.APIKey, xttest.SecretKey, &http.Client{Transport: rec})
+	client.SetUsdtBaseURL(srv.URL)
+	client.SetLogger(nil)
+	info, err := client.GetAccountInfo(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	listenKey, err := client.GetListenKey(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Result.UserID != xttest.UserID || listenKey.Result.ListenKey != xttest.ListenKey {
+		t.Fatalf("recorded user %d, listen key %q", info.Result.UserID, listenKey.Result.ListenKey)
+	}
+
+	path := filepath.Join(t.TempDir(), "xt.json")
+	if err := rec.Save(path); err != nil {
+		t.Fatal(err)
+	}
+	checkRedacted(t, rec.Interactions(), "validate-appkey", "validate-signature")
+	checkFixture(t, path, xttest.APIKey, xttest.ListenKey, `"userId":20002`, `"accountId":30003`)
+
+	// XT rejects private calls without a key locally, so replay with a placeholder.
+	rp, err := replay.Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	replayed := xt.NewClient("placeholder", "placeholder", &http.Client{Transport: rp})
+	replayed.SetUsdtBaseURL(srv.URL)
+	replayed.SetLogger(nil)
+	gotInfo, err := replayed.GetAccountInfo(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !gotInfo.Result.AllowTrade || gotInfo.Result.UserID != 0 || gotInfo.Result.AccountID != 0 {
+		t.Errorf("replayed account info = %+v", gotInfo.Result)
+	}
+	gotKey, err := replayed.GetListenKey(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if gotKey.Result.ListenKey != replay.Redacted {
+		t.Errorf("replayed listen key = %q, want %q", gotKey.Result.ListenKey, replay.Redacted)
+	}
+	if unused := rp.Unused(); len(unused) != 0 {
+		t.Errorf("%d recorded interactions not replayed", len(unused))
+	}
+}
+
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) { return f(req) }
+
+func TestRedactJSONFields(t *testing.T) {
+	rec := replay.NewRecorder(roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		body := `{"uid":123,"items":[{"userId":"u-1","name":"kept"}],"listenKey":"lk"}`
+		return &http.Response{StatusCode: http.StatusOK, Header: http.Header{}, Body: io.NopCloser(strings.NewReader(body))}, nil
+	}))
+	req, _ := http.NewRequest(http.MethodGet, "http://venue.test/stream?listenKey=lk&symbol=btc_usdt", nil)
+	resp, err := rec.RoundTrip(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+
+	in := rec.Interactions()[0]
+	if strings.Contains(in.Request.URL, "lk") || !strings.Contains(in.Request.URL, "symbol=btc_usdt") {
+		t.Errorf("recorded URL = %s", in.Request.URL)
+	}
+	var body struct {
+		UID   json.Number `json:"uid"`
+		Items []struct {
+			UserID string `json:"userId"`
+			Name   string `json:"name"`
+		} `json:"items"`
+		ListenKey string `json:"listenKey"`
+	}
+	if err := json.Unmarshal([]byte(in.Response.Body), &body); err != nil {
+		t.Fatal(err)
+	}
+	if body.UID != "0" || body.Items[0].UserID != replay.Redacted || body.Items[0].Name != "kept" || body.ListenKey != replay.Redacted {
+		t.Errorf("recorded body = %s", in.Response.Body)
+	}
+}
@@ -0,0 +1,140 @@
+// Package retry decides whether a failed REST request may be sent again and
+// how long to wait before doing so.
+//
+// The connectors describe every completed attempt as an Attempt and ask their
+// Policy for the next step. Requests are only retried when repeating them is
+// safe: reads, cancellations, and order creation carrying a client order ID.
+// A 429 response is retried for any request since the venue rejected it
+// before executing it.
+package retry
+
+import (
+	"context"
+	"errors"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// Attempt describes a completed request attempt.
+type Attempt struct {
+	Number     int           // 1 for the first attempt
+	Method     string        // HTTP method
+	Path       string        // Endpoint path
+	Idempotent bool          // Whether the request can be repeated without side effects
+	StatusCode int           // HTTP status, 0 if no response was received
+	Err        error         // Transport error, nil if a response was received
+	RetryAfter time.Duration // Server-requested delay from the Retry-After header, 0 if absent
+}
+
+// Policy decides whether to retry an attempt and how long to wait first.
+type Policy interface {
+	Next(a *Attempt) (delay time.Duration, retry bool)
+}
+
+// Never is a Policy that does not retry.
+var Never Policy = never{}
+
+type never struct{}
+
+func (never) Next(*Attempt) (time.Duration, bool) { return 0, false }
+
+// Backoff retries retryable attempts with exponential backoff and full
+// jitter, waiting at least as long as the server's Retry-After.
+type Backoff struct {
+	MaxAttempts int           // Total attempts including the first
+	BaseDelay   time.Duration // Delay ceiling for the first retry, doubled per retry
+	MaxDelay    time.Duration // Upper bound of the delay ceiling
+}
+
+// DefaultPolicy returns the policy used by the connectors unless overridden:
+// up to 3 attempts, backoff starting at 200ms and capped at 5s.
+func DefaultPolicy() *Backoff {
+	return &Backoff{MaxAttempts: 3, BaseDelay: 200 * time.Millisecond, MaxDelay: 5 * time.Second}
+}
+
+// Next implements Policy.
+func (b *Backoff) Next(a *Attempt) (time.Duration, bool) {
+	if a.Number >= b.MaxAttempts || !Retryable(a) {
+		return 0, false
+	}
+	ceiling := b.BaseDelay << (a.Number - 1)
+	if ceiling <= 0 || ceiling > b.MaxDelay {
+		ceiling = b.MaxDelay
+	}
+	delay := time.Duration(rand.Int64N(int64(ceiling) + 1))
+	return max(delay, a.RetryAfter), true
+}
+
+// Retryable reports whether an attempt failed in a way that is worth
+// retrying and safe to retry: a 429 for any request, or a transport error or
+// 5xx gateway failure for idempotent requests.
+func Retryable(a *Attempt) bool {
+	if a.StatusCode == http.StatusTooManyRequests {
+		return true
+	}
+	if !a.Idempotent {
+		return false
+	}
+	if a.Err != nil {
+		return !errors.Is(a.Err, context.Canceled) && !errors.Is(a.Err, context.DeadlineExceeded) && !IsPermanent(a.Err)
+	}
+	switch a.StatusCode {
+	case http.StatusInternalServerError, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+// Permanent marks err as a failure that sending the request again cannot fix,
+// such as an error of the credentials provider or the signer. The clients
+// return such errors without consulting their Policy.
+func Permanent(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &permanentError{err}
+}
+
+// IsPermanent reports whether err, or an error it wraps, was marked with
+// Permanent.
+func IsPermanent(err error) bool {
+	var p *permanentError
+	return errors.As(err, &p)
+}
+
+type permanentError struct{ err error }
+
+func (e *permanentError) Error() string { return e.err.Error() }
+func (e *permanentError) Unwrap() error { return e.err }
+
+// ParseRetryAfter parses a Retry-After header given either as seconds or as
+// an HTTP date. It returns 0 if the header is absent or invalid.
+func ParseRetryAfter(header string, now time.Time) time.Duration {
+	if header == "" {
+		return 0
+	}
+	if secs, err := strconv.Atoi(header); err == nil && secs > 0 {
+		return time.Duration(secs) * time.Second
+	}
+	if t, err := http.ParseTime(header); err == nil && t.After(now) {
+		return t.Sub(now)
+	}
+	return 0
+}
+
+// Sleep waits for d or until ctx is done, whichever comes first.
+func Sleep(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
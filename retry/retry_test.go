@@ -0,0 +1,115 @@
+package retry_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/neqin/futures/retry"
+)
+
+func TestBackoffNext(t *testing.T) {
+	b := &retry.Backoff{MaxAttempts: 5, BaseDelay: 100 * time.Millisecond, MaxDelay: 300 * time.Millisecond}
+	tests := []struct {
+		name    string
+		attempt retry.Attempt
+		retry   bool
+		ceiling time.Duration // Upper bound of the jittered delay
+		floor   time.Duration // Lower bound, from Retry-After
+	}{
+		{"first retry", retry.Attempt{Number: 1, Idempotent: true, StatusCode: http.StatusBadGateway}, true, 100 * time.Millisecond, 0},
+		{"ceiling doubles", retry.Attempt{Number: 2, Idempotent: true, StatusCode: http.StatusBadGateway}, true, 200 * time.Millisecond, 0},
+		{"ceiling capped", retry.Attempt{Number: 4, Idempotent: true, StatusCode: http.StatusBadGateway}, true, 300 * time.Millisecond, 0},
+		{"attempts exhausted", retry.Attempt{Number: 5, Idempotent: true, StatusCode: http.StatusBadGateway}, false, 0, 0},
+		{"not retryable", retry.Attempt{Number: 1, StatusCode: http.StatusBadGateway}, false, 0, 0},
+		{"retry after", retry.Attempt{Number: 1, StatusCode: http.StatusTooManyRequests, RetryAfter: 2 * time.Second}, true, 2 * time.Second, 2 * time.Second},
+	}
+	for _, tt := range tests {
+		for i := 0; i < 200; i++ { // Sample the jitter
+			delay, ok := b.Next(&tt.attempt)
+			if ok != tt.retry {
+				t.Errorf("%s: retry = %t, want %t", tt.name, ok, tt.retry)
+				break
+			}
+			if ok && (delay < tt.floor || delay > tt.ceiling) {
+				t.Errorf("%s: delay %v outside [%v, %v]", tt.name, delay, tt.floor, tt.ceiling)
+				break
+			}
+		}
+	}
+}
+
+func TestBackoffJitterSpread(t *testing.T) {
+	b := &retry.Backoff{MaxAttempts: 2, BaseDelay: time.Second, MaxDelay: time.Second}
+	a := &retry.Attempt{Number: 1, Idempotent: true, Err: errors.New("connection reset")}
+	var low, high bool
+	for i := 0; i < 1000 && !(low && high); i++ {
+		delay, _ := b.Next(a)
+		low = low || delay < 250*time.Millisecond
+		high = high || delay > 750*time.Millisecond
+	}
+	if !low || !high {
+		t.Errorf("delays not spread over [0, 1s]: saw low %t, high %t", low, high)
+	}
+}
+
+func TestRetryable(t *testing.T) {
+	transport := errors.New("connection reset")
+	tests := []struct {
+		name string
+		a    retry.Attempt
+		want bool
+	}{
+		{"429 on a non-idempotent request", retry.Attempt{StatusCode: http.StatusTooManyRequests}, true},
+		{"503 on a non-idempotent request", retry.Attempt{StatusCode: http.StatusServiceUnavailable}, false},
+		{"503", retry.Attempt{Idempotent: true, StatusCode: http.StatusServiceUnavailable}, true},
+		{"400", retry.Attempt{Idempotent: true, StatusCode: http.StatusBadRequest}, false},
+		{"transport error", retry.Attempt{Idempotent: true, Err: transport}, true},
+		{"cancelled", retry.Attempt{Idempotent: true, Err: fmt.Errorf("send: %w", context.Canceled)}, false},
+		{"deadline", retry.Attempt{Idempotent: true, Err: context.DeadlineExceeded}, false},
+		{"permanent", retry.Attempt{Idempotent: true, Err: fmt.Errorf("sign: %w", retry.Permanent(transport))}, false},
+	}
+	for _, tt := range tests {
+		if got := retry.Retryable(&tt.a); got != tt.want {
+			t.Errorf("%s: got %t, want %t", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestPermanent(t *testing.T) {
+	cause := errors.New("vault unavailable")
+	err := retry.Permanent(cause)
+	if !retry.IsPermanent(err) || !errors.Is(err, cause) || err.Error() != cause.Error() {
+		t.Errorf("Permanent(%v) = %v", cause, err)
+	}
+	if retry.IsPermanent(cause) {
+		t.Error("unmarked error reported as permanent")
+	}
+	if retry.Permanent(nil) != nil {
+		t.Error("Permanent(nil) != nil")
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		header string
+		want   time.Duration
+	}{
+		{"", 0},
+		{"3", 3 * time.Second},
+		{"0", 0},
+		{"-5", 0},
+		{"soon", 0},
+		{now.Add(90 * time.Second).Format(http.TimeFormat), 90 * time.Second},
+		{now.Add(-time.Minute).Format(http.TimeFormat), 0}, // In the past
+	}
+	for _, tt := range tests {
+		if got := retry.ParseRetryAfter(tt.header, now); got != tt.want {
+			t.Errorf("ParseRetryAfter(%q) = %v, want %v", tt.header, got, tt.want)
+		}
+	}
+}
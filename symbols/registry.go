@@ -0,0 +1,166 @@
+package symbols
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Registry holds the contracts of several venues, keyed by venue and
+// canonical symbol. A Registry is safe for concurrent use.
+type Registry struct {
+	loaders []Loader
+
+	mu        sync.RWMutex
+	byVenue   map[string]map[string]Contract // venue -> canonical symbol -> contract
+	byName    map[string]map[string]string   // venue -> venue symbol -> canonical symbol
+	refreshed map[string]time.Time           // venue -> time of the last successful load
+
+	errs chan error
+}
+
+// NewRegistry creates an empty registry for the given loaders. Call Refresh
+// or Run to populate it.
+func NewRegistry(loaders ...Loader) *Registry {
+	return &Registry{
+		loaders:   loaders,
+		byVenue:   make(map[string]map[string]Contract),
+		byName:    make(map[string]map[string]string),
+		refreshed: make(map[string]time.Time),
+		errs:      make(chan error, 16),
+	}
+}
+
+// Refresh reloads every venue and returns the joined errors of the venues
+// that failed. A venue whose loader fails keeps its previous contracts, updated
+// with any contracts the loader still returned.
+func (r *Registry) Refresh(ctx context.Context) error {
+	var errs []error
+	for _, l := range r.loaders {
+		contracts, err := l.LoadContracts(ctx)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("symbols: load %s: %w", l.Venue(), err))
+			r.update(l.Venue(), contracts, false)
+			continue
+		}
+		r.Set(l.Venue(), contracts)
+	}
+	return errors.Join(errs...)
+}
+
+// Set replaces the contracts of venue, e.g. to seed the registry from a
+// cached file or in tests.
+func (r *Registry) Set(venue string, contracts []Contract) {
+	r.update(venue, contracts, true)
+}
+
+func (r *Registry) update(venue string, contracts []Contract, replace bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	bySymbol, byName := r.byVenue[venue], r.byName[venue]
+	if replace || bySymbol == nil {
+		bySymbol = make(map[string]Contract, len(contracts))
+		byName = make(map[string]string, len(contracts))
+	}
+	for _, c := range contracts {
+		c.Venue = venue
+		bySymbol[c.Symbol] = c
+		byName[c.VenueSymbol] = c.Symbol
+	}
+	r.byVenue[venue] = bySymbol
+	r.byName[venue] = byName
+	if replace {
+		r.refreshed[venue] = time.Now()
+	}
+}
+
+// Run refreshes the registry immediately and then every interval until ctx is
+// done, and then returns ctx.Err(). Refresh errors are reported on Errors.
+func (r *Registry) Run(ctx context.Context, interval time.Duration) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		if err := r.Refresh(ctx); err != nil && ctx.Err() == nil {
+			select {
+			case r.errs <- err:
+			default:
+			}
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// Errors returns a channel of refresh errors raised by Run. Errors are
+// dropped if the channel is not drained.
+func (r *Registry) Errors() <-chan error {
+	return r.errs
+}
+
+// Get returns the contract of a canonical symbol on venue.
+func (r *Registry) Get(venue, symbol string) (Contract, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	c, ok := r.byVenue[venue][Canonical(symbol)]
+	return c, ok
+}
+
+// Lookup returns the contract with the venue's own symbol name.
+func (r *Registry) Lookup(venue, venueSymbol string) (Contract, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	symbol, ok := r.byName[venue][venueSymbol]
+	if !ok {
+		return Contract{}, false
+	}
+	c, ok := r.byVenue[venue][symbol]
+	return c, ok
+}
+
+// VenueSymbol returns the name venue uses for a canonical symbol.
+func (r *Registry) VenueSymbol(venue, symbol string) (string, bool) {
+	c, ok := r.Get(venue, symbol)
+	return c.VenueSymbol, ok
+}
+
+// Listings returns the contracts of a canonical symbol on every venue that
+// lists it, ordered by venue.
+func (r *Registry) Listings(symbol string) []Contract {
+	symbol = Canonical(symbol)
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	var result []Contract
+	for _, contracts := range r.byVenue {
+		if c, ok := contracts[symbol]; ok {
+			result = append(result, c)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Venue < result[j].Venue })
+	return result
+}
+
+// Symbols returns the sorted canonical symbols listed on venue.
+func (r *Registry) Symbols(venue string) []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	result := make([]string, 0, len(r.byVenue[venue]))
+	for symbol := range r.byVenue[venue] {
+		result = append(result, symbol)
+	}
+	sort.Strings(result)
+	return result
+}
+
+// Refreshed returns when the contracts of venue were last loaded, or the zero
+// time if they never were.
+func (r *Registry) Refreshed(venue string) time.Time {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.refreshed[venue]
+}
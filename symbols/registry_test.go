@@ -0,0 +1,150 @@
+package symbols_test
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/neqin/futures/decimal"
+	"github.com/neqin/futures/symbols"
+)
+
+// fakeLoader returns the queued results of successive loads.
+type fakeLoader struct {
+	venue   string
+	results []loadResult
+}
+
+type loadResult struct {
+	contracts []symbols.Contract
+	err       error
+}
+
+func (l *fakeLoader) Venue() string { return l.venue }
+
+func (l *fakeLoader) LoadContracts(ctx context.Context) ([]symbols.Contract, error) {
+	r := l.results[0]
+	l.results = l.results[1:]
+	return r.contracts, r.err
+}
+
+func contract(symbol, venueSymbol, tick string) symbols.Contract {
+	return symbols.Contract{Symbol: symbol, VenueSymbol: venueSymbol, TickSize: decimal.MustParse(tick)}
+}
+
+func TestRefreshPartialFailure(t *testing.T) {
+	ctx := context.Background()
+	failed := errors.New("ETH_USDT: malformed contract")
+	gate := &fakeLoader{venue: "gateio", results: []loadResult{
+		{contracts: []symbols.Contract{contract("BTC_USDT", "BTC_USDT", "0.1"), contract("ETH_USDT", "ETH_USDT", "0.01")}},
+		{contracts: []symbols.Contract{contract("BTC_USDT", "BTC_USDT", "0.5"), contract("SOL_USDT", "SOL_USDT", "0.001")}, err: failed},
+		{err: failed},
+		{contracts: []symbols.Contract{contract("SOL_USDT", "SOL_USDT", "0.01")}},
+	}}
+	xt := &fakeLoader{venue: "xt", results: []loadResult{
+		{contracts: []symbols.Contract{contract("BTC_USDT", "btc_usdt", "0.1")}},
+		{contracts: []symbols.Contract{contract("ETH_USDT", "eth_usdt", "0.01")}},
+		{contracts: []symbols.Contract{contract("ETH_USDT", "eth_usdt", "0.01")}},
+		{contracts: []symbols.Contract{contract("ETH_USDT", "eth_usdt", "0.01")}},
+	}}
+	r := symbols.NewRegistry(gate, xt)
+
+	tick := func(venue, symbol string) string {
+		c, ok := r.Get(venue, symbol)
+		if !ok {
+			return "unlisted"
+		}
+		return c.TickSize.String()
+	}
+
+	if err := r.Refresh(ctx); err != nil {
+		t.Fatal(err)
+	}
+	loaded := r.Refreshed("gateio")
+	if loaded.IsZero() || tick("xt", "btc_usdt") != "0.1" {
+		t.Fatalf("after the first refresh: refreshed %s, xt BTC_USDT tick %s", loaded, tick("xt", "BTC_USDT"))
+	}
+	if c, ok := r.Lookup("xt", "btc_usdt"); !ok || c.Symbol != "BTC_USDT" || c.Venue != "xt" {
+		t.Errorf("Lookup(xt, btc_usdt) = %+v, %t", c, ok)
+	}
+	time.Sleep(time.Millisecond) // Let a successful refresh change the timestamp
+
+	// The gateio loader fails but still returns part of the list: the returned
+	// contracts are updated and the others kept; the xt list is replaced.
+	err := r.Refresh(ctx)
+	if !errors.Is(err, failed) {
+		t.Fatalf("partial failure: %v, want the loader error", err)
+	}
+	steps := []struct {
+		name  string
+		gate  map[string]string // Symbol -> tick
+		xt    []string
+		fresh bool // Gate.io refresh time updated
+	}{
+		{"partial failure", map[string]string{"BTC_USDT": "0.5", "ETH_USDT": "0.01", "SOL_USDT": "0.001"}, []string{"ETH_USDT"}, false},
+		{"failure without contracts", map[string]string{"BTC_USDT": "0.5", "ETH_USDT": "0.01", "SOL_USDT": "0.001"}, []string{"ETH_USDT"}, false},
+		{"success replaces", map[string]string{"SOL_USDT": "0.01"}, []string{"ETH_USDT"}, true},
+	}
+	for i, s := range steps {
+		if i > 0 {
+			err = r.Refresh(ctx)
+			if (err != nil) == s.fresh {
+				t.Errorf("%s: refresh error %v", s.name, err)
+			}
+		}
+		var listed []string
+		for symbol, want := range s.gate {
+			listed = append(listed, symbol)
+			if got := tick("gateio", symbol); got != want {
+				t.Errorf("%s: gateio %s tick %s, want %s", s.name, symbol, got, want)
+			}
+		}
+		slices.Sort(listed)
+		if got := r.Symbols("gateio"); !slices.Equal(got, listed) {
+			t.Errorf("%s: gateio lists %v, want %v", s.name, got, listed)
+		}
+		if got := r.Symbols("xt"); !slices.Equal(got, s.xt) {
+			t.Errorf("%s: xt lists %v, want %v", s.name, got, s.xt)
+		}
+		if fresh := r.Refreshed("gateio").After(loaded); fresh != s.fresh {
+			t.Errorf("%s: gateio refresh time updated %t, want %t", s.name, fresh, s.fresh)
+		}
+	}
+	if _, ok := r.Lookup("gateio", "BTC_USDT"); ok {
+		t.Error("contract dropped by a successful refresh still found by venue symbol")
+	}
+}
+
+func TestRefreshFirstLoadFails(t *testing.T) {
+	failed := errors.New("timeout")
+	r := symbols.NewRegistry(&fakeLoader{venue: "xt", results: []loadResult{
+		{contracts: []symbols.Contract{contract("BTC_USDT", "btc_usdt", "0.1")}, err: failed},
+	}})
+	if err := r.Refresh(context.Background()); !errors.Is(err, failed) {
+		t.Fatalf("got %v, want the loader error", err)
+	}
+	if got := r.Symbols("xt"); !slices.Equal(got, []string{"BTC_USDT"}) || !r.Refreshed("xt").IsZero() {
+		t.Errorf("lists %v, refreshed %s; want the returned contract and no refresh time", got, r.Refreshed("xt"))
+	}
+}
+
+func TestListings(t *testing.T) {
+	r := symbols.NewRegistry()
+	r.Set("xt", []symbols.Contract{contract("BTC_USDT", "btc_usdt", "0.1")})
+	r.Set("gateio", []symbols.Contract{contract("BTC_USDT", "BTC_USDT", "0.1"), contract("ETH_USDT", "ETH_USDT", "0.01")})
+	var venues []string
+	for _, c := range r.Listings("btc-usdt") {
+		venues = append(venues, c.Venue)
+	}
+	if !slices.Equal(venues, []string{"gateio", "xt"}) {
+		t.Errorf("BTC_USDT listed on %v, want gateio and xt", venues)
+	}
+	if name, ok := r.VenueSymbol("xt", "BTC_USDT"); !ok || name != "btc_usdt" {
+		t.Errorf("VenueSymbol(xt, BTC_USDT) = %q, %t", name, ok)
+	}
+	if _, ok := r.Get("xt", "ETH_USDT"); ok {
+		t.Error("ETH_USDT found on xt")
+	}
+}
@@ -0,0 +1,95 @@
+// Package symbols maps canonical symbols to the contracts listed on each
+// venue and exposes their trading rules in a venue-neutral form.
+//
+// Canonical symbols follow the exchange package: upper-case "BASE_QUOTE"
+// (e.g. "BTC_USDT"). Each connector provides a Loader that lists the venue's
+// contracts (gateio.NewSymbolLoader, xt.NewSymbolLoader); a Registry combines
+// any number of loaders and refreshes them periodically.
+package symbols
+
+import (
+	"context"
+	"strings"
+
+	"github.com/neqin/futures/decimal"
+)
+
+// Contract describes one tradable contract on one venue. Sizes are in the
+// venue's contract units, as everywhere else in this module.
+type Contract struct {
+	Symbol      string // Canonical symbol, e.g. "BTC_USDT"
+	Venue       string // Venue identifier, e.g. "gateio", "xt"
+	VenueSymbol string // Name used by the venue's API, e.g. "BTC_USDT" or "btc_usdt"
+	Base        string // Base currency, upper-case
+	Quote       string // Quote currency, upper-case
+	Settle      string // Settlement currency, upper-case
+	Inverse     bool   // Coin-margined: margin and PnL are booked in the base currency
+
+	TickSize    decimal.Decimal // Minimum price increment
+	LotSize     decimal.Decimal // Minimum size increment, in contracts
+	MinSize     decimal.Decimal // Minimum order size, in contracts (zero if none)
+	MaxSize     decimal.Decimal // Maximum order size, in contracts (zero if none)
+	Multiplier  decimal.Decimal // Contract size: base currency per contract, or quote value per contract if Inverse
+	MinNotional decimal.Decimal // Minimum order value in the quote currency (zero if none)
+	MaxNotional decimal.Decimal // Maximum order value in the quote currency (zero if none)
+
+	PricePrecision    int32 // Decimal places of TickSize
+	QuantityPrecision int32 // Decimal places of LotSize
+}
+
+// RoundPrice rounds price to the nearest tick.
+func (c Contract) RoundPrice(price decimal.Decimal) decimal.Decimal {
+	return price.RoundToTick(c.TickSize)
+}
+
+// RoundSize rounds size down to a whole number of lots.
+func (c Contract) RoundSize(size decimal.Decimal) decimal.Decimal {
+	if size.Sign() < 0 {
+		return size.CeilToTick(c.LotSize)
+	}
+	return size.FloorToTick(c.LotSize)
+}
+
+// Notional returns the quote value of size contracts at price.
+func (c Contract) Notional(size, price decimal.Decimal) decimal.Decimal {
+	if c.Inverse {
+		return size.Abs().Mul(c.Multiplier)
+	}
+	return size.Abs().Mul(c.Multiplier).Mul(price)
+}
+
+// Loader lists the contracts of one venue.
+type Loader interface {
+	// Venue returns the venue identifier the contracts belong to.
+	Venue() string
+	// LoadContracts fetches the venue's current contract list.
+	LoadContracts(ctx context.Context) ([]Contract, error)
+}
+
+// Canonical converts a venue symbol such as "btc_usdt" or "BTC-USDT" to the
+// canonical "BTC_USDT" form.
+func Canonical(symbol string) string {
+	return strings.ToUpper(strings.ReplaceAll(symbol, "-", "_"))
+}
+
+// SplitSymbol returns the base and quote currencies of a "BASE_QUOTE" symbol.
+func SplitSymbol(symbol string) (base, quote string) {
+	base, quote, _ = strings.Cut(Canonical(symbol), "_")
+	return base, quote
+}
+
+// Precision returns the number of decimal places needed to represent step,
+// e.g. 2 for "0.01" and 0 for "5" (at most 18).
+func Precision(step decimal.Decimal) int32 {
+	for p := int32(0); p < 18; p++ {
+		if step.Truncate(p).Equal(step) {
+			return p
+		}
+	}
+	return 18
+}
+
+// Step returns 10^-precision, e.g. "0.001" for 3.
+func Step(precision int32) decimal.Decimal {
+	return decimal.New(1, -precision)
+}
@@ -0,0 +1,101 @@
+package symbols_test
+
+import (
+	"testing"
+
+	"github.com/neqin/futures/decimal"
+	"github.com/neqin/futures/symbols"
+)
+
+func TestPrecision(t *testing.T) {
+	tests := []struct {
+		step string
+		want int32
+	}{
+		{"0.01", 2},
+		{"0.5", 1},
+		{"5", 0},
+		{"10", 0},
+		{"0.00010000", 4}, // Trailing zeros do not count
+		{"0.0000000000000000001", 18},
+	}
+	for _, tt := range tests {
+		if got := symbols.Precision(decimal.MustParse(tt.step)); got != tt.want {
+			t.Errorf("Precision(%s) = %d, want %d", tt.step, got, tt.want)
+		}
+	}
+	for p := int32(0); p <= 8; p++ {
+		if got := symbols.Precision(symbols.Step(p)); got != p {
+			t.Errorf("Precision(Step(%d)) = %d", p, got)
+		}
+	}
+}
+
+func TestCanonical(t *testing.T) {
+	tests := []struct {
+		symbol, canonical, base, quote string
+	}{
+		{"btc_usdt", "BTC_USDT", "BTC", "USDT"},
+		{"BTC-USDT", "BTC_USDT", "BTC", "USDT"},
+		{"BTC_USDT", "BTC_USDT", "BTC", "USDT"},
+		{"btc", "BTC", "BTC", ""},
+	}
+	for _, tt := range tests {
+		base, quote := symbols.SplitSymbol(tt.symbol)
+		if got := symbols.Canonical(tt.symbol); got != tt.canonical || base != tt.base || quote != tt.quote {
+			t.Errorf("%s: canonical %s, split %s/%s; want %s, %s/%s", tt.symbol, got, base, quote, tt.canonical, tt.base, tt.quote)
+		}
+	}
+}
+
+func TestRounding(t *testing.T) {
+	c := symbols.Contract{TickSize: decimal.MustParse("0.5"), LotSize: decimal.MustParse("0.1")}
+	sizes := []struct {
+		size, want string
+	}{
+		{"1.27", "1.2"},
+		{"1.2", "1.2"},
+		{"-1.27", "-1.2"}, // Towards zero, like positive sizes
+		{"-0.05", "0"},
+		{"0.09", "0"},
+	}
+	for _, tt := range sizes {
+		if got := c.RoundSize(decimal.MustParse(tt.size)); !got.Equal(decimal.MustParse(tt.want)) {
+			t.Errorf("RoundSize(%s) = %s, want %s", tt.size, got, tt.want)
+		}
+	}
+	prices := []struct {
+		price, want string
+	}{
+		{"100.2", "100"},
+		{"100.3", "100.5"},
+		{"100.75", "101"}, // Halfway rounds up
+	}
+	for _, tt := range prices {
+		if got := c.RoundPrice(decimal.MustParse(tt.price)); !got.Equal(decimal.MustParse(tt.want)) {
+			t.Errorf("RoundPrice(%s) = %s, want %s", tt.price, got, tt.want)
+		}
+	}
+}
+
+func TestNotional(t *testing.T) {
+	tests := []struct {
+		name    string
+		inverse bool
+		mult    string
+		size    string
+		price   string
+		want    string
+	}{
+		{"linear", false, "0.001", "3", "50000", "150"},
+		{"linear short", false, "0.001", "-3", "50000", "150"},
+		{"inverse", true, "10", "3", "50000", "30"}, // 10 USD per contract whatever the price
+		{"inverse short", true, "10", "-3", "20000", "30"},
+	}
+	for _, tt := range tests {
+		c := symbols.Contract{Inverse: tt.inverse, Multiplier: decimal.MustParse(tt.mult)}
+		if got := c.Notional(decimal.MustParse(tt.size), decimal.MustParse(tt.price)); !got.Equal(decimal.MustParse(tt.want)) {
+			t.Errorf("%s: got %s, want %s", tt.name, got, tt.want)
+		}
+	}
+}
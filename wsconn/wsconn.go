@@ -0,0 +1,271 @@
+// Package wsconn keeps the single websocket connection a connector's
+// WSClient shares between all of its subscriptions.
+//
+// A Conn dials lazily, sends the venue's keep-alive frame, re-dials with
+// exponential backoff when the connection drops and hands every frame it
+// reads to the connector, which decodes it and routes acknowledgements and
+// pushes. The read loop must never wait on a slow subscriber: Deliver drops
+// a push whose subscription buffer is full and reports it on Errors.
+package wsconn
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+var (
+	// ErrClosed is returned when using a Conn after Close has been called.
+	ErrClosed = errors.New("websocket connection closed")
+	// ErrBufferFull is reported on Errors when a push is dropped because the
+	// consumer of its subscription has fallen behind.
+	ErrBufferFull = errors.New("subscription buffer full, update dropped")
+)
+
+// Config describes the venue side of a Conn.
+type Config struct {
+	Dialer            *websocket.Dialer // If nil, websocket.DefaultDialer is used
+	PingInterval      time.Duration     // How often Ping is sent
+	ReadTimeout       time.Duration     // The connection is considered dead after this long without a frame
+	WriteTimeout      time.Duration     // Deadline of every write, and of every re-dial
+	MaxReconnectDelay time.Duration     // Cap of the exponential re-dial backoff
+
+	// Ping returns the keep-alive frame, sent as a text message.
+	Ping func() []byte
+	// Handle is called by the read loop with every frame. It must not block.
+	Handle func(data []byte)
+	// Resubscribe is called with the new connection after a reconnect to
+	// replay the active subscriptions. It runs on the reconnecting goroutine
+	// and may wait for acknowledgements.
+	Resubscribe func(conn *websocket.Conn)
+}
+
+// Conn is a lazily dialed, self-healing websocket connection.
+type Conn struct {
+	cfg Config
+
+	mu           sync.Mutex
+	url          string
+	conn         *websocket.Conn
+	reconnecting bool // A dropped connection is being re-dialed
+	closed       bool
+
+	writeMu sync.Mutex
+	errs    chan error
+	closeCh chan struct{}
+}
+
+// New returns a Conn to url. Nothing is dialed until Connect is called.
+func New(url string, cfg Config) *Conn {
+	if cfg.Dialer == nil {
+		cfg.Dialer = websocket.DefaultDialer
+	}
+	return &Conn{
+		cfg:     cfg,
+		url:     url,
+		errs:    make(chan error, 16),
+		closeCh: make(chan struct{}),
+	}
+}
+
+// SetURL changes the endpoint used by the next dial.
+func (c *Conn) SetURL(url string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.url = url
+}
+
+// Errors returns a channel of asynchronous errors (lost connections, failed
+// re-dials and whatever the connector reports). Errors are dropped if the
+// channel is not drained.
+func (c *Conn) Errors() <-chan error {
+	return c.errs
+}
+
+// Report queues err on Errors without blocking.
+func (c *Conn) Report(err error) {
+	select {
+	case c.errs <- err:
+	default:
+	}
+}
+
+// Done returns a channel closed by Close.
+func (c *Conn) Done() <-chan struct{} {
+	return c.closeCh
+}
+
+// Current returns the live connection, or nil while none is established.
+func (c *Conn) Current() *websocket.Conn {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.conn
+}
+
+// Connect dials the server if there is no live connection and no reconnect
+// in progress; in the latter case Current stays nil until the reconnect
+// succeeds, and subscriptions registered meanwhile are replayed by it. The
+// dial runs without the lock held so that a slow handshake does not stall
+// the read loop or other callers; if another caller connected in the
+// meantime, the new connection is discarded.
+func (c *Conn) Connect(ctx context.Context) error {
+	c.mu.Lock()
+	closed, connected := c.closed, c.conn != nil || c.reconnecting
+	c.mu.Unlock()
+	if closed {
+		return ErrClosed
+	}
+	if connected {
+		return nil
+	}
+
+	conn, err := c.dial(ctx)
+	if err != nil {
+		return err
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.closed {
+		conn.Close()
+		return ErrClosed
+	}
+	if c.conn != nil || c.reconnecting {
+		conn.Close()
+		return nil
+	}
+	c.conn = conn
+	go c.readLoop(conn)
+	go c.pingLoop(conn)
+	return nil
+}
+
+func (c *Conn) dial(ctx context.Context) (*websocket.Conn, error) {
+	c.mu.Lock()
+	url := c.url
+	c.mu.Unlock()
+	conn, _, err := c.cfg.Dialer.DialContext(ctx, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to dial %s: %w", url, err)
+	}
+	conn.SetReadDeadline(time.Now().Add(c.cfg.ReadTimeout))
+	return conn, nil
+}
+
+// Write sends a text frame on conn. Gorilla connections allow one
+// concurrent writer.
+func (c *Conn) Write(conn *websocket.Conn, data []byte) error {
+	c.writeMu.Lock()
+	defer c.writeMu.Unlock()
+	conn.SetWriteDeadline(time.Now().Add(c.cfg.WriteTimeout))
+	return conn.WriteMessage(websocket.TextMessage, data)
+}
+
+// Deliver hands a push to a subscription without blocking. If the
+// subscription's buffer is full the push is dropped and reported on Errors
+// as ErrBufferFull, labelled with name.
+func (c *Conn) Deliver(ch chan<- json.RawMessage, data json.RawMessage, name string) {
+	select {
+	case ch <- data:
+	default:
+		c.Report(fmt.Errorf("%s: %w", name, ErrBufferFull))
+	}
+}
+
+// Close terminates the connection and stops reconnecting.
+func (c *Conn) Close() error {
+	c.mu.Lock()
+	if c.closed {
+		c.mu.Unlock()
+		return nil
+	}
+	c.closed = true
+	close(c.closeCh)
+	conn := c.conn
+	c.conn = nil
+	c.mu.Unlock()
+
+	if conn != nil {
+		return conn.Close()
+	}
+	return nil
+}
+
+func (c *Conn) pingLoop(conn *websocket.Conn) {
+	ticker := time.NewTicker(c.cfg.PingInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			if err := c.Write(conn, c.cfg.Ping()); err != nil {
+				return // readLoop notices the broken connection and reconnects
+			}
+		case <-c.closeCh:
+			return
+		}
+	}
+}
+
+func (c *Conn) readLoop(conn *websocket.Conn) {
+	for {
+		_, data, err := conn.ReadMessage()
+		if err != nil {
+			conn.Close()
+			c.reconnect(conn, err)
+			return
+		}
+		conn.SetReadDeadline(time.Now().Add(c.cfg.ReadTimeout))
+		c.cfg.Handle(data)
+	}
+}
+
+// reconnect re-dials with exponential backoff after the connection drops
+// and lets the connector replay its subscriptions.
+func (c *Conn) reconnect(old *websocket.Conn, cause error) {
+	c.mu.Lock()
+	if c.closed || c.conn != old {
+		c.mu.Unlock()
+		return
+	}
+	c.conn = nil
+	c.reconnecting = true
+	c.mu.Unlock()
+	c.Report(fmt.Errorf("websocket connection lost, reconnecting: %w", cause))
+
+	delay := time.Second
+	for {
+		select {
+		case <-c.closeCh:
+			return
+		case <-time.After(delay):
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), c.cfg.WriteTimeout)
+		conn, err := c.dial(ctx)
+		cancel()
+		if err != nil {
+			c.Report(err)
+			delay = min(delay*2, c.cfg.MaxReconnectDelay)
+			continue
+		}
+
+		c.mu.Lock()
+		if c.closed {
+			c.mu.Unlock()
+			conn.Close()
+			return
+		}
+		c.conn = conn
+		c.reconnecting = false
+		c.mu.Unlock()
+
+		go c.readLoop(conn)
+		go c.pingLoop(conn)
+		c.cfg.Resubscribe(conn)
+		return
+	}
+}
@@ -0,0 +1,128 @@
+package wsconn_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/neqin/futures/wsconn"
+)
+
+// newServer greets every connection with its number and drops the first one.
+func newServer(t *testing.T) (*httptest.Server, string) {
+	t.Helper()
+	var conns atomic.Int64
+	upgrader := websocket.Upgrader{}
+	srv := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(rw, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		n := conns.Add(1)
+		conn.WriteMessage(websocket.TextMessage, []byte{byte('0' + n)})
+		if n == 1 {
+			return
+		}
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				return
+			}
+		}
+	}))
+	t.Cleanup(srv.Close)
+	return srv, "ws" + strings.TrimPrefix(srv.URL, "http")
+}
+
+func config(frames chan<- string, resubscribed chan<- struct{}) wsconn.Config {
+	return wsconn.Config{
+		PingInterval:      time.Minute,
+		ReadTimeout:       5 * time.Second,
+		WriteTimeout:      5 * time.Second,
+		MaxReconnectDelay: time.Second,
+		Ping:              func() []byte { return []byte("ping") },
+		Handle:            func(data []byte) { frames <- string(data) },
+		Resubscribe:       func(*websocket.Conn) { resubscribed <- struct{}{} },
+	}
+}
+
+func TestReconnect(t *testing.T) {
+	ctx := context.Background()
+	_, url := newServer(t)
+	frames := make(chan string, 4)
+	resubscribed := make(chan struct{}, 1)
+	c := wsconn.New(url, config(frames, resubscribed))
+	defer c.Close()
+
+	if err := c.Connect(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if got := <-frames; got != "1" {
+		t.Errorf("first frame = %q, want 1", got)
+	}
+	select {
+	case err := <-c.Errors():
+		if !strings.Contains(err.Error(), "connection lost") {
+			t.Errorf("reported %v, want the lost connection", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("dropped connection not reported")
+	}
+	select {
+	case <-resubscribed:
+	case <-time.After(5 * time.Second):
+		t.Fatal("subscriptions not replayed after the reconnect")
+	}
+	if got := <-frames; got != "2" {
+		t.Errorf("frame after reconnect = %q, want 2", got)
+	}
+	if c.Current() == nil {
+		t.Error("no live connection after the reconnect")
+	}
+	if err := c.Connect(ctx); err != nil { // Already connected
+		t.Error(err)
+	}
+}
+
+func TestClose(t *testing.T) {
+	ctx := context.Background()
+	_, url := newServer(t)
+	c := wsconn.New(url, config(make(chan string, 4), make(chan struct{}, 1)))
+	if err := c.Close(); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case <-c.Done():
+	default:
+		t.Error("Done not closed by Close")
+	}
+	if err := c.Connect(ctx); !errors.Is(err, wsconn.ErrClosed) {
+		t.Errorf("Connect after Close: %v, want ErrClosed", err)
+	}
+}
+
+func TestDeliverDropsWhenFull(t *testing.T) {
+	c := wsconn.New("ws://unused.test", config(nil, nil))
+	ch := make(chan json.RawMessage, 1)
+	c.Deliver(ch, json.RawMessage(`1`), "tickers")
+	c.Deliver(ch, json.RawMessage(`2`), "tickers") // Must not block
+
+	if got := string(<-ch); got != "1" {
+		t.Errorf("delivered %s, want 1", got)
+	}
+	select {
+	case err := <-c.Errors():
+		if !errors.Is(err, wsconn.ErrBufferFull) || !strings.HasPrefix(err.Error(), "tickers: ") {
+			t.Errorf("reported %v, want ErrBufferFull for tickers", err)
+		}
+	default:
+		t.Error("dropped push not reported")
+	}
+}